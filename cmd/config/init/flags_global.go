@@ -4,16 +4,56 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/dymensionxyz/roller/utils/roller"
+	"github.com/dymensionxyz/roller/utils/tracing"
 )
 
 func AddGlobalFlags(command *cobra.Command) {
 	home := roller.GetRootDir()
 	command.PersistentFlags().StringP(
 		GlobalFlagNames.Home, "", home, "The directory of the roller config files")
+	command.PersistentFlags().String(
+		GlobalFlagNames.TraceExporter,
+		tracing.Exporters.None,
+		"Export traces of the executed commands and hub queries (otlp, file)",
+	)
+	command.PersistentFlags().String(
+		GlobalFlagNames.TraceEndpoint,
+		"",
+		"OTLP/HTTP collector endpoint (host:port) used by the otlp trace exporter",
+	)
+	command.PersistentFlags().String(
+		GlobalFlagNames.TraceOutput,
+		"",
+		"File the traces are written to by the file trace exporter (default <home>/roller-traces.json)",
+	)
 }
 
 var GlobalFlagNames = struct {
-	Home string
+	Home          string
+	TraceExporter string
+	TraceEndpoint string
+	TraceOutput   string
 }{
-	Home: "home",
+	Home:          "home",
+	TraceExporter: "trace-exporter",
+	TraceEndpoint: "trace-endpoint",
+	TraceOutput:   "trace-output",
+}
+
+// GetTracingConfig returns the tracing configuration from the global flags
+func GetTracingConfig(cmd *cobra.Command) tracing.Config {
+	exporter, _ := cmd.Flags().GetString(GlobalFlagNames.TraceExporter)
+	endpoint, _ := cmd.Flags().GetString(GlobalFlagNames.TraceEndpoint)
+	output, _ := cmd.Flags().GetString(GlobalFlagNames.TraceOutput)
+
+	if output == "" {
+		home, _ := cmd.Flags().GetString(GlobalFlagNames.Home)
+		output = tracing.DefaultFilePath(home)
+	}
+
+	return tracing.Config{
+		Exporter: exporter,
+		Endpoint: endpoint,
+		FilePath: output,
+	}
 }
@@ -16,8 +16,8 @@ const (
 var InternalBinsDir = fmt.Sprintf("%s/roller_bins", binsDir)
 
 var (
-	AllServices            = []string{"rollapp", "da-light-client", "relayer", "eibc"}
-	RollappSystemdServices = []string{"rollapp", "da-light-client"}
+	AllServices            = []string{"rollapp", "da-light-client", "health-agent", "relayer", "eibc"}
+	RollappSystemdServices = []string{"rollapp", "da-light-client", "health-agent"}
 	RelayerSystemdServices = []string{"relayer"}
 	EibcSystemdServices    = []string{"eibc"}
 )
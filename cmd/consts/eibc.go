@@ -1,6 +1,5 @@
 package consts
 
 const (
-	DefaultIndexer              = "http://44.206.211.230:3000/"
 	DefaultEibcOperatorFeeShare = 0.1
 )
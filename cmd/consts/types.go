@@ -8,6 +8,9 @@ type HubData struct {
 	ArchiveRpcUrl string    `toml:"archive_rpc_url" json:"archiveRpcUrl"`
 	GasPrice      string    `toml:"gas_price"       json:"gasPrice"`
 	DaNetwork     DaNetwork `toml:"da_network"      json:"daNetwork"`
+	// IndexerUrl is the eibc order indexer set up by 'roller eibc init', the eibc client
+	// queries the hub directly when it's empty
+	IndexerUrl string `toml:"indexer_url,omitempty" json:"indexerUrl,omitempty"`
 }
 
 type RollappData = struct {
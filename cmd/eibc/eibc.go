@@ -4,8 +4,15 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/dymensionxyz/roller/cmd/eibc/fulfill"
+	"github.com/dymensionxyz/roller/cmd/eibc/group"
+	"github.com/dymensionxyz/roller/cmd/eibc/indexer"
 	eibcinit "github.com/dymensionxyz/roller/cmd/eibc/init"
+	"github.com/dymensionxyz/roller/cmd/eibc/liquidity"
+	"github.com/dymensionxyz/roller/cmd/eibc/operator"
+	"github.com/dymensionxyz/roller/cmd/eibc/orders"
+	"github.com/dymensionxyz/roller/cmd/eibc/pnl"
 	"github.com/dymensionxyz/roller/cmd/eibc/scale"
+	"github.com/dymensionxyz/roller/cmd/eibc/simulate"
 	"github.com/dymensionxyz/roller/cmd/eibc/start"
 	"github.com/dymensionxyz/roller/cmd/eibc/update"
 	"github.com/dymensionxyz/roller/cmd/services"
@@ -26,6 +33,13 @@ func Cmd() *cobra.Command {
 	cmd.AddCommand(update.Cmd())
 	cmd.AddCommand(scale.Cmd())
 	cmd.AddCommand(fulfill.Cmd())
+	cmd.AddCommand(orders.Cmd())
+	cmd.AddCommand(pnl.Cmd())
+	cmd.AddCommand(liquidity.Cmd())
+	cmd.AddCommand(group.Cmd())
+	cmd.AddCommand(indexer.Cmd())
+	cmd.AddCommand(operator.Cmd())
+	cmd.AddCommand(simulate.Cmd())
 
 	sl := []string{"eibc"}
 	cmd.AddCommand(
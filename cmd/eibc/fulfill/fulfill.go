@@ -5,6 +5,7 @@ import (
 
 	"github.com/dymensionxyz/roller/cmd/eibc/fulfill/feeshare"
 	"github.com/dymensionxyz/roller/cmd/eibc/fulfill/order"
+	"github.com/dymensionxyz/roller/cmd/eibc/fulfill/policy"
 	"github.com/dymensionxyz/roller/cmd/eibc/fulfill/rollapps"
 )
 
@@ -17,6 +18,7 @@ func Cmd() *cobra.Command {
 	cmd.AddCommand(order.Cmd())
 	cmd.AddCommand(feeshare.Cmd())
 	cmd.AddCommand(rollapps.Cmd())
+	cmd.AddCommand(policy.Cmd())
 
 	return cmd
 }
@@ -1,15 +1,17 @@
 package order
 
 import (
+	"os"
+	"path/filepath"
+
 	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
 
 	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
-	"github.com/dymensionxyz/roller/utils/bash"
+	"github.com/dymensionxyz/roller/cmd/consts"
 	"github.com/dymensionxyz/roller/utils/eibc"
 	"github.com/dymensionxyz/roller/utils/filesystem"
 	"github.com/dymensionxyz/roller/utils/roller"
-	"github.com/dymensionxyz/roller/utils/tx"
 )
 
 func Cmd() *cobra.Command {
@@ -56,31 +58,21 @@ func Cmd() *cobra.Command {
 				).Show()
 			}
 
-			gCmd, err := eibc.GetFulfillOrderCmd(
-				orderId,
-				feeAmount,
-				rollerCfg.HubData,
-			)
+			userHome, err := os.UserHomeDir()
 			if err != nil {
-				pterm.Error.Println("failed to fulfill order: ", err)
+				pterm.Error.Println("failed to get user home dir", err)
 				return
 			}
-
-			txOutput, err := bash.ExecCommandWithInput(home, gCmd, "signatures")
+			eibcConfigPath := filepath.Join(userHome, consts.ConfigDirName.Eibc, "config.yaml")
+			err = eibc.CheckFulfillPolicy(eibcConfigPath, orderId, rollerCfg.HubData)
 			if err != nil {
-				pterm.Error.Println("failed to update sequencer metadata", err)
+				pterm.Error.Println("refusing to fulfill order: ", err)
 				return
 			}
 
-			txHash, err := bash.ExtractTxHash(txOutput)
+			err = eibc.FulfillOrder(home, orderId, feeAmount, rollerCfg.HubData)
 			if err != nil {
-				pterm.Error.Println("failed to extract tx hash", err)
-				return
-			}
-
-			err = tx.MonitorTransaction(rollerCfg.HubData.RpcUrl, txHash)
-			if err != nil {
-				pterm.Error.Println("transaction failed", err)
+				pterm.Error.Println("failed to fulfill order: ", err)
 				return
 			}
 		},
@@ -0,0 +1,70 @@
+package list
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	eibcutils "github.com/dymensionxyz/roller/utils/eibc"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the fee and order size policies of the RollApps and assets",
+		Run: func(cmd *cobra.Command, args []string) {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				pterm.Error.Println("failed to get user home dir", err)
+				return
+			}
+
+			eibcHome := filepath.Join(home, consts.ConfigDirName.Eibc)
+			isEibcClientInitialized, err := filesystem.DirNotEmpty(eibcHome)
+			if err != nil {
+				pterm.Error.Println("failed to check eibc client initialized", err)
+				return
+			}
+
+			if !isEibcClientInitialized {
+				pterm.Error.Println("eibc client not initialized")
+				return
+			}
+
+			var cfg eibcutils.Config
+			err = cfg.LoadConfig(filepath.Join(eibcHome, "config.yaml"))
+			if err != nil {
+				pterm.Error.Println("failed to load eibc config: ", err)
+				return
+			}
+
+			entries := cfg.FulfillCriteria.Entries()
+			if len(entries) == 0 {
+				pterm.Info.Println("no fulfill policy set")
+				return
+			}
+
+			data := pterm.TableData{{"Target", "ID", "Min Fee %", "Max Order Size (roller)"}}
+			for _, e := range entries {
+				minFee, maxSize := "-", "-"
+				if e.MinFeePercentage != nil {
+					minFee = strconv.FormatFloat(float64(*e.MinFeePercentage), 'f', -1, 32)
+				}
+				if e.MaxOrderSize != "" {
+					maxSize = e.MaxOrderSize
+				}
+				data = append(data, []string{e.Target, e.Key, minFee, maxSize})
+			}
+
+			// nolint: errcheck
+			pterm.DefaultTable.WithHasHeader().WithData(data).Render()
+		},
+	}
+
+	return cmd
+}
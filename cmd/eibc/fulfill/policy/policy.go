@@ -0,0 +1,22 @@
+package policy
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/dymensionxyz/roller/cmd/eibc/fulfill/policy/list"
+	"github.com/dymensionxyz/roller/cmd/eibc/fulfill/policy/remove"
+	"github.com/dymensionxyz/roller/cmd/eibc/fulfill/policy/set"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "policy",
+		Short: "Commands to manage the fee and order size policies per RollApp and asset",
+	}
+
+	cmd.AddCommand(set.Cmd())
+	cmd.AddCommand(list.Cmd())
+	cmd.AddCommand(remove.Cmd())
+
+	return cmd
+}
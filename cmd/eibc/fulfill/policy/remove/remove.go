@@ -0,0 +1,76 @@
+package remove
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	eibcutils "github.com/dymensionxyz/roller/utils/eibc"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:       "remove <rollapp|asset> <rollapp-id|denom>",
+		Short:     "Remove the fee and order size policy of a RollApp or asset",
+		Example:   `  roller eibc fulfill policy remove asset adym`,
+		Args:      cobra.ExactArgs(2),
+		ValidArgs: []string{eibcutils.PolicyTargets.Rollapp, eibcutils.PolicyTargets.Asset},
+		Run: func(cmd *cobra.Command, args []string) {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				pterm.Error.Println("failed to get user home dir", err)
+				return
+			}
+
+			eibcHome := filepath.Join(home, consts.ConfigDirName.Eibc)
+			eibcConfigPath := filepath.Join(eibcHome, "config.yaml")
+			isEibcClientInitialized, err := filesystem.DirNotEmpty(eibcHome)
+			if err != nil {
+				pterm.Error.Println("failed to check eibc client initialized", err)
+				return
+			}
+
+			if !isEibcClientInitialized {
+				pterm.Error.Println("eibc client not initialized")
+				return
+			}
+
+			target, key := args[0], args[1]
+			if target != eibcutils.PolicyTargets.Rollapp && target != eibcutils.PolicyTargets.Asset {
+				pterm.Error.Println("invalid policy target: ", target)
+				return
+			}
+
+			var cfg eibcutils.Config
+			err = cfg.LoadConfig(eibcConfigPath)
+			if err != nil {
+				pterm.Error.Println("failed to load eibc config: ", err)
+				return
+			}
+
+			if !cfg.FulfillCriteria.Remove(target, key) {
+				pterm.Info.Printfln("%s %s has no policy", target, key)
+				return
+			}
+
+			err = eibcutils.WriteFulfillPolicy(eibcConfigPath, cfg.FulfillCriteria)
+			if err != nil {
+				pterm.Error.Println("failed to update eibc config: ", err)
+				return
+			}
+			pterm.Success.Printfln("policy of %s %s removed from the eibc config", target, key)
+
+			err = eibcutils.UpdateGroupFulfillPolicy(eibcConfigPath, cfg, home)
+			if err != nil {
+				pterm.Error.Println("failed to update eibc operator metadata: ", err)
+				return
+			}
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,134 @@
+package set
+
+import (
+	"os"
+	"path/filepath"
+
+	cosmossdkmath "cosmossdk.io/math"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	eibcutils "github.com/dymensionxyz/roller/utils/eibc"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+)
+
+const (
+	flagMinFeePercent = "min-fee-percent"
+	flagMaxOrderSize  = "max-order-size"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set <rollapp|asset> <rollapp-id|denom>",
+		Short: "Set the minimal fee percentage and maximal order size of a RollApp or asset",
+		Long: `Set the minimal fee percentage and maximal order size of a RollApp or asset.
+
+The fee percentage is a float number between 0 and 100, orders whose fee is a
+lower percentage of the order amount are ignored by the eibc client. The max
+order size is the maximal order price in the base denom of the order asset.
+An order has to satisfy both the policy of its RollApp and of its asset.
+
+The eibc client doesn't read the max order size, the orders it fulfills on its
+own are only filtered by fee. Roller refuses to fulfill the orders above it
+('roller eibc fulfill order' and 'roller eibc orders list --select') and applies
+it in 'roller eibc simulate' and 'roller eibc scale'.
+
+RollApp policies can only be set for the RollApps supported by the eibc client.
+The policy is also published in the on-chain metadata of the eibc operator.
+`,
+		Example: `  roller eibc fulfill policy set rollapp rollappevm_1234-1 --min-fee-percent 0.5
+  roller eibc fulfill policy set asset adym --max-order-size 1000000000000000000000`,
+		Args:      cobra.ExactArgs(2),
+		ValidArgs: []string{eibcutils.PolicyTargets.Rollapp, eibcutils.PolicyTargets.Asset},
+		Run: func(cmd *cobra.Command, args []string) {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				pterm.Error.Println("failed to get user home dir", err)
+				return
+			}
+
+			eibcHome := filepath.Join(home, consts.ConfigDirName.Eibc)
+			eibcConfigPath := filepath.Join(eibcHome, "config.yaml")
+			isEibcClientInitialized, err := filesystem.DirNotEmpty(eibcHome)
+			if err != nil {
+				pterm.Error.Println("failed to check eibc client initialized", err)
+				return
+			}
+
+			if !isEibcClientInitialized {
+				pterm.Error.Println("eibc client not initialized")
+				return
+			}
+
+			target, key := args[0], args[1]
+
+			var minFee *float32
+			if cmd.Flags().Changed(flagMinFeePercent) {
+				f, _ := cmd.Flags().GetFloat32(flagMinFeePercent)
+				minFee = &f
+			}
+
+			var maxSize *cosmossdkmath.Int
+			if cmd.Flags().Changed(flagMaxOrderSize) {
+				s, _ := cmd.Flags().GetString(flagMaxOrderSize)
+				amount, err := eibcutils.ParseMaxOrderSize(s)
+				if err != nil {
+					pterm.Error.Println(err)
+					return
+				}
+				maxSize = &amount
+			}
+
+			if minFee == nil && maxSize == nil {
+				pterm.Error.Printfln(
+					"provide at least one of --%s and --%s",
+					flagMinFeePercent,
+					flagMaxOrderSize,
+				)
+				return
+			}
+
+			var cfg eibcutils.Config
+			err = cfg.LoadConfig(eibcConfigPath)
+			if err != nil {
+				pterm.Error.Println("failed to load eibc config: ", err)
+				return
+			}
+
+			err = eibcutils.ValidatePolicyEntry(cfg, target, key, minFee, maxSize)
+			if err != nil {
+				pterm.Error.Println("invalid policy: ", err)
+				return
+			}
+
+			cfg.FulfillCriteria.Set(target, key, minFee, maxSize)
+			err = eibcutils.WriteFulfillPolicy(eibcConfigPath, cfg.FulfillCriteria)
+			if err != nil {
+				pterm.Error.Println("failed to update eibc config: ", err)
+				return
+			}
+			pterm.Success.Printfln("policy of %s %s updated in the eibc config", target, key)
+			if maxSize != nil {
+				pterm.Warning.Println(
+					"the eibc client doesn't read the max order size, only roller enforces it",
+				)
+			}
+
+			err = eibcutils.UpdateGroupFulfillPolicy(eibcConfigPath, cfg, home)
+			if err != nil {
+				pterm.Error.Println("failed to update eibc operator metadata: ", err)
+				return
+			}
+		},
+	}
+
+	cmd.Flags().Float32(flagMinFeePercent, 0, "minimal fee percentage of the orders to fulfill")
+	cmd.Flags().String(
+		flagMaxOrderSize,
+		"",
+		"maximal price of the orders to fulfill, only enforced by roller",
+	)
+
+	return cmd
+}
@@ -0,0 +1,28 @@
+package group
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/dymensionxyz/roller/cmd/eibc/group/members"
+	"github.com/dymensionxyz/roller/cmd/eibc/group/policy"
+	"github.com/dymensionxyz/roller/cmd/eibc/group/proposals"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "group",
+		Short: "Commands to manage the eibc operator group shared by the liquidity providers",
+		Long: `Commands to manage the eibc operator group shared by the liquidity providers.
+
+The orders are fulfilled with the funds of the group policy. The members of the
+group are the liquidity providers, they vote on the proposals that move the
+policy funds, with a weight proportional to their share.
+`,
+	}
+
+	cmd.AddCommand(members.Cmd())
+	cmd.AddCommand(policy.Cmd())
+	cmd.AddCommand(proposals.Cmd())
+
+	return cmd
+}
@@ -0,0 +1,58 @@
+package groupflags
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	eibcutils "github.com/dymensionxyz/roller/utils/eibc"
+)
+
+const (
+	FlagGroupID        = "group-id"
+	FlagFrom           = "from"
+	FlagKeyringBackend = "keyring-backend"
+	FlagKeyringDir     = "keyring-dir"
+)
+
+// AddFlags adds the flags that select the operator group and the member key, for the
+// group members that don't run the eibc client of the group
+func AddFlags(cmd *cobra.Command) {
+	cmd.Flags().String(FlagGroupID, "", "id of the operator group, the eibc client one by default")
+	cmd.Flags().String(FlagFrom, "", "key of the group member, the whale account by default")
+	cmd.Flags().String(
+		FlagKeyringBackend,
+		string(consts.SupportedKeyringBackends.Test),
+		"keyring backend of the --from key (test or os)",
+	)
+	cmd.Flags().String(
+		FlagKeyringDir,
+		"",
+		"keyring directory of the --from key, the eibc home by default",
+	)
+}
+
+// OverridesFromFlags returns the group overrides of the flags
+func OverridesFromFlags(cmd *cobra.Command) (eibcutils.GroupOverrides, error) {
+	groupID, _ := cmd.Flags().GetString(FlagGroupID)
+	from, _ := cmd.Flags().GetString(FlagFrom)
+	backend, _ := cmd.Flags().GetString(FlagKeyringBackend)
+	keyringDir, _ := cmd.Flags().GetString(FlagKeyringDir)
+
+	kb := consts.SupportedKeyringBackend(backend)
+	if kb != consts.SupportedKeyringBackends.Test && kb != consts.SupportedKeyringBackends.OS {
+		return eibcutils.GroupOverrides{}, fmt.Errorf(
+			"invalid --%s: %s, use test or os",
+			FlagKeyringBackend,
+			backend,
+		)
+	}
+
+	return eibcutils.GroupOverrides{
+		GroupID:        groupID,
+		From:           from,
+		KeyringBackend: kb,
+		KeyringDir:     keyringDir,
+	}, nil
+}
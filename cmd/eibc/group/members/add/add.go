@@ -0,0 +1,70 @@
+package add
+
+import (
+	"os"
+	"strconv"
+
+	cosmossdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	eibcutils "github.com/dymensionxyz/roller/utils/eibc"
+)
+
+const (
+	flagWeight   = "weight"
+	flagMetadata = "metadata"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "add <address>",
+		Short:   "Add a member to the eibc operator group or update its weight",
+		Example: `  roller eibc group members add dym1... --weight 2 --metadata "lp name"`,
+		Args:    cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				pterm.Error.Println("failed to get user home dir", err)
+				return
+			}
+
+			address := args[0]
+			_, err = cosmossdktypes.GetFromBech32(address, consts.AddressPrefixes.Hub)
+			if err != nil {
+				pterm.Error.Printfln("%s is not a valid hub address: %v", address, err)
+				return
+			}
+
+			weight, _ := cmd.Flags().GetString(flagWeight)
+			w, err := strconv.ParseFloat(weight, 64)
+			if err != nil || w <= 0 {
+				pterm.Error.Printfln("--%s must be a positive number, got %s", flagWeight, weight)
+				return
+			}
+			metadata, _ := cmd.Flags().GetString(flagMetadata)
+
+			gi, err := eibcutils.LoadGroupInfo(home)
+			if err != nil {
+				pterm.Error.Println(err)
+				return
+			}
+
+			err = eibcutils.UpdateGroupMembers(*gi, []eibcutils.GroupMember{
+				{Address: address, Weight: weight, Metadata: metadata},
+			})
+			if err != nil {
+				pterm.Error.Println("failed to add group member: ", err)
+				return
+			}
+
+			pterm.Success.Printfln("%s added to group %s with weight %s", address, gi.GroupID, weight)
+		},
+	}
+
+	cmd.Flags().String(flagWeight, "1", "voting weight of the member")
+	cmd.Flags().String(flagMetadata, "", "metadata of the member")
+
+	return cmd
+}
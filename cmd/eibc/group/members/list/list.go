@@ -0,0 +1,56 @@
+package list
+
+import (
+	"os"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	eibcutils "github.com/dymensionxyz/roller/utils/eibc"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the members of the eibc operator group",
+		Run: func(cmd *cobra.Command, args []string) {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				pterm.Error.Println("failed to get user home dir", err)
+				return
+			}
+
+			gi, err := eibcutils.LoadGroupInfo(home)
+			if err != nil {
+				pterm.Error.Println(err)
+				return
+			}
+
+			members, err := eibcutils.GetGroupMembers(*gi)
+			if err != nil {
+				pterm.Error.Println("failed to retrieve group members: ", err)
+				return
+			}
+
+			data := pterm.TableData{{"Address", "Weight", "Metadata", "Added At"}}
+			for _, m := range members {
+				address := m.Address
+				if address == gi.Admin {
+					address += " (admin)"
+				}
+				data = append(data, []string{
+					address,
+					m.Weight,
+					m.Metadata,
+					m.AddedAt.Format("2006-01-02 15:04:05"),
+				})
+			}
+
+			pterm.Info.Printfln("members of group %s:", gi.GroupID)
+			// nolint: errcheck
+			pterm.DefaultTable.WithHasHeader().WithData(data).Render()
+		},
+	}
+
+	return cmd
+}
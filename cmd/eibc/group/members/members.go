@@ -0,0 +1,22 @@
+package members
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/dymensionxyz/roller/cmd/eibc/group/members/add"
+	"github.com/dymensionxyz/roller/cmd/eibc/group/members/list"
+	"github.com/dymensionxyz/roller/cmd/eibc/group/members/remove"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "members",
+		Short: "Commands to manage the members of the eibc operator group",
+	}
+
+	cmd.AddCommand(add.Cmd())
+	cmd.AddCommand(remove.Cmd())
+	cmd.AddCommand(list.Cmd())
+
+	return cmd
+}
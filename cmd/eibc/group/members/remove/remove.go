@@ -0,0 +1,64 @@
+package remove
+
+import (
+	"os"
+	"slices"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	eibcutils "github.com/dymensionxyz/roller/utils/eibc"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "remove <address>",
+		Short: "Remove a member from the eibc operator group",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				pterm.Error.Println("failed to get user home dir", err)
+				return
+			}
+
+			address := args[0]
+
+			gi, err := eibcutils.LoadGroupInfo(home)
+			if err != nil {
+				pterm.Error.Println(err)
+				return
+			}
+
+			members, err := eibcutils.GetGroupMembers(*gi)
+			if err != nil {
+				pterm.Error.Println("failed to retrieve group members: ", err)
+				return
+			}
+
+			if !slices.ContainsFunc(members, func(m eibcutils.GroupMember) bool {
+				return m.Address == address
+			}) {
+				pterm.Error.Printfln("%s is not a member of group %s", address, gi.GroupID)
+				return
+			}
+			if len(members) == 1 {
+				pterm.Error.Println("the last member of the group can't be removed")
+				return
+			}
+
+			// a zero weight removes the member
+			err = eibcutils.UpdateGroupMembers(*gi, []eibcutils.GroupMember{
+				{Address: address, Weight: "0"},
+			})
+			if err != nil {
+				pterm.Error.Println("failed to remove group member: ", err)
+				return
+			}
+
+			pterm.Success.Printfln("%s removed from group %s", address, gi.GroupID)
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,18 @@
+package policy
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/dymensionxyz/roller/cmd/eibc/group/policy/show"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "policy",
+		Short: "Commands related to the eibc operator group policy",
+	}
+
+	cmd.AddCommand(show.Cmd())
+
+	return cmd
+}
@@ -0,0 +1,68 @@
+package show
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	eibcutils "github.com/dymensionxyz/roller/utils/eibc"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show",
+		Short: "Show the eibc operator group policy and its decision policy",
+		Run: func(cmd *cobra.Command, args []string) {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				pterm.Error.Println("failed to get user home dir", err)
+				return
+			}
+
+			gi, err := eibcutils.LoadGroupInfo(home)
+			if err != nil {
+				pterm.Error.Println(err)
+				return
+			}
+
+			p, err := eibcutils.GetGroupPolicy(*gi)
+			if err != nil {
+				pterm.Error.Println("failed to retrieve group policy: ", err)
+				return
+			}
+
+			data := pterm.TableData{
+				{"Address", p.Address},
+				{"Group ID", p.GroupID},
+				{"Admin", p.Admin},
+				{"Version", p.Version},
+				{"Decision Policy", p.DecisionPolicy.Type},
+				{"Threshold Percentage", p.DecisionPolicy.Percentage},
+				{"Voting Period", p.DecisionPolicy.Windows.VotingPeriod},
+				{"Min Execution Period", p.DecisionPolicy.Windows.MinExecutionPeriod},
+			}
+			// nolint: errcheck
+			pterm.DefaultTable.WithData(data).Render()
+
+			members, err := eibcutils.GetGroupMembers(*gi)
+			if err != nil {
+				pterm.Error.Println("failed to retrieve group members: ", err)
+				return
+			}
+
+			pct, _ := strconv.ParseFloat(p.DecisionPolicy.Percentage, 64)
+			if len(members) > 1 && pct > 0 && pct < 0.5 {
+				pterm.Warning.Printfln(
+					"the group has %d members and a proposal passes with %s of the votes, "+
+						"any member can move the policy funds alone",
+					len(members),
+					p.DecisionPolicy.Percentage,
+				)
+			}
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,49 @@
+package exec
+
+import (
+	"os"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	"github.com/dymensionxyz/roller/cmd/eibc/group/groupflags"
+	eibcutils "github.com/dymensionxyz/roller/utils/eibc"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "exec <proposal-id>",
+		Short: "Execute an accepted proposal of the eibc operator group",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				pterm.Error.Println("failed to get user home dir", err)
+				return
+			}
+
+			overrides, err := groupflags.OverridesFromFlags(cmd)
+			if err != nil {
+				pterm.Error.Println(err)
+				return
+			}
+			gi, err := eibcutils.LoadMemberGroupInfo(home, overrides)
+			if err != nil {
+				pterm.Error.Println(err)
+				return
+			}
+
+			txHash, err := eibcutils.ExecGroupProposal(*gi, args[0])
+			if err != nil {
+				pterm.Error.Println("failed to execute proposal: ", err)
+				return
+			}
+
+			pterm.Success.Printfln("proposal %s executed in %s", args[0], txHash)
+		},
+	}
+
+	groupflags.AddFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,76 @@
+package list
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	"github.com/dymensionxyz/roller/cmd/eibc/group/groupflags"
+	eibcutils "github.com/dymensionxyz/roller/utils/eibc"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the proposals of the eibc operator group policy",
+		Run: func(cmd *cobra.Command, args []string) {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				pterm.Error.Println("failed to get user home dir", err)
+				return
+			}
+
+			overrides, err := groupflags.OverridesFromFlags(cmd)
+			if err != nil {
+				pterm.Error.Println(err)
+				return
+			}
+			gi, err := eibcutils.LoadMemberGroupInfo(home, overrides)
+			if err != nil {
+				pterm.Error.Println(err)
+				return
+			}
+
+			proposals, err := eibcutils.GetGroupProposals(*gi)
+			if err != nil {
+				pterm.Error.Println("failed to retrieve proposals: ", err)
+				return
+			}
+			if len(proposals) == 0 {
+				pterm.Info.Println("no proposals found")
+				return
+			}
+
+			data := pterm.TableData{
+				{"ID", "Title", "Status", "Messages", "Yes/No/Abstain/Veto", "Voting Ends"},
+			}
+			for _, p := range proposals {
+				t := p.FinalTallyResult
+				data = append(data, []string{
+					p.ID,
+					p.Title,
+					strings.TrimPrefix(p.Status, "PROPOSAL_STATUS_"),
+					strings.Join(p.MessageTypes(), ", "),
+					fmt.Sprintf(
+						"%s/%s/%s/%s",
+						t.YesCount,
+						t.NoCount,
+						t.AbstainCount,
+						t.NoWithVetoCount,
+					),
+					p.VotingPeriodEnd.Format("2006-01-02 15:04:05"),
+				})
+			}
+
+			// nolint: errcheck
+			pterm.DefaultTable.WithHasHeader().WithData(data).Render()
+		},
+	}
+
+	groupflags.AddFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,24 @@
+package proposals
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/dymensionxyz/roller/cmd/eibc/group/proposals/exec"
+	"github.com/dymensionxyz/roller/cmd/eibc/group/proposals/list"
+	"github.com/dymensionxyz/roller/cmd/eibc/group/proposals/submit"
+	"github.com/dymensionxyz/roller/cmd/eibc/group/proposals/vote"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "proposals",
+		Short: "Commands to submit and vote on the eibc operator group proposals",
+	}
+
+	cmd.AddCommand(list.Cmd())
+	cmd.AddCommand(submit.Cmd())
+	cmd.AddCommand(vote.Cmd())
+	cmd.AddCommand(exec.Cmd())
+
+	return cmd
+}
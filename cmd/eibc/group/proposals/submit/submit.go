@@ -0,0 +1,92 @@
+package submit
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	eibcutils "github.com/dymensionxyz/roller/utils/eibc"
+)
+
+const (
+	flagTitle   = "title"
+	flagSummary = "summary"
+	flagExec    = "exec"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "submit <messages-file>",
+		Short: "Submit a proposal executing messages with the eibc operator group policy",
+		Long: `Submit a proposal executing messages with the eibc operator group policy.
+
+The messages file is a json list of messages with their '@type', signed by the
+group policy address. The whale account is the proposer, use '--exec' to
+execute the proposal right away when the proposer vote is enough to pass it.
+`,
+		Example: `  roller eibc group proposals submit withdraw.json --title "withdraw lp funds"`,
+		Args:    cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				pterm.Error.Println("failed to get user home dir", err)
+				return
+			}
+
+			data, err := os.ReadFile(args[0])
+			if err != nil {
+				pterm.Error.Println("failed to read messages file: ", err)
+				return
+			}
+
+			var msgs []json.RawMessage
+			err = json.Unmarshal(data, &msgs)
+			if err != nil {
+				pterm.Error.Println("the messages file must contain a json list: ", err)
+				return
+			}
+			if len(msgs) == 0 {
+				pterm.Error.Println("the messages file is empty")
+				return
+			}
+
+			title, _ := cmd.Flags().GetString(flagTitle)
+			summary, _ := cmd.Flags().GetString(flagSummary)
+			execute, _ := cmd.Flags().GetBool(flagExec)
+
+			gi, err := eibcutils.LoadGroupInfo(home)
+			if err != nil {
+				pterm.Error.Println(err)
+				return
+			}
+
+			messages := make([]any, 0, len(msgs))
+			for _, m := range msgs {
+				messages = append(messages, m)
+			}
+
+			txHash, err := eibcutils.SubmitGroupProposal(gi.EibcHome, eibcutils.GroupProposal{
+				GroupPolicyAddress: gi.PolicyAddress,
+				Messages:           messages,
+				Proposers:          []string{gi.Member.Address},
+				Title:              title,
+				Summary:            summary,
+			}, execute, gi.Hub)
+			if err != nil {
+				pterm.Error.Println("failed to submit proposal: ", err)
+				return
+			}
+
+			pterm.Success.Printfln("proposal submitted in %s", txHash)
+			pterm.Info.Println("the members can vote with 'roller eibc group proposals vote'")
+		},
+	}
+
+	cmd.Flags().String(flagTitle, "", "title of the proposal")
+	cmd.Flags().String(flagSummary, "", "summary of the proposal")
+	cmd.Flags().Bool(flagExec, false, "try to execute the proposal right after the submission")
+
+	return cmd
+}
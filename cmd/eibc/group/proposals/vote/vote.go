@@ -0,0 +1,68 @@
+package vote
+
+import (
+	"os"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	"github.com/dymensionxyz/roller/cmd/eibc/group/groupflags"
+	eibcutils "github.com/dymensionxyz/roller/utils/eibc"
+)
+
+const flagExec = "exec"
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "vote <proposal-id> <yes|no|abstain|veto>",
+		Short: "Vote on a proposal of the eibc operator group",
+		Long: `Vote on a proposal of the eibc operator group.
+
+The vote is signed by the whale account by default. The other members of the
+group vote with their own key with '--from', and select the group with
+'--group-id' when it's not the group of their eibc client.
+`,
+		Example: `  roller eibc group proposals vote 4 yes --group-id 12 --from member1 \
+    --keyring-dir ~/.dymension`,
+		Args:      cobra.ExactArgs(2),
+		ValidArgs: []string{"yes", "no", "abstain", "veto"},
+		Run: func(cmd *cobra.Command, args []string) {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				pterm.Error.Println("failed to get user home dir", err)
+				return
+			}
+
+			proposalID, option := args[0], args[1]
+			if _, ok := eibcutils.VoteOptions[option]; !ok {
+				pterm.Error.Printfln("invalid vote option %s, use yes, no, abstain or veto", option)
+				return
+			}
+			execute, _ := cmd.Flags().GetBool(flagExec)
+
+			overrides, err := groupflags.OverridesFromFlags(cmd)
+			if err != nil {
+				pterm.Error.Println(err)
+				return
+			}
+			gi, err := eibcutils.LoadMemberGroupInfo(home, overrides)
+			if err != nil {
+				pterm.Error.Println(err)
+				return
+			}
+
+			txHash, err := eibcutils.VoteGroupProposal(*gi, proposalID, option, execute)
+			if err != nil {
+				pterm.Error.Println("failed to vote: ", err)
+				return
+			}
+
+			pterm.Success.Printfln("voted %s on proposal %s in %s", option, proposalID, txHash)
+		},
+	}
+
+	cmd.Flags().Bool(flagExec, false, "try to execute the proposal after the vote")
+	groupflags.AddFlags(cmd)
+
+	return cmd
+}
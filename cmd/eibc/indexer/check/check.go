@@ -0,0 +1,135 @@
+package check
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	eibcutils "github.com/dymensionxyz/roller/utils/eibc"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+)
+
+const (
+	flagSample     = "sample"
+	flagIndexerURL = "indexer-url"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "check",
+		Short: "Compare the orders served by the indexer with the hub state",
+		Long: `Compare the orders served by the indexer with the hub state.
+
+A sample of the pending orders returned by the indexer is checked against the
+demand orders of the hub, the orders that are no longer pending, already
+fulfilled or that have a different price or fee are reported. The unfulfilled
+orders of the hub that the indexer doesn't serve are reported as well.
+
+By default the indexer of the eibc config is checked, or the indexer of the
+hub environment when the config has none.
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				pterm.Error.Println("failed to get user home dir", err)
+				return
+			}
+
+			eibcHome := filepath.Join(home, consts.ConfigDirName.Eibc)
+			isEibcClientInitialized, err := filesystem.DirNotEmpty(eibcHome)
+			if err != nil {
+				pterm.Error.Println("failed to check eibc client initialized", err)
+				return
+			}
+			if !isEibcClientInitialized {
+				pterm.Error.Println("eibc client not initialized")
+				return
+			}
+
+			sample, _ := cmd.Flags().GetInt(flagSample)
+			indexerURL, _ := cmd.Flags().GetString(flagIndexerURL)
+
+			var cfg eibcutils.Config
+			hd, err := cfg.HubDataFromHubRpc(filepath.Join(eibcHome, "config.yaml"))
+			if err != nil {
+				pterm.Error.Println("failed to retrieve hub data: ", err)
+				return
+			}
+
+			if indexerURL == "" {
+				indexerURL = cfg.OrderPolling.IndexerURL
+			}
+			if indexerURL == "" {
+				pterm.Error.Printfln("no indexer configured for %s", hd.ID)
+				return
+			}
+
+			spinner, _ := pterm.DefaultSpinner.Start("comparing the indexer with the hub")
+			report, err := eibcutils.CheckIndexer(indexerURL, *hd, sample)
+			if err != nil {
+				spinner.Fail("failed to check the indexer: ", err)
+				return
+			}
+			spinner.Stop()
+
+			printReport(report, cfg.OrderPolling.Enabled)
+		},
+	}
+
+	cmd.Flags().Int(flagSample, 20, "number of indexer orders to check, 0 checks all of them")
+	cmd.Flags().String(flagIndexerURL, "", "indexer to check instead of the configured one")
+
+	return cmd
+}
+
+func printReport(r *eibcutils.IndexerReport, pollingEnabled bool) {
+	if !r.Healthy() {
+		pterm.Error.Printfln("indexer %s is down: %v", r.URL, r.Err)
+		return
+	}
+
+	pterm.Info.Printfln("indexer %s answered in %s", r.URL, r.Latency)
+	pterm.Info.Printfln(
+		"%d pending orders on the indexer, %d on the hub",
+		r.IndexerOrders,
+		r.HubOrders,
+	)
+
+	data := pterm.TableData{{"Order", "Result", "Detail"}}
+	for _, c := range r.Checks {
+		if c.Result == eibcutils.OrderCheckResults.Ok {
+			continue
+		}
+		data = append(data, []string{c.ID, c.Result, c.Detail})
+	}
+	if len(data) > 1 {
+		_ = pterm.DefaultTable.WithHasHeader().WithData(data).Render()
+	}
+
+	pterm.Info.Printfln(
+		"%d of %d sampled orders match the hub",
+		len(r.Checks)-r.Inconsistencies(),
+		len(r.Checks),
+	)
+	if len(r.Unindexed) > 0 {
+		pterm.Warning.Printfln(
+			"%d unfulfilled hub orders are missing from the indexer",
+			len(r.Unindexed),
+		)
+	}
+
+	if r.Consistent() {
+		pterm.Success.Println("the indexer is consistent with the hub")
+		if !pollingEnabled {
+			pterm.Info.Println("run 'roller eibc start' to poll the orders from the indexer again")
+		}
+		return
+	}
+
+	pterm.Warning.Println(
+		"the indexer is inconsistent with the hub, 'roller eibc start' will query the hub instead",
+	)
+}
@@ -0,0 +1,20 @@
+package indexer
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/dymensionxyz/roller/cmd/eibc/indexer/check"
+	"github.com/dymensionxyz/roller/cmd/eibc/indexer/run"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "indexer",
+		Short: "Commands to manage the indexer the eibc client polls the orders from",
+	}
+
+	cmd.AddCommand(check.Cmd())
+	cmd.AddCommand(run.Cmd())
+
+	return cmd
+}
@@ -0,0 +1,223 @@
+package run
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/utils/config/yamlconfig"
+	eibcutils "github.com/dymensionxyz/roller/utils/eibc"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+)
+
+const (
+	flagListen          = "listen"
+	flagMongoDbURI      = "mongodb-uri"
+	flagResyncInterval  = "resync-interval"
+	flagConfigureClient = "configure-client"
+	flagPublic          = "public"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "run",
+		Short: "Run an eibc order indexer for the hub of the eibc client",
+		Long: `Run an eibc order indexer for the hub of the eibc client.
+
+The demand order events of the hub are received over the websocket of the hub
+node and stored in MongoDB. The pending orders of the hub are synced at start and
+then every --resync-interval, which recovers the events missed while the
+websocket was disconnected.
+
+The orders are served with the graphql api polled by the eibc client, use
+--configure-client to point the order polling of the eibc client to it. The
+progress of the indexer is served on /health.
+
+Unless --mongodb-uri is set, the MongoDB container of roller is started.
+
+The api only listens on localhost by default, use --public to listen on a public
+address (e.g. --listen :3000 --public) and serve the orders to other clients.
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				pterm.Error.Println("failed to get user home dir", err)
+				return
+			}
+
+			eibcHome := filepath.Join(home, consts.ConfigDirName.Eibc)
+			isEibcClientInitialized, err := filesystem.DirNotEmpty(eibcHome)
+			if err != nil {
+				pterm.Error.Println("failed to check eibc client initialized", err)
+				return
+			}
+			if !isEibcClientInitialized {
+				pterm.Error.Println("eibc client not initialized")
+				return
+			}
+
+			listen, _ := cmd.Flags().GetString(flagListen)
+			mongoURI, _ := cmd.Flags().GetString(flagMongoDbURI)
+			resyncInterval, _ := cmd.Flags().GetDuration(flagResyncInterval)
+			configureClient, _ := cmd.Flags().GetBool(flagConfigureClient)
+			public, _ := cmd.Flags().GetBool(flagPublic)
+			if resyncInterval <= 0 {
+				pterm.Error.Printfln("--%s must be positive", flagResyncInterval)
+				return
+			}
+			loopback, err := isLoopback(listen)
+			if err != nil {
+				pterm.Error.Printfln("invalid --%s: %v", flagListen, err)
+				return
+			}
+			if !loopback && !public {
+				pterm.Error.Printfln(
+					"%s exposes the indexer api outside of this machine, use --%s to allow it",
+					listen,
+					flagPublic,
+				)
+				return
+			}
+
+			eibcConfigPath := filepath.Join(eibcHome, "config.yaml")
+			var cfg eibcutils.Config
+			hd, err := cfg.HubDataFromHubRpc(eibcConfigPath)
+			if err != nil {
+				pterm.Error.Println("failed to retrieve hub data: ", err)
+				return
+			}
+
+			if !cmd.Flags().Changed(flagMongoDbURI) {
+				err = eibcutils.CreateMongoDbContainer()
+				if err != nil {
+					pterm.Error.Println("failed to start the mongodb container: ", err)
+					return
+				}
+			}
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			connectCtx, cancel := context.WithTimeout(ctx, time.Minute)
+			store, err := eibcutils.NewOrderStore(connectCtx, mongoURI, hd.ID)
+			cancel()
+			if err != nil {
+				pterm.Error.Println(err)
+				return
+			}
+			// nolint errcheck
+			defer store.Close(context.Background())
+
+			indexer := eibcutils.NewOrderIndexer(*hd, store)
+			srv := &http.Server{
+				Addr:              listen,
+				Handler:           indexer.Handler(),
+				ReadHeaderTimeout: 10 * time.Second,
+			}
+
+			srvErr := make(chan error, 1)
+			go func() {
+				err := srv.ListenAndServe()
+				if !errors.Is(err, http.ErrServerClosed) {
+					srvErr <- err
+				}
+			}()
+			// nolint errcheck
+			defer srv.Shutdown(context.Background())
+			pterm.Info.Printfln("serving the indexer api on %s", listen)
+
+			if configureClient {
+				err = useIndexer(eibcConfigPath, listen)
+				if err != nil {
+					pterm.Error.Println("failed to configure the eibc client: ", err)
+					return
+				}
+			}
+
+			runErr := make(chan error, 1)
+			go func() {
+				runErr <- indexer.Run(ctx, resyncInterval)
+			}()
+
+			select {
+			case err := <-srvErr:
+				pterm.Error.Println("indexer api failed: ", err)
+			case err := <-runErr:
+				if err != nil {
+					pterm.Error.Println("indexer failed: ", err)
+					return
+				}
+				pterm.Info.Println("indexer stopped")
+			}
+		},
+	}
+
+	cmd.Flags().String(flagListen, "localhost:3000", "address the indexer api listens on")
+	cmd.Flags().String(flagMongoDbURI, eibcutils.DefaultMongoDbURI, "mongodb the orders are stored in")
+	cmd.Flags().Duration(
+		flagResyncInterval,
+		5*time.Minute,
+		"interval of the sync of the pending orders with the hub",
+	)
+	cmd.Flags().Bool(
+		flagConfigureClient,
+		false,
+		"point the order polling of the eibc client to the indexer",
+	)
+	cmd.Flags().Bool(flagPublic, false, "allow the indexer api to listen on a public address")
+
+	return cmd
+}
+
+// isLoopback returns true when the address only accepts local connections
+func isLoopback(listen string) (bool, error) {
+	host, _, err := net.SplitHostPort(listen)
+	if err != nil {
+		return false, err
+	}
+	if host == "localhost" {
+		return true, nil
+	}
+
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback(), nil
+}
+
+// useIndexer enables the order polling of the eibc client from the local indexer
+func useIndexer(eibcConfigPath, listen string) error {
+	host, port, err := net.SplitHostPort(listen)
+	if err != nil {
+		return err
+	}
+	if host == "" || host == "0.0.0.0" || host == "::" {
+		host = "localhost"
+	}
+	indexerURL := fmt.Sprintf("http://%s/", net.JoinHostPort(host, port))
+
+	updates := map[string]interface{}{
+		"order_polling.indexer_url": indexerURL,
+		"order_polling.enabled":     true,
+	}
+	err = yamlconfig.UpdateNestedYAML(eibcConfigPath, updates)
+	if err != nil {
+		return err
+	}
+
+	pterm.Info.Printfln(
+		"the eibc client polls the orders from %s, restart it with "+
+			"'roller eibc start --order-source indexer'",
+		indexerURL,
+	)
+	return nil
+}
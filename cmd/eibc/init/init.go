@@ -35,6 +35,8 @@ import (
 //go:embed templates/*.tmpl
 var embeddedTemplates embed.FS
 
+const flagIndexerURL = "indexer-url"
+
 func Cmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "init",
@@ -78,6 +80,9 @@ func Cmd() *cobra.Command {
 			} else {
 				hd = rollerConfig.HubData
 			}
+			if indexerURL, _ := cmd.Flags().GetString(flagIndexerURL); indexerURL != "" {
+				hd.IndexerUrl = indexerURL
+			}
 
 			isEibcClientInitialized, err := filesystem.DirNotEmpty(eibcHome)
 			if err != nil {
@@ -257,6 +262,14 @@ func Cmd() *cobra.Command {
 			}
 		},
 	}
+
+	cmd.Flags().String(
+		flagIndexerURL,
+		"",
+		"eibc order indexer polled by the eibc client, defaults to the indexer_url of the "+
+			"hub in roller.toml, the orders are queried from the hub without indexer",
+	)
+
 	return cmd
 }
 
@@ -630,10 +643,12 @@ func createPolicyFile(eibcHome string) error {
 }
 
 func updateEibcConfig(eibcConfigPath string, hd consts.HubData) error {
+	// without an indexer, the eibc client queries the hub
+	indexerURL := hd.IndexerUrl
 	updates := map[string]interface{}{
 		"node_address":              hd.RpcUrl,
-		"order_polling.indexer_url": consts.DefaultIndexer,
-		"order_polling.enabled":     true,
+		"order_polling.indexer_url": indexerURL,
+		"order_polling.enabled":     indexerURL != "",
 		"operator.account_name":     consts.KeysIds.Eibc,
 		"gas.fees":                  "4000000000000000adym",
 		// "rollapps.example_1234-1":   nil,
@@ -0,0 +1,20 @@
+package liquidity
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/dymensionxyz/roller/cmd/eibc/liquidity/rebalance"
+	"github.com/dymensionxyz/roller/cmd/eibc/liquidity/show"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "liquidity",
+		Short: "Commands to track and rebalance the funds used to fulfill eibc orders",
+	}
+
+	cmd.AddCommand(show.Cmd())
+	cmd.AddCommand(rebalance.Cmd())
+
+	return cmd
+}
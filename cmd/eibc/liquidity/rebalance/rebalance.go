@@ -0,0 +1,202 @@
+package rebalance
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	cosmossdkmath "cosmossdk.io/math"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	eibcutils "github.com/dymensionxyz/roller/utils/eibc"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+)
+
+const (
+	flagRatio       = "ratio"
+	flagTarget      = "target"
+	flagGasReserve  = "gas-reserve"
+	flagMinTransfer = "min-transfer"
+)
+
+// defaultGasReserve is 10dym
+const defaultGasReserve = "10000000000000000000"
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rebalance",
+		Short: "Move funds between the whale account and the group policy according to target ratios",
+		Long: `Move funds between the whale account and the group policy according to target ratios.
+
+The ratio of a denom is the share of its funds that should be held by the group
+policy, the funds used to fulfill orders. The rest is kept in the whale account.
+The whale account always keeps --gas-reserve ` + consts.Denoms.Hub + ` for the transaction fees.
+
+Funds are sent to the policy from the whale account, and withdrawn from the
+policy by a group proposal that is executed immediately.
+`,
+		Example: `  roller eibc liquidity rebalance --ratio 0.8
+  roller eibc liquidity rebalance --target adym=0.5,ibc/27394FB0=0.9`,
+		Run: func(cmd *cobra.Command, args []string) {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				pterm.Error.Println("failed to get user home dir", err)
+				return
+			}
+
+			eibcHome := filepath.Join(home, consts.ConfigDirName.Eibc)
+			isEibcClientInitialized, err := filesystem.DirNotEmpty(eibcHome)
+			if err != nil {
+				pterm.Error.Println("failed to check eibc client initialized", err)
+				return
+			}
+			if !isEibcClientInitialized {
+				pterm.Error.Println("eibc client not initialized")
+				return
+			}
+
+			targets, err := targetsFromFlags(cmd)
+			if err != nil {
+				pterm.Error.Println(err)
+				return
+			}
+
+			var cfg eibcutils.Config
+			hd, err := cfg.HubDataFromHubRpc(filepath.Join(eibcHome, "config.yaml"))
+			if err != nil {
+				pterm.Error.Println("failed to retrieve hub data: ", err)
+				return
+			}
+			if cfg.Fulfillers.PolicyAddress == "" {
+				pterm.Error.Println("no policy address in the eibc config, run 'roller eibc init'")
+				return
+			}
+
+			_, liquidity, err := eibcutils.QueryLiquidity(home, cfg, *hd)
+			if err != nil {
+				pterm.Error.Println("failed to query eibc liquidity: ", err)
+				return
+			}
+
+			transfers := eibcutils.PlanRebalance(liquidity, targets)
+			if len(transfers) == 0 {
+				pterm.Success.Println("the funds are already balanced")
+				return
+			}
+
+			data := pterm.TableData{{"Denom", "Amount", "From", "To", "Target Ratio"}}
+			for _, t := range transfers {
+				from, to := "policy", "whale"
+				if t.ToPolicy {
+					from, to = to, from
+				}
+				data = append(data, []string{
+					t.Denom,
+					t.Amount.String(),
+					from,
+					to,
+					strconv.FormatFloat(targets.Ratio(t.Denom), 'f', -1, 64),
+				})
+			}
+			// nolint: errcheck
+			pterm.DefaultTable.WithHasHeader().WithData(data).Render()
+
+			proceed, _ := pterm.DefaultInteractiveConfirm.WithDefaultValue(false).
+				WithDefaultText("would you like to execute the transfers?").
+				Show()
+			if !proceed {
+				pterm.Info.Println("exiting")
+				return
+			}
+
+			res, err := eibcutils.ExecuteRebalance(home, cfg, transfers, *hd)
+			if res != nil && !res.Funded.Empty() {
+				pterm.Success.Printfln("%s sent to the policy", res.Funded)
+			}
+			if err != nil {
+				pterm.Error.Println("failed to rebalance: ", err)
+				return
+			}
+
+			p := res.Proposal
+			switch {
+			case p == nil || p.Executed():
+				pterm.Success.Println("funds rebalanced")
+			case p.Failed():
+				pterm.Error.Printfln(
+					"withdrawal of %s failed, proposal %s execution failed: %s",
+					res.Withdrawal,
+					p.ProposalID,
+					p.Logs,
+				)
+			case p.Status == "PROPOSAL_STATUS_SUBMITTED":
+				pterm.Warning.Printfln(
+					"withdrawal of %s is pending, proposal %s needs more votes",
+					res.Withdrawal,
+					p.ProposalID,
+				)
+			case p.Status == "PROPOSAL_STATUS_ACCEPTED":
+				pterm.Warning.Printfln(
+					"withdrawal of %s is pending, execute proposal %s with "+
+						"'roller eibc group proposals exec %s'",
+					res.Withdrawal,
+					p.ProposalID,
+					p.ProposalID,
+				)
+			default:
+				pterm.Error.Printfln(
+					"withdrawal of %s failed, proposal %s is %s",
+					res.Withdrawal,
+					p.ProposalID,
+					p.Status,
+				)
+			}
+		},
+	}
+
+	cmd.Flags().Float64(flagRatio, 0.9, "share of the funds of every denom held by the policy")
+	cmd.Flags().StringToString(flagTarget, nil, "policy share per denom, overrides --ratio")
+	cmd.Flags().String(
+		flagGasReserve,
+		defaultGasReserve,
+		"amount of "+consts.Denoms.Hub+" kept in the whale account",
+	)
+	cmd.Flags().Float64(
+		flagMinTransfer,
+		0.01,
+		"skip the transfers smaller than this share of the denom funds",
+	)
+
+	return cmd
+}
+
+func targetsFromFlags(cmd *cobra.Command) (eibcutils.RebalanceTargets, error) {
+	ratio, _ := cmd.Flags().GetFloat64(flagRatio)
+	perDenom, _ := cmd.Flags().GetStringToString(flagTarget)
+	reserve, _ := cmd.Flags().GetString(flagGasReserve)
+	minTransfer, _ := cmd.Flags().GetFloat64(flagMinTransfer)
+
+	gasReserve, ok := cosmossdkmath.NewIntFromString(reserve)
+	if !ok {
+		return eibcutils.RebalanceTargets{}, fmt.Errorf("invalid --%s: %s", flagGasReserve, reserve)
+	}
+
+	targets := eibcutils.RebalanceTargets{
+		Ratios:      map[string]float64{},
+		Default:     ratio,
+		GasReserve:  gasReserve,
+		MinTransfer: minTransfer,
+	}
+	for denom, v := range perDenom {
+		r, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return eibcutils.RebalanceTargets{}, fmt.Errorf("invalid ratio of %s: %s", denom, v)
+		}
+		targets.Ratios[denom] = r
+	}
+
+	return targets, targets.Validate()
+}
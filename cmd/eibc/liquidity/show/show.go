@@ -0,0 +1,136 @@
+package show
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	eibcutils "github.com/dymensionxyz/roller/utils/eibc"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+)
+
+const flagMinCoverage = "min-coverage"
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show",
+		Short: "Show the funds available per denom across the whale, the policy and the fulfillers",
+		Long: `Show the funds available per denom across the whale account, the group policy
+and the fulfillers.
+
+The orders are fulfilled with the funds of the group policy. The demand of a
+denom is the price of the unfulfilled orders of the supported RollApps that are
+pending finalization on the hub, a warning is printed when the policy funds cover less
+than --min-coverage times the demand.
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				pterm.Error.Println("failed to get user home dir", err)
+				return
+			}
+
+			eibcHome := filepath.Join(home, consts.ConfigDirName.Eibc)
+			isEibcClientInitialized, err := filesystem.DirNotEmpty(eibcHome)
+			if err != nil {
+				pterm.Error.Println("failed to check eibc client initialized", err)
+				return
+			}
+			if !isEibcClientInitialized {
+				pterm.Error.Println("eibc client not initialized")
+				return
+			}
+
+			minCoverage, _ := cmd.Flags().GetFloat64(flagMinCoverage)
+
+			var cfg eibcutils.Config
+			hd, err := cfg.HubDataFromHubRpc(filepath.Join(eibcHome, "config.yaml"))
+			if err != nil {
+				pterm.Error.Println("failed to retrieve hub data: ", err)
+				return
+			}
+
+			accounts, liquidity, err := eibcutils.QueryLiquidity(home, cfg, *hd)
+			if err != nil {
+				pterm.Error.Println("failed to query eibc liquidity: ", err)
+				return
+			}
+
+			printAccounts(accounts)
+			printLiquidity(liquidity, minCoverage)
+		},
+	}
+
+	cmd.Flags().Float64(
+		flagMinCoverage,
+		1,
+		"warn when the policy funds cover less than this multiple of the pending order volume",
+	)
+
+	return cmd
+}
+
+func printAccounts(accounts []eibcutils.AccountFunds) {
+	var fulfillers int
+	data := pterm.TableData{{"Name", "Role", "Address", "Balances"}}
+	for _, a := range accounts {
+		if a.Role == eibcutils.AccountRoles.Fulfiller {
+			fulfillers++
+			if !a.Balances.AmountOf(consts.Denoms.Hub).IsPositive() {
+				pterm.Warning.Printfln("fulfiller %s (%s) has no funds for gas", a.Name, a.Address)
+			}
+			continue
+		}
+		data = append(data, []string{a.Name, a.Role, a.Address, a.Balances.String()})
+	}
+
+	// nolint: errcheck
+	pterm.DefaultTable.WithHasHeader().WithData(data).Render()
+	pterm.Info.Printfln("%d fulfillers", fulfillers)
+}
+
+func printLiquidity(liquidity []eibcutils.DenomLiquidity, minCoverage float64) {
+	data := pterm.TableData{
+		{"Denom", "Whale", "Policy", "Fulfillers", "Pending Orders", "Pending Volume", "Coverage"},
+	}
+	var low []eibcutils.DenomLiquidity
+	for _, l := range liquidity {
+		coverage := "-"
+		if c := l.Coverage(); c >= 0 {
+			coverage = strconv.FormatFloat(c, 'f', 2, 64) + "x"
+		}
+		data = append(data, []string{
+			l.Denom,
+			l.Whale.String(),
+			l.Policy.String(),
+			l.Fulfillers.String(),
+			strconv.Itoa(l.Orders),
+			l.Demand.String(),
+			coverage,
+		})
+		if l.Low(minCoverage) {
+			low = append(low, l)
+		}
+	}
+
+	// nolint: errcheck
+	pterm.DefaultTable.WithHasHeader().WithData(data).Render()
+
+	for _, l := range low {
+		pterm.Warning.Printfln(
+			"%s is running low: the policy holds %s for %s of pending orders",
+			l.Denom,
+			l.Policy,
+			l.Demand,
+		)
+	}
+	if len(low) > 0 {
+		pterm.Info.Println(
+			"fund the policy with 'roller eibc liquidity rebalance' or by sending funds to it",
+		)
+	}
+}
@@ -0,0 +1,137 @@
+package apply
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	eibcutils "github.com/dymensionxyz/roller/utils/eibc"
+)
+
+const flagFile = "file"
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "apply",
+		Short: "Publish the edited metadata of the eibc operator",
+		Long: `Publish the edited metadata of the eibc operator.
+
+The draft written by 'roller eibc operator metadata edit', or the YAML file set
+with --file, is validated and compared to the on-chain metadata before being
+published. The operator group is updated by the whale account, or with a
+proposal of the group policy when the policy administers the group.
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				pterm.Error.Println("failed to get user home dir", err)
+				return
+			}
+			file, _ := cmd.Flags().GetString(flagFile)
+
+			gi, err := eibcutils.LoadGroupInfo(home)
+			if err != nil {
+				pterm.Error.Println(err)
+				return
+			}
+
+			var cfg eibcutils.Config
+			err = cfg.LoadConfig(filepath.Join(gi.EibcHome, "config.yaml"))
+			if err != nil {
+				pterm.Error.Println("failed to load eibc config: ", err)
+				return
+			}
+
+			draftPath := eibcutils.OperatorMetadataPath(gi.EibcHome)
+			if file == "" {
+				file = draftPath
+			}
+			edited, err := eibcutils.LoadOperatorMetadataFile(file)
+			if os.IsNotExist(err) {
+				pterm.Error.Printfln(
+					"%s not found, run %s first",
+					file,
+					pterm.DefaultBasicText.WithStyle(pterm.FgYellow.ToStyle()).
+						Sprint("roller eibc operator metadata edit"),
+				)
+				return
+			}
+			if err != nil {
+				pterm.Error.Println(err)
+				return
+			}
+			err = edited.Validate(cfg)
+			if err != nil {
+				pterm.Error.Println(err)
+				return
+			}
+
+			onChain, g, err := eibcutils.OperatorMetadataOnChain(*gi)
+			if err != nil {
+				pterm.Error.Println(err)
+				return
+			}
+
+			diff, err := eibcutils.OperatorMetadataDiff(onChain, edited)
+			if err != nil {
+				pterm.Error.Println("failed to compare the metadata: ", err)
+				return
+			}
+			if diff == "" {
+				pterm.Info.Println("the metadata matches the on-chain metadata, nothing to publish")
+				return
+			}
+			eibcutils.PrintDiff(diff)
+
+			proceed, _ := pterm.DefaultInteractiveConfirm.WithDefaultText(
+				"publish the changes?",
+			).Show()
+			if !proceed {
+				return
+			}
+
+			if file != draftPath {
+				draftPath = ""
+			}
+			err = Publish(*gi, *g, edited, draftPath)
+			if err != nil {
+				pterm.Error.Println("failed to update eibc operator metadata: ", err)
+				return
+			}
+		},
+	}
+
+	cmd.Flags().String(flagFile, "", "YAML metadata to publish instead of the draft")
+
+	return cmd
+}
+
+// Publish updates the operator group with the metadata, the draft is removed once the
+// metadata is published
+func Publish(
+	gi eibcutils.GroupInfo,
+	g eibcutils.Group,
+	m *eibcutils.EibcOperatorMetadata,
+	draftPath string,
+) error {
+	if g.Admin == gi.PolicyAddress {
+		pterm.Info.Println("the group is administered by its policy, submitting a proposal")
+	}
+
+	txHash, err := eibcutils.ApplyOperatorMetadata(gi, g, m)
+	if err != nil {
+		return err
+	}
+	pterm.Success.Printfln("operator metadata updated in tx %s", txHash)
+
+	if draftPath != "" {
+		err = os.Remove(draftPath)
+		if err != nil {
+			pterm.Warning.Printfln("failed to remove the draft %s: %v", draftPath, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,143 @@
+package edit
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	"github.com/dymensionxyz/roller/cmd/eibc/operator/metadata/apply"
+	"github.com/dymensionxyz/roller/utils/bash"
+	eibcutils "github.com/dymensionxyz/roller/utils/eibc"
+)
+
+const flagReset = "reset"
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "edit",
+		Short: "Edit the metadata of the eibc operator",
+		Long: `Edit the metadata of the eibc operator.
+
+The metadata is opened in $EDITOR as YAML, the edits are kept in a draft in the
+eibc home until they are published with 'roller eibc operator metadata apply'.
+The draft starts from the on-chain metadata, or from the existing draft unless
+--reset is set. The edited metadata is validated against the eibc client
+config and compared to the on-chain metadata.
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				pterm.Error.Println("failed to get user home dir", err)
+				return
+			}
+			reset, _ := cmd.Flags().GetBool(flagReset)
+
+			gi, err := eibcutils.LoadGroupInfo(home)
+			if err != nil {
+				pterm.Error.Println(err)
+				return
+			}
+
+			var cfg eibcutils.Config
+			err = cfg.LoadConfig(filepath.Join(gi.EibcHome, "config.yaml"))
+			if err != nil {
+				pterm.Error.Println("failed to load eibc config: ", err)
+				return
+			}
+
+			onChain, g, err := eibcutils.OperatorMetadataOnChain(*gi)
+			if err != nil {
+				pterm.Error.Println(err)
+				return
+			}
+
+			draftPath := eibcutils.OperatorMetadataPath(gi.EibcHome)
+			_, err = os.Stat(draftPath)
+			if reset || os.IsNotExist(err) {
+				err = eibcutils.WriteOperatorMetadataFile(draftPath, onChain)
+				if err != nil {
+					pterm.Error.Println("failed to write the draft: ", err)
+					return
+				}
+			}
+
+			var edited *eibcutils.EibcOperatorMetadata
+			for {
+				e := editor()
+				err = bash.ExecCommandWithInteractions(e[0], append(e[1:], draftPath)...)
+				if err != nil {
+					pterm.Error.Println("failed to run the editor: ", err)
+					return
+				}
+
+				edited, err = eibcutils.LoadOperatorMetadataFile(draftPath)
+				if err == nil {
+					err = edited.Validate(cfg)
+				}
+				if err == nil {
+					break
+				}
+
+				pterm.Error.Println(err)
+				retry, _ := pterm.DefaultInteractiveConfirm.WithDefaultText(
+					"edit the metadata again?",
+				).WithDefaultValue(true).Show()
+				if !retry {
+					pterm.Info.Printfln("the invalid draft is kept in %s", draftPath)
+					return
+				}
+			}
+
+			diff, err := eibcutils.OperatorMetadataDiff(onChain, edited)
+			if err != nil {
+				pterm.Error.Println("failed to compare the metadata: ", err)
+				return
+			}
+			if diff == "" {
+				pterm.Info.Println("the metadata matches the on-chain metadata")
+				return
+			}
+			eibcutils.PrintDiff(diff)
+
+			publish, _ := pterm.DefaultInteractiveConfirm.WithDefaultText(
+				"publish the changes now?",
+			).Show()
+			if !publish {
+				pterm.Info.Printfln(
+					"the draft is saved in %s, run %s to publish it",
+					draftPath,
+					pterm.DefaultBasicText.WithStyle(pterm.FgYellow.ToStyle()).
+						Sprint("roller eibc operator metadata apply"),
+				)
+				return
+			}
+
+			err = apply.Publish(*gi, *g, edited, draftPath)
+			if err != nil {
+				pterm.Error.Println("failed to update eibc operator metadata: ", err)
+				return
+			}
+		},
+	}
+
+	cmd.Flags().Bool(
+		flagReset,
+		false,
+		"discard the existing draft and start from the on-chain metadata",
+	)
+
+	return cmd
+}
+
+// editor returns the editor command of the user and its arguments
+func editor() []string {
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if e := strings.Fields(os.Getenv(env)); len(e) > 0 {
+			return e
+		}
+	}
+	return []string{"vi"}
+}
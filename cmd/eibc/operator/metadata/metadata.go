@@ -0,0 +1,28 @@
+package metadata
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/dymensionxyz/roller/cmd/eibc/operator/metadata/apply"
+	"github.com/dymensionxyz/roller/cmd/eibc/operator/metadata/edit"
+	"github.com/dymensionxyz/roller/cmd/eibc/operator/metadata/show"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "metadata",
+		Short: "Commands to manage the metadata of the eibc operator",
+		Long: `Commands to manage the metadata of the eibc operator.
+
+The metadata describes the operator to the liquidity providers, it's published
+in the metadata of the operator group. The metadata is edited in a local draft
+and published on the hub with 'apply'.
+`,
+	}
+
+	cmd.AddCommand(show.Cmd())
+	cmd.AddCommand(edit.Cmd())
+	cmd.AddCommand(apply.Cmd())
+
+	return cmd
+}
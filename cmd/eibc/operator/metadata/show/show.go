@@ -0,0 +1,67 @@
+package show
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	eibcutils "github.com/dymensionxyz/roller/utils/eibc"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show",
+		Short: "Show the on-chain metadata of the eibc operator",
+		Run: func(cmd *cobra.Command, args []string) {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				pterm.Error.Println("failed to get user home dir", err)
+				return
+			}
+
+			gi, err := eibcutils.LoadGroupInfo(home)
+			if err != nil {
+				pterm.Error.Println(err)
+				return
+			}
+
+			onChain, g, err := eibcutils.OperatorMetadataOnChain(*gi)
+			if err != nil {
+				pterm.Error.Println(err)
+				return
+			}
+
+			ym, err := yaml.Marshal(onChain)
+			if err != nil {
+				pterm.Error.Println("failed to marshal eibc operator metadata: ", err)
+				return
+			}
+			pterm.Info.Printfln("metadata of the operator group %s:", g.ID)
+			fmt.Println(string(ym))
+
+			draftPath := eibcutils.OperatorMetadataPath(gi.EibcHome)
+			draft, err := eibcutils.LoadOperatorMetadataFile(draftPath)
+			if err != nil {
+				if !os.IsNotExist(err) {
+					pterm.Warning.Printfln("failed to read the draft %s: %v", draftPath, err)
+				}
+				return
+			}
+
+			diff, err := eibcutils.OperatorMetadataDiff(onChain, draft)
+			if err == nil && diff != "" {
+				pterm.Info.Printfln(
+					"the draft %s has unpublished changes, run %s to publish them",
+					draftPath,
+					pterm.DefaultBasicText.WithStyle(pterm.FgYellow.ToStyle()).
+						Sprint("roller eibc operator metadata apply"),
+				)
+			}
+		},
+	}
+
+	return cmd
+}
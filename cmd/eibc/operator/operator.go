@@ -0,0 +1,18 @@
+package operator
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/dymensionxyz/roller/cmd/eibc/operator/metadata"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "operator",
+		Short: "Commands to manage the eibc operator",
+	}
+
+	cmd.AddCommand(metadata.Cmd())
+
+	return cmd
+}
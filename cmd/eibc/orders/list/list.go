@@ -0,0 +1,289 @@
+package list
+
+import (
+	"cmp"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	eibcutils "github.com/dymensionxyz/roller/utils/eibc"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+)
+
+const (
+	flagRollapp       = "rollapp"
+	flagDenom         = "denom"
+	flagMinFeePercent = "min-fee-percent"
+	flagMaxAge        = "max-age"
+	flagStatus        = "status"
+	flagSource        = "source"
+	flagLimit         = "limit"
+	flagSelect        = "select"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the pending eibc demand orders",
+		Long: `List the pending eibc demand orders of the hub the eibc client is connected to.
+
+The orders are queried from the indexer of the order polling config when it's
+enabled, and from the hub otherwise. The orders are sorted by fee percentage,
+the expected profit is the part of the order fee earned at the operator min fee
+share, in the denom of the order. The order ages are only queried with
+'--max-age'. Use '--select' to pick an order
+from the list and fulfill it with the eibc account, only the orders that
+satisfy the fulfill policy can be selected.
+`,
+		Example: `  roller eibc orders list --rollapp rollappevm_1234-1 --min-fee-percent 0.5
+  roller eibc orders list --max-age 1h --select`,
+		Run: func(cmd *cobra.Command, args []string) {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				pterm.Error.Println("failed to get user home dir", err)
+				return
+			}
+
+			eibcHome := filepath.Join(home, consts.ConfigDirName.Eibc)
+			isEibcClientInitialized, err := filesystem.DirNotEmpty(eibcHome)
+			if err != nil {
+				pterm.Error.Println("failed to check eibc client initialized", err)
+				return
+			}
+			if !isEibcClientInitialized {
+				pterm.Error.Println("eibc client not initialized")
+				return
+			}
+
+			filter, err := filterFromFlags(cmd)
+			if err != nil {
+				pterm.Error.Println(err)
+				return
+			}
+			source, _ := cmd.Flags().GetString(flagSource)
+			limit, _ := cmd.Flags().GetInt(flagLimit)
+			interactive, _ := cmd.Flags().GetBool(flagSelect)
+
+			eibcConfigPath := filepath.Join(eibcHome, "config.yaml")
+			var cfg eibcutils.Config
+			hd, err := cfg.HubDataFromHubRpc(eibcConfigPath)
+			if err != nil {
+				pterm.Error.Println("failed to retrieve hub data: ", err)
+				return
+			}
+
+			orders, err := queryOrders(source, cfg, *hd)
+			if err != nil {
+				pterm.Error.Println("failed to query demand orders: ", err)
+				return
+			}
+
+			now := time.Now()
+			maxAge := filter.MaxAge
+			filter.MaxAge = 0
+			orders = eibcutils.FilterOrders(orders, filter, now)
+			// the creation times are queried block by block, only for the orders that
+			// passed the other filters
+			if maxAge > 0 {
+				eibcutils.FillCreationTimes(hd.RpcUrl, orders)
+				orders = eibcutils.FilterOrders(orders, eibcutils.OrderFilter{MaxAge: maxAge}, now)
+			}
+			// the fees are in the denoms of the orders, so they're compared as percentages
+			slices.SortStableFunc(orders, func(a, b eibcutils.DemandOrder) int {
+				return cmp.Compare(b.FeePercentage(), a.FeePercentage())
+			})
+			if limit > 0 && len(orders) > limit {
+				orders = orders[:limit]
+			}
+
+			if len(orders) == 0 {
+				pterm.Info.Println("no demand orders match the filters")
+				return
+			}
+
+			feeShare := float64(cfg.OperatorConfig.MinFeeShare)
+			printOrders(orders, feeShare)
+
+			if !interactive {
+				return
+			}
+
+			err = selectAndFulfill(home, orders, feeShare, cfg.FulfillCriteria, *hd)
+			if err != nil {
+				pterm.Error.Println("failed to fulfill order: ", err)
+				return
+			}
+		},
+	}
+
+	cmd.Flags().String(flagRollapp, "", "only show the orders of the rollapp")
+	cmd.Flags().String(flagDenom, "", "only show the orders of the denom")
+	cmd.Flags().Float64(flagMinFeePercent, 0, "only show the orders with a higher fee percentage")
+	cmd.Flags().Duration(flagMaxAge, 0, "only show the orders created within the duration")
+	cmd.Flags().String(
+		flagStatus,
+		eibcutils.FulfillmentStatuses.Unfulfilled,
+		"fulfilment status of the orders to show (unfulfilled, fulfilled, any)",
+	)
+	cmd.Flags().String(
+		flagSource,
+		eibcutils.OrderSources.Auto,
+		"source of the orders (auto, hub, indexer)",
+	)
+	cmd.Flags().Int(flagLimit, 50, "maximum number of orders to show, 0 shows all of them")
+	cmd.Flags().Bool(flagSelect, false, "select an order to fulfill")
+
+	return cmd
+}
+
+func filterFromFlags(cmd *cobra.Command) (eibcutils.OrderFilter, error) {
+	rollappID, _ := cmd.Flags().GetString(flagRollapp)
+	denom, _ := cmd.Flags().GetString(flagDenom)
+	minFee, _ := cmd.Flags().GetFloat64(flagMinFeePercent)
+	maxAge, _ := cmd.Flags().GetDuration(flagMaxAge)
+	status, _ := cmd.Flags().GetString(flagStatus)
+
+	if !slices.Contains([]string{
+		eibcutils.FulfillmentStatuses.Unfulfilled,
+		eibcutils.FulfillmentStatuses.Fulfilled,
+		eibcutils.FulfillmentStatuses.Any,
+	}, status) {
+		return eibcutils.OrderFilter{}, fmt.Errorf("invalid --%s: %s", flagStatus, status)
+	}
+	if minFee < 0 || minFee > 100 {
+		return eibcutils.OrderFilter{}, fmt.Errorf(
+			"--%s must be between 0 and 100, got %v",
+			flagMinFeePercent,
+			minFee,
+		)
+	}
+
+	return eibcutils.OrderFilter{
+		RollappID:        rollappID,
+		Denom:            denom,
+		MinFeePercentage: minFee,
+		MaxAge:           maxAge,
+		Fulfillment:      status,
+	}, nil
+}
+
+// queryOrders queries the indexer when it's used by the eibc client, the hub is queried
+// instead when the indexer fails in auto mode
+func queryOrders(
+	source string,
+	cfg eibcutils.Config,
+	hd consts.HubData,
+) ([]eibcutils.DemandOrder, error) {
+	useIndexer := cfg.OrderPolling.Enabled && cfg.OrderPolling.IndexerURL != ""
+
+	switch source {
+	case eibcutils.OrderSources.Hub:
+		return eibcutils.QueryHubDemandOrders(hd, eibcutils.OrderStatuses.Pending)
+	case eibcutils.OrderSources.Indexer:
+		if cfg.OrderPolling.IndexerURL == "" {
+			return nil, fmt.Errorf("no indexer url in the eibc config")
+		}
+		return eibcutils.QueryIndexerDemandOrders(cfg.OrderPolling.IndexerURL, hd.ID)
+	case eibcutils.OrderSources.Auto:
+		if useIndexer {
+			orders, err := eibcutils.QueryIndexerDemandOrders(cfg.OrderPolling.IndexerURL, hd.ID)
+			if err == nil {
+				return orders, nil
+			}
+			pterm.Warning.Println("failed to query the indexer, querying the hub: ", err)
+		}
+		return eibcutils.QueryHubDemandOrders(hd, eibcutils.OrderStatuses.Pending)
+	default:
+		return nil, fmt.Errorf("invalid --%s: %s", flagSource, source)
+	}
+}
+
+func printOrders(orders []eibcutils.DemandOrder, feeShare float64) {
+	data := pterm.TableData{
+		{"ID", "RollApp", "Denom", "Price", "Fee", "Fee %", "Expected Profit", "Age", "Fulfilled"},
+	}
+	now := time.Now()
+	for _, o := range orders {
+		age := "unknown"
+		if !o.CreatedAt.IsZero() {
+			age = now.Sub(o.CreatedAt).Truncate(time.Second).String()
+		}
+		data = append(data, []string{
+			o.ID,
+			o.RollappID,
+			o.Denom,
+			o.Price.String(),
+			o.Fee.String(),
+			strconv.FormatFloat(o.FeePercentage(), 'f', 3, 64),
+			o.ExpectedProfit(feeShare).String(),
+			age,
+			strconv.FormatBool(o.Fulfilled()),
+		})
+	}
+
+	// nolint: errcheck
+	pterm.DefaultTable.WithHasHeader().WithData(data).Render()
+	pterm.Info.Printfln("expected profit computed at a fee share of %v", feeShare)
+}
+
+func selectAndFulfill(
+	home string,
+	orders []eibcutils.DemandOrder,
+	feeShare float64,
+	policy eibcutils.FulfillPolicy,
+	hd consts.HubData,
+) error {
+	var options []string
+	byOption := map[string]eibcutils.DemandOrder{}
+	var refused int
+	for _, o := range orders {
+		if o.Fulfilled() {
+			continue
+		}
+		if policy.Allows(o) != "" {
+			refused++
+			continue
+		}
+		opt := fmt.Sprintf(
+			"%s (%s%s, fee %s, profit %s)",
+			o.ID,
+			o.Price.String(),
+			o.Denom,
+			o.Fee.String(),
+			o.ExpectedProfit(feeShare).String(),
+		)
+		options = append(options, opt)
+		byOption[opt] = o
+	}
+	if refused > 0 {
+		pterm.Info.Printfln("%d of the listed orders don't satisfy the fulfill policy", refused)
+	}
+	if len(options) == 0 {
+		pterm.Info.Println("none of the listed orders can be fulfilled")
+		return nil
+	}
+
+	selected, err := pterm.DefaultInteractiveSelect.
+		WithDefaultText("select the order to fulfill").
+		WithOptions(options).
+		Show()
+	if err != nil {
+		return err
+	}
+
+	o := byOption[selected]
+	err = eibcutils.FulfillOrder(home, o.ID, o.Fee.String(), hd)
+	if err != nil {
+		return err
+	}
+
+	pterm.Success.Printfln("order %s fulfilled", o.ID)
+	return nil
+}
@@ -0,0 +1,18 @@
+package orders
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/dymensionxyz/roller/cmd/eibc/orders/list"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "orders",
+		Short: "Commands to inspect the eibc demand orders",
+	}
+
+	cmd.AddCommand(list.Cmd())
+
+	return cmd
+}
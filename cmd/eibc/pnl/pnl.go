@@ -0,0 +1,273 @@
+package pnl
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	cosmossdkmath "cosmossdk.io/math"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	eibcutils "github.com/dymensionxyz/roller/utils/eibc"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+)
+
+const (
+	flagDays      = "days"
+	flagGroupBy   = "group-by"
+	flagCsv       = "csv"
+	flagAddresses = "address"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pnl",
+		Short: "Show the profit and loss of the eibc operator",
+		Long: `Show the profit and loss of the eibc operator.
+
+The orders fulfilled with the funds of the operator and of the group policy, and
+the gas paid by the fulfillers of the eibc client, are reconstructed from the
+hub transaction history, which requires a hub node with transaction indexing
+enabled. For every day, RollApp or asset the report shows
+the fulfilled volume, the order fees, the part of the fees earned by the
+operator and the gas spent in ` + consts.Denoms.Hub + `. The operator income is estimated
+from the operator min fee share when the hub doesn't report it.
+
+The capital locked in orders that are fulfilled but not finalized yet is shown
+per RollApp and asset.
+`,
+		Example: `  roller eibc pnl --days 7 --group-by rollapp
+  roller eibc pnl --csv pnl.csv`,
+		Run: func(cmd *cobra.Command, args []string) {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				pterm.Error.Println("failed to get user home dir", err)
+				return
+			}
+
+			eibcHome := filepath.Join(home, consts.ConfigDirName.Eibc)
+			isEibcClientInitialized, err := filesystem.DirNotEmpty(eibcHome)
+			if err != nil {
+				pterm.Error.Println("failed to check eibc client initialized", err)
+				return
+			}
+			if !isEibcClientInitialized {
+				pterm.Error.Println("eibc client not initialized")
+				return
+			}
+
+			days, _ := cmd.Flags().GetInt(flagDays)
+			groupBy, _ := cmd.Flags().GetString(flagGroupBy)
+			csvPath, _ := cmd.Flags().GetString(flagCsv)
+			extra, _ := cmd.Flags().GetStringSlice(flagAddresses)
+
+			if !slices.Contains([]string{
+				eibcutils.PnLGroupings.Day,
+				eibcutils.PnLGroupings.Rollapp,
+				eibcutils.PnLGroupings.Asset,
+			}, groupBy) {
+				pterm.Error.Printfln("invalid --%s: %s", flagGroupBy, groupBy)
+				return
+			}
+			if days <= 0 {
+				pterm.Error.Printfln("--%s must be positive", flagDays)
+				return
+			}
+
+			eibcConfigPath := filepath.Join(eibcHome, "config.yaml")
+			var cfg eibcutils.Config
+			hd, err := cfg.HubDataFromHubRpc(eibcConfigPath)
+			if err != nil {
+				pterm.Error.Println("failed to retrieve hub data: ", err)
+				return
+			}
+
+			ki, err := eibcutils.GetKeyConfig().Info(home)
+			if err != nil {
+				pterm.Error.Println("failed to retrieve eibc operator address: ", err)
+				return
+			}
+
+			addresses := []string{ki.Address}
+			if cfg.Fulfillers.PolicyAddress != "" {
+				addresses = append(addresses, cfg.Fulfillers.PolicyAddress)
+			}
+			// the fulfillers sign and pay the gas of the fulfilments of the eibc client
+			fulfillers, err := eibcutils.ListFulfillers(cfg)
+			if err != nil {
+				pterm.Error.Println("failed to list the fulfillers: ", err)
+				return
+			}
+			for _, f := range fulfillers {
+				if !slices.Contains(addresses, f.Address) {
+					addresses = append(addresses, f.Address)
+				}
+			}
+			addresses = append(addresses, extra...)
+
+			since := time.Now().AddDate(0, 0, -days)
+			feeShare := float64(cfg.OperatorConfig.MinFeeShare)
+
+			spinner, _ := pterm.DefaultSpinner.Start("reconstructing fulfilled orders from the hub")
+			history, err := eibcutils.QueryPnLHistory(*hd, addresses, since, feeShare)
+			if err != nil {
+				spinner.Fail("failed to query the hub transactions: ", err)
+				return
+			}
+
+			spinner.UpdateText("querying the orders pending finalization")
+			locked, err := eibcutils.LockedOrders(*hd, addresses)
+			if err != nil {
+				spinner.Fail("failed to query the pending orders: ", err)
+				return
+			}
+			spinner.Success(
+				fmt.Sprintf(
+					"%d orders fulfilled and %d transactions paid in the last %d days",
+					len(history.Orders),
+					len(history.Txs),
+					days,
+				),
+			)
+
+			rows := history.Aggregate(groupBy)
+			if len(rows) == 0 {
+				pterm.Info.Println("no fulfilled orders found")
+			} else {
+				printRows(groupBy, rows)
+			}
+			printLocked(locked)
+
+			if csvPath == "" {
+				return
+			}
+			err = writeCsv(csvPath, groupBy, rows)
+			if err != nil {
+				pterm.Error.Println("failed to write csv report: ", err)
+				return
+			}
+			pterm.Success.Println("report written to", csvPath)
+		},
+	}
+
+	cmd.Flags().Int(flagDays, 30, "number of days of history to include")
+	cmd.Flags().String(
+		flagGroupBy,
+		eibcutils.PnLGroupings.Day,
+		"group the report by day, rollapp or asset",
+	)
+	cmd.Flags().String(flagCsv, "", "export the report to the csv file")
+	cmd.Flags().StringSlice(
+		flagAddresses,
+		nil,
+		"additional addresses whose fulfilments are included (e.g. fulfiller accounts)",
+	)
+
+	return cmd
+}
+
+func rowValues(r eibcutils.PnLRow) []string {
+	return []string{
+		r.Key,
+		r.Denom,
+		strconv.Itoa(r.Orders),
+		r.Volume.String(),
+		r.Fees.String(),
+		r.OperatorIncome.String(),
+		r.GasSpent.String(),
+	}
+}
+
+func header(groupBy string) []string {
+	return []string{
+		groupBy,
+		"denom",
+		"orders",
+		"volume",
+		"fees",
+		"operator_income",
+		"gas_spent_" + consts.Denoms.Hub,
+	}
+}
+
+func printRows(groupBy string, rows []eibcutils.PnLRow) {
+	data := pterm.TableData{header(groupBy)}
+	for _, r := range rows {
+		data = append(data, rowValues(r))
+	}
+
+	// nolint: errcheck
+	pterm.DefaultTable.WithHasHeader().WithData(data).Render()
+}
+
+func printLocked(locked []eibcutils.DemandOrder) {
+	if len(locked) == 0 {
+		pterm.Info.Println("no capital locked in orders pending finalization")
+		return
+	}
+
+	type key struct{ rollapp, denom string }
+	amounts := map[key]cosmossdkmath.Int{}
+	counts := map[key]int{}
+	var keys []key
+	for _, o := range locked {
+		k := key{o.RollappID, o.Denom}
+		if _, ok := amounts[k]; !ok {
+			amounts[k] = cosmossdkmath.ZeroInt()
+			keys = append(keys, k)
+		}
+		amounts[k] = amounts[k].Add(o.Price)
+		counts[k]++
+	}
+	slices.SortFunc(keys, func(a, b key) int {
+		if c := strings.Compare(a.rollapp, b.rollapp); c != 0 {
+			return c
+		}
+		return strings.Compare(a.denom, b.denom)
+	})
+
+	data := pterm.TableData{{"rollapp", "denom", "pending orders", "locked"}}
+	for _, k := range keys {
+		data = append(data, []string{
+			k.rollapp,
+			k.denom,
+			strconv.Itoa(counts[k]),
+			amounts[k].String(),
+		})
+	}
+
+	pterm.Info.Println("capital locked pending finalization:")
+	// nolint: errcheck
+	pterm.DefaultTable.WithHasHeader().WithData(data).Render()
+}
+
+func writeCsv(path, groupBy string, rows []eibcutils.PnLRow) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	// nolint: errcheck
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	err = w.Write(header(groupBy))
+	if err != nil {
+		return err
+	}
+	for _, r := range rows {
+		err = w.Write(rowValues(r))
+		if err != nil {
+			return err
+		}
+	}
+	w.Flush()
+
+	return w.Error()
+}
@@ -1,13 +1,44 @@
 package scale
 
 import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"time"
+
+	cosmossdkmath "cosmossdk.io/math"
 	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
 
-	"github.com/dymensionxyz/roller/utils/bash"
+	"github.com/dymensionxyz/roller/cmd/consts"
 	eibcutils "github.com/dymensionxyz/roller/utils/eibc"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+)
+
+const (
+	flagWindow       = "window"
+	flagDrainTimeout = "drain-timeout"
+	flagSweep        = "sweep"
+	flagAuto         = "auto"
+	flagMin          = "min"
+	flagMax          = "max"
+	flagInterval     = "interval"
+	flagCooldown     = "cooldown"
 )
 
+type scaler struct {
+	cfg          eibcutils.Config
+	cfgPath      string
+	hd           consts.HubData
+	whale        string
+	drainTimeout time.Duration
+	sweep        bool
+}
+
 func Cmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "scale [count]",
@@ -16,20 +47,379 @@ func Cmd() *cobra.Command {
 
 fulfiller wallets are created to fulfill orders on behalf of the whale account
 
+Without [count], the fulfillers with their balances and orders in flight are
+shown together with a recommended count, based on the peak order rate of the
+supported RollApps over --window and on max_orders_per_tx.
+
+When scaling down, the eibc client stops using the removed fulfillers, roller
+waits up to --drain-timeout for their orders in flight to be finalized and then
+sends their remaining balance back to the whale account.
+
+With --auto, the number of fulfillers follows the number of unfulfilled orders
+queued on the hub, between --min and --max. The fulfillers are added as soon as
+the queue grows and removed once the queue stayed small for --cooldown.
+
 a good number to start with is 30 (default when initializing the eibc client)
 `,
-		Args: cobra.ExactArgs(1),
+		Args: cobra.MaximumNArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
-			count := args[0]
+			home, err := os.UserHomeDir()
+			if err != nil {
+				pterm.Error.Println("failed to get user home dir", err)
+				return
+			}
+
+			eibcHome := filepath.Join(home, consts.ConfigDirName.Eibc)
+			isEibcClientInitialized, err := filesystem.DirNotEmpty(eibcHome)
+			if err != nil {
+				pterm.Error.Println("failed to check eibc client initialized", err)
+				return
+			}
+			if !isEibcClientInitialized {
+				pterm.Error.Println("eibc client not initialized")
+				return
+			}
+
+			window, _ := cmd.Flags().GetDuration(flagWindow)
+			auto, _ := cmd.Flags().GetBool(flagAuto)
+
+			s := scaler{cfgPath: filepath.Join(eibcHome, "config.yaml")}
+			hd, err := s.cfg.HubDataFromHubRpc(s.cfgPath)
+			if err != nil {
+				pterm.Error.Println("failed to retrieve hub data: ", err)
+				return
+			}
+			s.hd = *hd
+			s.drainTimeout, _ = cmd.Flags().GetDuration(flagDrainTimeout)
+			s.sweep, _ = cmd.Flags().GetBool(flagSweep)
+
+			whale, err := eibcutils.GetKeyConfig().Info(home)
+			if err != nil {
+				pterm.Error.Println("failed to retrieve whale account: ", err)
+				return
+			}
+			s.whale = whale.Address
+
+			if auto {
+				if len(args) > 0 {
+					pterm.Error.Printfln("[count] can't be used with --%s", flagAuto)
+					return
+				}
+				minCount, _ := cmd.Flags().GetInt(flagMin)
+				maxCount, _ := cmd.Flags().GetInt(flagMax)
+				interval, _ := cmd.Flags().GetDuration(flagInterval)
+				cooldown, _ := cmd.Flags().GetDuration(flagCooldown)
+				if minCount < 1 || maxCount < minCount {
+					pterm.Error.Printfln("--%s must be at least 1 and at most --%s", flagMin, flagMax)
+					return
+				}
+				if interval <= 0 {
+					pterm.Error.Printfln("--%s must be positive", flagInterval)
+					return
+				}
+
+				ctx, stop := signal.NotifyContext(
+					cmd.Context(),
+					os.Interrupt,
+					syscall.SIGTERM,
+				)
+				defer stop()
+
+				err = s.autoScale(ctx, minCount, maxCount, interval, cooldown)
+				if err != nil {
+					pterm.Error.Println("auto scaling failed: ", err)
+				}
+				return
+			}
 
-			c := eibcutils.GetScaleCmd(count)
+			loads, err := eibcutils.QueryFulfillerLoad(s.cfg, s.hd)
+			if err != nil {
+				pterm.Error.Println("failed to query the fulfillers: ", err)
+				return
+			}
+			printFulfillers(loads)
 
-			_, err := bash.ExecCommandWithStdout(c)
+			rate, err := eibcutils.QueryOrderRate(s.cfg, s.hd, window)
+			if err != nil {
+				pterm.Error.Println("failed to query the order rate: ", err)
+				return
+			}
+			recommended := eibcutils.RecommendFulfillers(rate, s.cfg.Fulfillers.MaxOrdersPerTx)
+			pterm.Info.Printfln(
+				"%d orders over the last %s, %.2f per minute on average, %d at peak",
+				rate.Orders,
+				window,
+				rate.PerMinute,
+				rate.PeakPerMinute,
+			)
+			pterm.Info.Printfln(
+				"current fulfillers: %d, recommended: %d (max %d orders per tx)",
+				s.cfg.Fulfillers.Scale,
+				recommended,
+				s.cfg.Fulfillers.MaxOrdersPerTx,
+			)
+
+			if len(args) == 0 {
+				return
+			}
+
+			count, err := strconv.Atoi(args[0])
+			if err != nil || count < 1 {
+				pterm.Error.Printfln("invalid count: %s", args[0])
+				return
+			}
+			if count == s.cfg.Fulfillers.Scale {
+				pterm.Info.Printfln("the eibc client already uses %d fulfillers", count)
+				return
+			}
+			if count < recommended {
+				pterm.Warning.Printfln(
+					"%d fulfillers is below the recommended %d for the recent order rate",
+					count,
+					recommended,
+				)
+			}
+
+			if count > s.cfg.Fulfillers.Scale {
+				s.warnScaleUpFunds(count - s.cfg.Fulfillers.Scale)
+			} else {
+				removed := removedFulfillers(loads, count)
+				if len(removed) > 0 {
+					pterm.Info.Println("fulfillers to remove:")
+					printFulfillers(removed)
+				}
+			}
+
+			proceed, _ := pterm.DefaultInteractiveConfirm.WithDefaultText(
+				fmt.Sprintf("scale the fulfillers from %d to %d?", s.cfg.Fulfillers.Scale, count),
+			).Show()
+			if !proceed {
+				return
+			}
+
+			if count > s.cfg.Fulfillers.Scale {
+				err = eibcutils.ScaleFulfillers(count)
+			} else {
+				err = s.scaleDown(loads, count)
+			}
 			if err != nil {
 				pterm.Error.Println("failed to scale the number of fulfillers: ", err)
 				return
 			}
+			pterm.Success.Printfln("the eibc client uses %d fulfillers", count)
 		},
 	}
+
+	cmd.Flags().Duration(flagWindow, time.Hour, "window of the order rate used for the recommendation")
+	cmd.Flags().Duration(
+		flagDrainTimeout,
+		10*time.Minute,
+		"maximal wait for the orders in flight of the removed fulfillers",
+	)
+	cmd.Flags().Bool(flagSweep, true, "send the balance of the removed fulfillers to the whale")
+	cmd.Flags().Bool(flagAuto, false, "scale the fulfillers with the order queue depth")
+	cmd.Flags().Int(flagMin, 1, "minimal number of fulfillers in auto mode")
+	cmd.Flags().Int(flagMax, 50, "maximal number of fulfillers in auto mode")
+	cmd.Flags().Duration(flagInterval, time.Minute, "interval of the queue checks in auto mode")
+	cmd.Flags().Duration(
+		flagCooldown,
+		10*time.Minute,
+		"time the queue has to stay small before scaling down in auto mode",
+	)
+
 	return cmd
 }
+
+// removedFulfillers returns the fulfillers the eibc client stops using when scaled down to
+// count, the loads are sorted by fulfiller index
+func removedFulfillers(loads []eibcutils.FulfillerLoad, count int) []eibcutils.FulfillerLoad {
+	if len(loads) <= count {
+		return nil
+	}
+	return loads[count:]
+}
+
+// scaleDown stops the eibc client from using the removed fulfillers, drains them and sends
+// their balance back to the whale
+func (s scaler) scaleDown(loads []eibcutils.FulfillerLoad, count int) error {
+	removed := removedFulfillers(loads, count)
+
+	err := eibcutils.ScaleFulfillers(count)
+	if err != nil {
+		return err
+	}
+	if len(removed) == 0 {
+		return nil
+	}
+
+	addresses := make([]string, 0, len(removed))
+	for _, f := range removed {
+		addresses = append(addresses, f.Address)
+	}
+	inFlight, err := eibcutils.DrainFulfillers(s.hd, addresses, s.drainTimeout)
+	if err != nil {
+		return err
+	}
+	busy := map[string]bool{}
+	for _, o := range inFlight {
+		busy[o.FulfillerAddress] = true
+	}
+
+	if !s.sweep {
+		return nil
+	}
+	for _, f := range removed {
+		if busy[f.Address] {
+			pterm.Warning.Printfln("%s still has orders in flight, its balance is kept", f.Name)
+			continue
+		}
+		txHash, err := eibcutils.SweepFulfiller(s.cfg, s.hd, f, s.whale)
+		if err != nil {
+			pterm.Warning.Printfln("failed to sweep the balance of %s: %v", f.Name, err)
+			continue
+		}
+		if txHash != "" {
+			pterm.Info.Printfln("balance of %s sent to the whale account", f.Name)
+		}
+	}
+
+	return nil
+}
+
+// warnScaleUpFunds warns when the whale account can't fund the gas of the new fulfillers
+func (s scaler) warnScaleUpFunds(added int) {
+	fee, ok := s.cfg.GasFee()
+	if !ok {
+		return
+	}
+
+	balances, err := eibcutils.QueryBalances(s.hd, s.whale)
+	if err != nil {
+		pterm.Warning.Println("failed to query the whale balance: ", err)
+		return
+	}
+
+	required := fee.Amount.Mul(cosmossdkmath.NewInt(int64(added * eibcutils.LowGasRunway)))
+	available := balances.AmountOf(fee.Denom)
+	if available.LT(required) {
+		pterm.Warning.Printfln(
+			"the whale account has %s%s, %s%s are recommended to fund the gas of "+
+				"%d new fulfillers",
+			available,
+			fee.Denom,
+			required,
+			fee.Denom,
+			added,
+		)
+	}
+}
+
+// autoScale follows the queue depth until the context is cancelled
+func (s scaler) autoScale(
+	ctx context.Context,
+	minCount, maxCount int,
+	interval, cooldown time.Duration,
+) error {
+	current := s.cfg.Fulfillers.Scale
+	var smallSince time.Time
+
+	pterm.Info.Printfln(
+		"auto scaling between %d and %d fulfillers, currently %d",
+		minCount,
+		maxCount,
+		current,
+	)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		// the scale commands rewrite the eibc config, it's reloaded so that the next step
+		// starts from the fulfillers actually in use
+		var cfg eibcutils.Config
+		err := cfg.LoadConfig(s.cfgPath)
+		if err != nil {
+			pterm.Warning.Println("failed to reload the eibc config: ", err)
+		} else {
+			s.cfg = cfg
+			current = s.cfg.Fulfillers.Scale
+		}
+
+		depth, err := eibcutils.QueueDepth(s.cfg, s.hd)
+		if err != nil {
+			pterm.Warning.Println("failed to query the order queue: ", err)
+		} else {
+			target := eibcutils.FulfillersForQueue(
+				depth,
+				s.cfg.Fulfillers.MaxOrdersPerTx,
+				minCount,
+				maxCount,
+			)
+
+			switch {
+			case target > current:
+				pterm.Info.Printfln("%d orders queued, scaling up to %d fulfillers", depth, target)
+				err = eibcutils.ScaleFulfillers(target)
+				if err != nil {
+					return err
+				}
+				current = target
+				smallSince = time.Time{}
+			case target < current && smallSince.IsZero():
+				smallSince = time.Now()
+			case target < current && time.Since(smallSince) >= cooldown:
+				pterm.Info.Printfln("%d orders queued, scaling down to %d fulfillers", depth, target)
+				loads, err := eibcutils.QueryFulfillerLoad(s.cfg, s.hd)
+				if err != nil {
+					return err
+				}
+				err = s.scaleDown(loads, target)
+				if err != nil {
+					return err
+				}
+				current = target
+				smallSince = time.Time{}
+			case target == current:
+				smallSince = time.Time{}
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func printFulfillers(loads []eibcutils.FulfillerLoad) {
+	if len(loads) == 0 {
+		pterm.Info.Println("no fulfillers")
+		return
+	}
+
+	data := pterm.TableData{{"Name", "Address", "Balances", "Gas Runway", "In Flight"}}
+	var lowGas int
+	for _, l := range loads {
+		runway := "unknown"
+		if l.GasRunway >= 0 {
+			runway = fmt.Sprintf("%d txs", l.GasRunway)
+		}
+		if l.LowGas() {
+			lowGas++
+			runway = pterm.FgRed.Sprint(runway)
+		}
+		data = append(data, []string{
+			l.Name,
+			l.Address,
+			l.Balances.String(),
+			runway,
+			strconv.Itoa(len(l.InFlight)),
+		})
+	}
+	_ = pterm.DefaultTable.WithHasHeader().WithData(data).Render()
+
+	if lowGas > 0 {
+		pterm.Warning.Printfln("%d fulfillers are low on gas", lowGas)
+	}
+}
@@ -0,0 +1,200 @@
+package simulate
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	cosmossdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	eibcutils "github.com/dymensionxyz/roller/utils/eibc"
+)
+
+const (
+	flagConfig         = "config"
+	flagFunds          = "funds"
+	flagFinalization   = "finalization"
+	flagFulfillers     = "fulfillers"
+	flagMaxOrdersPerTx = "max-orders-per-tx"
+	flagTxInterval     = "tx-interval"
+	flagFallbackLevel  = "fallback-level"
+	flagTakenOnly      = "taken-only"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "simulate [fixture]",
+		Short: "Simulate the fulfilment of a stream of demand orders with the eibc client policies",
+		Long: `Simulate the fulfilment of a stream of demand orders with the eibc client policies.
+
+The orders of the JSON fixture are replayed offline against the policies of the
+eibc client config: the supported RollApps, the fulfill criteria
+(min fee percentage and max order size), max_orders_per_tx, the number of
+fulfillers and the validation fallback level and wait time. The report shows
+which orders would be taken, the expected profit and the capital required.
+
+The fixture is either the output of 'dymd q eibc list-demand-orders -o json' or
+a list of orders, optionally under "orders":
+
+  [
+    {
+      "id": "order-1",
+      "rollapp_id": "rollapp_1234-1",
+      "denom": "ibc/...",
+      "price": "1000000",
+      "fee": "5000",
+      "created_at": "2024-01-01T00:00:00Z",
+      "validated_after": {"p2p": "10s", "settlement": "20m"}
+    }
+  ]
+
+An order without validation delays is validated when it's created. An order is
+validated when it reaches the p2p level or the fallback level within the
+validation wait time.
+`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			configPath, _ := cmd.Flags().GetString(flagConfig)
+			fundsStr, _ := cmd.Flags().GetString(flagFunds)
+			takenOnly, _ := cmd.Flags().GetBool(flagTakenOnly)
+
+			if configPath == "" {
+				home, err := os.UserHomeDir()
+				if err != nil {
+					pterm.Error.Println("failed to get user home dir", err)
+					return
+				}
+				configPath = filepath.Join(home, consts.ConfigDirName.Eibc, "config.yaml")
+			}
+
+			var cfg eibcutils.Config
+			err := cfg.LoadConfig(configPath)
+			if err != nil {
+				pterm.Error.Println("failed to load eibc config: ", err)
+				return
+			}
+
+			sc, err := eibcutils.SimConfigFromEibcConfig(cfg)
+			if err != nil {
+				pterm.Error.Println(err)
+				return
+			}
+			if cmd.Flags().Changed(flagFulfillers) {
+				sc.Fulfillers, _ = cmd.Flags().GetInt(flagFulfillers)
+			}
+			if cmd.Flags().Changed(flagMaxOrdersPerTx) {
+				sc.MaxOrdersPerTx, _ = cmd.Flags().GetInt(flagMaxOrdersPerTx)
+			}
+			if cmd.Flags().Changed(flagTxInterval) {
+				sc.TxInterval, _ = cmd.Flags().GetDuration(flagTxInterval)
+			}
+			if cmd.Flags().Changed(flagFallbackLevel) {
+				sc.FallbackLevel, _ = cmd.Flags().GetString(flagFallbackLevel)
+			}
+			sc.Finalization, _ = cmd.Flags().GetDuration(flagFinalization)
+			if fundsStr != "" {
+				sc.Funds, err = cosmossdktypes.ParseCoinsNormalized(fundsStr)
+				if err != nil {
+					pterm.Error.Printfln("invalid --%s: %v", flagFunds, err)
+					return
+				}
+			}
+
+			orders, err := eibcutils.LoadSimOrders(args[0])
+			if err != nil {
+				pterm.Error.Println("failed to load the order fixture: ", err)
+				return
+			}
+			if len(orders) == 0 {
+				pterm.Info.Println("the fixture has no orders")
+				return
+			}
+
+			report := eibcutils.Simulate(orders, sc)
+
+			pterm.Info.Printfln(
+				"simulating %d orders with %d fulfillers, up to %d orders per tx",
+				len(orders),
+				max(sc.Fulfillers, 1),
+				max(sc.MaxOrdersPerTx, 1),
+			)
+			printResults(report.Results, takenOnly)
+			printSummary(report)
+		},
+	}
+
+	cmd.Flags().String(flagConfig, "", "eibc client config, defaults to the config of the eibc home")
+	cmd.Flags().String(flagFunds, "", "funds available to fulfill orders, unlimited when empty")
+	cmd.Flags().Duration(
+		flagFinalization,
+		0,
+		"delay after which the capital of a fulfilled order is returned, never when 0",
+	)
+	cmd.Flags().Int(flagFulfillers, 0, "number of fulfillers instead of the configured one")
+	cmd.Flags().Int(flagMaxOrdersPerTx, 0, "max orders per tx instead of the configured one")
+	cmd.Flags().Duration(flagTxInterval, 10*time.Second, "time a fulfiller takes to send a tx")
+	cmd.Flags().String(
+		flagFallbackLevel,
+		"",
+		"validation fallback level instead of the configured one (p2p, settlement)",
+	)
+	cmd.Flags().Bool(flagTakenOnly, false, "only list the orders that would be taken")
+
+	return cmd
+}
+
+func printResults(results []eibcutils.SimResult, takenOnly bool) {
+	data := pterm.TableData{
+		{"ID", "RollApp", "Denom", "Price", "Fee %", "Taken", "Fulfilled After", "Reason"},
+	}
+	for _, r := range results {
+		if takenOnly && !r.Taken {
+			continue
+		}
+
+		taken := pterm.FgRed.Sprint("no")
+		after := ""
+		if r.Taken {
+			taken = pterm.FgGreen.Sprint("yes")
+			if !r.Order.CreatedAt.IsZero() {
+				after = r.FulfilledAt.Sub(r.Order.CreatedAt).String()
+			}
+		}
+		data = append(data, []string{
+			r.Order.ID,
+			r.Order.RollappID,
+			r.Order.Denom,
+			r.Order.Price.String(),
+			strconv.FormatFloat(r.Order.FeePercentage(), 'f', 3, 64),
+			taken,
+			after,
+			r.Reason,
+		})
+	}
+	_ = pterm.DefaultTable.WithHasHeader().WithData(data).Render()
+}
+
+func printSummary(report eibcutils.SimReport) {
+	data := pterm.TableData{
+		{"Denom", "Taken", "Skipped", "Volume", "Fees", "Expected Profit", "Capital Required"},
+	}
+	for _, d := range report.Denoms {
+		data = append(data, []string{
+			d.Denom,
+			strconv.Itoa(d.Taken),
+			strconv.Itoa(d.Skipped),
+			d.Volume.String(),
+			d.Fees.String(),
+			d.Profit.String(),
+			d.Capital.String(),
+		})
+	}
+
+	pterm.Println()
+	_ = pterm.DefaultTable.WithHasHeader().WithData(data).Render()
+	pterm.Info.Printfln("%d fulfilment transactions", report.Txs)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
@@ -14,10 +15,28 @@ import (
 	"github.com/dymensionxyz/roller/utils/filesystem"
 )
 
+const (
+	flagOrderSource          = "order-source"
+	flagIndexerURL           = "indexer-url"
+	flagIndexerCheckInterval = "indexer-check-interval"
+
+	defaultIndexerCheckInterval = 5 * time.Minute
+)
+
 func Cmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "start",
 		Short: "Start the eibc client",
+		Long: `Start the eibc client.
+
+With the auto order source, the indexer is checked against the hub on start and
+every '--indexer-check-interval'. The eibc client polls the orders from the
+indexer while it's healthy and consistent with the hub, and is restarted to
+query the hub when it isn't, and back once the indexer recovers.
+
+The indexer is the 'order_polling.indexer_url' of the eibc config, set with
+'--indexer-url' or 'roller eibc init --indexer-url'.
+`,
 		Run: func(cmd *cobra.Command, args []string) {
 			pterm.Info.Println("starting eibc client")
 			home, _ := os.UserHomeDir()
@@ -38,34 +57,160 @@ func Cmd() *cobra.Command {
 				return
 			}
 
-			done := make(chan error, 1)
-			c := eibcutils.GetStartCmd()
+			orderSource, _ := cmd.Flags().GetString(flagOrderSource)
+			indexerURL, _ := cmd.Flags().GetString(flagIndexerURL)
+			checkInterval, _ := cmd.Flags().GetDuration(flagIndexerCheckInterval)
+			if orderSource != eibcutils.OrderSources.Auto {
+				checkInterval = 0
+			}
+
+			useIndexer, err := applyOrderSource(eibcHome, orderSource, indexerURL)
+			if err != nil {
+				pterm.Error.Println("failed to set the order source: ", err)
+				return
+			}
 
 			ctx, cancel := context.WithCancel(cmd.Context())
 			defer cancel()
 
-			go func() {
-				err := bash.ExecCmdFollow(
-					done,
-					ctx,
-					c,
-					nil, // No need for printOutput since we configured output above
-				)
-
-				done <- err
-			}()
-
-			select {
-			case err := <-done:
+			// the eibc client reads the order polling config on start, so it's restarted
+			// when the indexer health changes
+			for {
+				switched, err := runClient(ctx, eibcHome, useIndexer, checkInterval)
 				if err != nil {
 					pterm.Error.Println("eibc client process returned an error: ", err)
 					return
 				}
-			case <-ctx.Done():
-				pterm.Error.Println("context cancelled, terminating command")
-				return
+				if !switched {
+					return
+				}
+				useIndexer = !useIndexer
+				pterm.Info.Println("restarting the eibc client with the new order source")
 			}
 		},
 	}
+
+	cmd.Flags().String(
+		flagOrderSource,
+		eibcutils.OrderSources.Auto,
+		"source of the orders (auto, indexer, hub), auto falls back to the hub when the "+
+			"indexer is down or inconsistent",
+	)
+	cmd.Flags().String(
+		flagIndexerURL,
+		"",
+		"eibc order indexer to poll the orders from, saved in the eibc config",
+	)
+	cmd.Flags().Duration(
+		flagIndexerCheckInterval,
+		defaultIndexerCheckInterval,
+		"interval between the indexer checks of the auto order source, 0 only checks on start",
+	)
+
 	return cmd
 }
+
+// runClient runs the eibc client until it exits or the context is cancelled. With a check
+// interval, the indexer is checked periodically and the client is stopped when the order
+// source switches, switched is then true
+func runClient(
+	ctx context.Context,
+	eibcHome string,
+	useIndexer bool,
+	checkInterval time.Duration,
+) (bool, error) {
+	runCtx, stop := context.WithCancel(ctx)
+	defer stop()
+
+	// the signals received while following the client are reported on their own channel,
+	// so that they never block the report of the client exit
+	done := make(chan error, 1)
+	signals := make(chan error, 1)
+	go func() {
+		done <- bash.ExecCmdFollow(signals, runCtx, eibcutils.GetStartCmd(), nil)
+	}()
+
+	var tick <-chan time.Time
+	if checkInterval > 0 {
+		ticker := time.NewTicker(checkInterval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case err := <-done:
+			return false, err
+		case err := <-signals:
+			<-done
+			return false, err
+		case <-ctx.Done():
+			pterm.Error.Println("context cancelled, terminating command")
+			return false, nil
+		case <-tick:
+			report, usable, err := updateOrderSource(eibcHome, eibcutils.OrderSources.Auto, "")
+			if err != nil {
+				pterm.Warning.Println("failed to check the indexer: ", err)
+				continue
+			}
+			if usable == useIndexer {
+				continue
+			}
+
+			printOrderSource(report, usable)
+			stop()
+			<-done
+			return true, nil
+		}
+	}
+}
+
+// applyOrderSource updates the order polling config of the eibc client with the source, it
+// returns true when the orders are polled from the indexer
+func applyOrderSource(eibcHome, source, indexerURL string) (bool, error) {
+	report, useIndexer, err := updateOrderSource(eibcHome, source, indexerURL)
+	if err != nil {
+		return false, err
+	}
+
+	printOrderSource(report, useIndexer)
+	return useIndexer, nil
+}
+
+// updateOrderSource checks the indexer and enables the order polling of the eibc config
+// when the orders are taken from it, indexerURL replaces the configured indexer when set
+func updateOrderSource(
+	eibcHome, source, indexerURL string,
+) (*eibcutils.IndexerReport, bool, error) {
+	eibcConfigPath := filepath.Join(eibcHome, "config.yaml")
+	var cfg eibcutils.Config
+	hd, err := cfg.HubDataFromHubRpc(eibcConfigPath)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return eibcutils.ApplyOrderSource(eibcConfigPath, source, indexerURL, *hd)
+}
+
+func printOrderSource(report *eibcutils.IndexerReport, useIndexer bool) {
+	switch {
+	case useIndexer:
+		pterm.Info.Println("polling the orders from the indexer")
+	case report == nil:
+		pterm.Info.Println("querying the orders from the hub")
+	case !report.Healthy():
+		pterm.Warning.Printfln(
+			"indexer %s is down (%v), querying the orders from the hub",
+			report.URL,
+			report.Err,
+		)
+	default:
+		pterm.Warning.Printfln(
+			"indexer %s is inconsistent with the hub (%d of %d sampled orders differ), "+
+				"querying the orders from the hub",
+			report.URL,
+			report.Inconsistencies(),
+			len(report.Checks),
+		)
+	}
+}
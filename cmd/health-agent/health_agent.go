@@ -0,0 +1,17 @@
+package health_agent
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/dymensionxyz/roller/cmd/health-agent/start"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "health-agent",
+		Short: "Commands for running the RollApp health agent.",
+	}
+	cmd.AddCommand(start.Cmd())
+
+	return cmd
+}
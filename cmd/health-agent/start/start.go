@@ -0,0 +1,88 @@
+package start
+
+import (
+	"os"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/utils/healthagent"
+	"github.com/dymensionxyz/roller/utils/logging"
+	"github.com/dymensionxyz/roller/utils/metricstore"
+	"github.com/dymensionxyz/roller/utils/roller"
+)
+
+const historyIntervalFlag = "history-interval"
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "start",
+		Short: "Runs the health agent and its status API.",
+		Long: `Runs the health agent and its status API.
+
+The health agent periodically checks the DA light client and the DA submissions of the RollApp.
+When 'HealthAgent.enabled' is set in roller.toml, the agent hotswaps the DA node when it
+detects problems, otherwise the checks are only reported.
+
+For sequencers, the agent also monitors the hub's view of the RollApp (state updates, current
+proposer and liveness slashing). The results are available on the /status endpoint and as
+prometheus metrics on the /metrics endpoint for alert rules.
+
+The key RollApp metrics, balances and heights are recorded on disk, use
+'roller observability history' to display them.
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			home := cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String()
+			rollerData, err := roller.LoadConfig(home)
+			if err != nil {
+				pterm.Error.Println("failed to load roller config file", err)
+				return
+			}
+
+			rollerLogger := logging.GetRollerLogger(rollerData.Home)
+			agent := healthagent.NewAgent(home, rollerLogger)
+			go agent.Run()
+
+			historyInterval, _ := cmd.Flags().GetDuration(historyIntervalFlag)
+			if historyInterval > 0 {
+				store, err := metricstore.New(
+					metricstore.Dir(rollerData.Home),
+					metricstore.DefaultCapacity,
+				)
+				if err != nil {
+					pterm.Error.Println("failed to initialize the metric history store: ", err)
+					return
+				}
+				recorder := metricstore.NewRecorder(
+					rollerData,
+					store,
+					historyInterval,
+					rollerLogger,
+				)
+				go recorder.Run()
+			}
+
+			addr := healthagent.ListenAddress(rollerData.HealthAgent)
+			pterm.Info.Printf(
+				"health agent status API listening on %s\n",
+				pterm.DefaultBasicText.WithStyle(pterm.FgYellow.ToStyle()).
+					Sprintf("http://%s/status", addr),
+			)
+
+			err = agent.Serve(addr)
+			if err != nil {
+				pterm.Error.Println("health agent status API failed: ", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	cmd.Flags().Duration(
+		historyIntervalFlag,
+		metricstore.DefaultInterval,
+		"interval of the metric history samples, 0 disables the history recording",
+	)
+
+	return cmd
+}
@@ -1,24 +1,53 @@
 package export
 
 import (
-	_ "embed"
+	"embed"
+	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
 
 	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
 	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/utils/roller"
 )
 
-//go:embed templates/grafana/dashboard.json
-var grafanaDashboardTemplate []byte
+//go:embed templates/grafana/*.json
+var grafanaDashboardTemplates embed.FS
+
+const (
+	flagComponent  = "component"
+	flagDatasource = "datasource"
+)
+
+// dashboardFiles maps the component name to the embedded dashboard template and
+// the file name it is exported to
+var dashboardFiles = map[string]struct {
+	Template string
+	Output   string
+}{
+	"rollapp": {Template: "dashboard.json", Output: "dashboard.json"},
+	"relayer": {Template: "relayer.json", Output: "relayer-dashboard.json"},
+	"da":      {Template: "da.json", Output: "da-dashboard.json"},
+	"eibc":    {Template: "eibc.json", Output: "eibc-dashboard.json"},
+}
+
+var supportedComponents = []string{"rollapp", "relayer", "da", "eibc"}
 
 func Cmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "export",
-		Short: "Exports a baseline grafana dashboard for the RollApp's key metrics",
+		Short: "Exports baseline grafana dashboards for the RollApp's key metrics",
+		Long: fmt.Sprintf(`Exports baseline grafana dashboards for the RollApp's key metrics
+
+Available components: %s
+`, strings.Join(supportedComponents, ", ")),
+		Example: "roller observability export --component rollapp,relayer --datasource PBFA97CFB590B2093",
 		Run: func(cmd *cobra.Command, args []string) {
 			err := initconfig.AddFlags(cmd)
 			if err != nil {
@@ -26,17 +55,123 @@ func Cmd() *cobra.Command {
 				return
 			}
 			home := cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String()
-			gdpath := filepath.Join(home, consts.ConfigDirName.Rollapp, "dashboard.json")
 
-			err = os.WriteFile(gdpath, grafanaDashboardTemplate, 0o644)
-			if err != nil {
-				pterm.Error.Printfln("failed to export template")
-				return
+			components, _ := cmd.Flags().GetStringSlice(flagComponent)
+			datasource, _ := cmd.Flags().GetString(flagDatasource)
+
+			if slices.Contains(components, "all") {
+				components = supportedComponents
 			}
 
-			pterm.Info.Printf("example grafana dashboard exported to %s\n", gdpath)
+			for _, c := range components {
+				if _, ok := dashboardFiles[c]; !ok {
+					pterm.Error.Printfln(
+						"unsupported component %s, available components: %s",
+						c,
+						strings.Join(supportedComponents, ", "),
+					)
+					return
+				}
+			}
+
+			// the rollapp ID is optional, the dashboards can be exported
+			// on machines that don't run a rollapp (e.g. eibc or relayer only)
+			var raID string
+			rollerData, err := roller.LoadConfig(home)
+			if err == nil {
+				raID = rollerData.RollappID
+			}
+
+			for _, c := range components {
+				df := dashboardFiles[c]
+				tmpl, err := grafanaDashboardTemplates.ReadFile(
+					filepath.Join("templates", "grafana", df.Template),
+				)
+				if err != nil {
+					pterm.Error.Printfln("failed to read %s template: %v", c, err)
+					return
+				}
+
+				dashboard, err := parameterizeDashboard(tmpl, raID, datasource)
+				if err != nil {
+					pterm.Error.Printfln("failed to populate %s template: %v", c, err)
+					return
+				}
+
+				gdpath := filepath.Join(home, consts.ConfigDirName.Rollapp, df.Output)
+				err = os.WriteFile(gdpath, dashboard, 0o644)
+				if err != nil {
+					pterm.Error.Printfln("failed to export template")
+					return
+				}
+
+				pterm.Info.Printf("example %s grafana dashboard exported to %s\n", c, gdpath)
+			}
 		},
 	}
 
+	cmd.Flags().StringSlice(
+		flagComponent,
+		[]string{"rollapp"},
+		fmt.Sprintf(
+			"components to export the dashboards for (%s or all)",
+			strings.Join(supportedComponents, ", "),
+		),
+	)
+	cmd.Flags().String(
+		flagDatasource,
+		"",
+		"uid of the prometheus datasource to preselect in the dashboards",
+	)
+
 	return cmd
 }
+
+// parameterizeDashboard sets the default values of the 'rollapp_id' and 'data_source'
+// dashboard variables, empty values leave the template defaults untouched
+func parameterizeDashboard(tmpl []byte, raID, datasource string) ([]byte, error) {
+	if raID == "" && datasource == "" {
+		return tmpl, nil
+	}
+
+	var dashboard map[string]any
+	err := json.Unmarshal(tmpl, &dashboard)
+	if err != nil {
+		return nil, err
+	}
+
+	templating, ok := dashboard["templating"].(map[string]any)
+	if !ok {
+		return tmpl, nil
+	}
+	variables, ok := templating["list"].([]any)
+	if !ok {
+		return tmpl, nil
+	}
+
+	for _, v := range variables {
+		variable, ok := v.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		switch variable["name"] {
+		case "rollapp_id":
+			if raID == "" {
+				continue
+			}
+			variable["query"] = raID
+			variable["current"] = map[string]any{"text": raID, "value": raID}
+			variable["options"] = []any{
+				map[string]any{"selected": true, "text": raID, "value": raID},
+			}
+		case "data_source":
+			if datasource == "" {
+				continue
+			}
+			variable["current"] = map[string]any{"text": datasource, "value": datasource}
+		}
+	}
+
+	return json.MarshalIndent(dashboard, "", "  ")
+}
@@ -0,0 +1,178 @@
+package history
+
+import (
+	"encoding/csv"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/utils/metricstore"
+)
+
+const (
+	flagMetric = "metric"
+	flagSince  = "since"
+	flagOutput = "output"
+	flagWidth  = "width"
+)
+
+var sparkTicks = []rune("▁▂▃▄▅▆▇█")
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "Show the recorded history of a RollApp metric",
+		Long: `Show the recorded history of a RollApp metric.
+
+The history is recorded by the health agent, run without --metric to list the recorded metrics.
+`,
+		Example: "roller observability history --metric rollapp_hub_height --since 24h",
+		Run: func(cmd *cobra.Command, args []string) {
+			home := cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String()
+			metric, _ := cmd.Flags().GetString(flagMetric)
+			since, _ := cmd.Flags().GetDuration(flagSince)
+			output, _ := cmd.Flags().GetString(flagOutput)
+			width, _ := cmd.Flags().GetInt(flagWidth)
+
+			store, err := metricstore.New(metricstore.Dir(home), metricstore.DefaultCapacity)
+			if err != nil {
+				pterm.Error.Println("failed to open the metric history store: ", err)
+				return
+			}
+
+			if metric == "" {
+				metrics, err := store.Metrics()
+				if err != nil {
+					pterm.Error.Println("failed to list recorded metrics: ", err)
+					return
+				}
+				if len(metrics) == 0 {
+					pterm.Info.Printf(
+						"no metrics recorded yet, run %s to start recording\n",
+						pterm.DefaultBasicText.WithStyle(pterm.FgYellow.ToStyle()).
+							Sprintf("roller health-agent start"),
+					)
+					return
+				}
+				pterm.Info.Println("recorded metrics:")
+				for _, m := range metrics {
+					fmt.Println(m)
+				}
+				return
+			}
+
+			err = metricstore.ValidateMetricName(metric)
+			if err != nil {
+				pterm.Error.Println(err)
+				return
+			}
+
+			samples, err := store.Read(metric, time.Now().Add(-since))
+			if err != nil {
+				pterm.Error.Println(err)
+				return
+			}
+
+			switch output {
+			case "csv":
+				w := csv.NewWriter(os.Stdout)
+				// nolint: errcheck
+				w.Write([]string{"time", metric})
+				for _, s := range samples {
+					// nolint: errcheck
+					w.Write([]string{
+						s.Time.UTC().Format(time.RFC3339),
+						strconv.FormatFloat(s.Value, 'f', -1, 64),
+					})
+				}
+				w.Flush()
+			case "sparkline":
+				if len(samples) == 0 {
+					pterm.Info.Printf("no samples recorded for %s in the last %s\n", metric, since)
+					return
+				}
+				printSparkline(metric, samples, width)
+			default:
+				pterm.Error.Printf("unsupported output %s, use sparkline or csv\n", output)
+				return
+			}
+		},
+	}
+
+	cmd.Flags().String(flagMetric, "", "name of the metric to display")
+	cmd.Flags().Duration(flagSince, 24*time.Hour, "how far back to display the metric")
+	cmd.Flags().String(flagOutput, "sparkline", "output format (sparkline, csv)")
+	cmd.Flags().Int(flagWidth, 60, "width of the sparkline")
+
+	return cmd
+}
+
+func printSparkline(metric string, samples []metricstore.Sample, width int) {
+	values := downsample(samples, width)
+
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, v := range values {
+		lo = math.Min(lo, v)
+		hi = math.Max(hi, v)
+	}
+
+	var sb strings.Builder
+	for _, v := range values {
+		i := 0
+		if hi > lo {
+			i = int((v - lo) / (hi - lo) * float64(len(sparkTicks)-1))
+		}
+		sb.WriteRune(sparkTicks[i])
+	}
+
+	first, last := samples[0], samples[len(samples)-1]
+	fmt.Printf(
+		"%s (%s - %s)\n",
+		pterm.DefaultBasicText.WithStyle(pterm.FgYellow.ToStyle()).Sprint(metric),
+		first.Time.Local().Format(time.DateTime),
+		last.Time.Local().Format(time.DateTime),
+	)
+	fmt.Println(sb.String())
+	fmt.Printf(
+		"min: %s, max: %s, last: %s, samples: %d\n",
+		formatValue(lo),
+		formatValue(hi),
+		formatValue(last.Value),
+		len(samples),
+	)
+}
+
+// downsample averages the samples into at most width buckets
+func downsample(samples []metricstore.Sample, width int) []float64 {
+	if width <= 0 || len(samples) <= width {
+		values := make([]float64, len(samples))
+		for i, s := range samples {
+			values[i] = s.Value
+		}
+		return values
+	}
+
+	values := make([]float64, width)
+	for b := 0; b < width; b++ {
+		start := b * len(samples) / width
+		end := (b + 1) * len(samples) / width
+		var sum float64
+		for _, s := range samples[start:end] {
+			sum += s.Value
+		}
+		values[b] = sum / float64(end-start)
+	}
+
+	return values
+}
+
+func formatValue(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
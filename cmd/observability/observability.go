@@ -6,6 +6,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/dymensionxyz/roller/cmd/observability/export"
+	"github.com/dymensionxyz/roller/cmd/observability/history"
 	"github.com/dymensionxyz/roller/cmd/observability/query"
 )
 
@@ -17,6 +18,7 @@ func Cmd() *cobra.Command {
 
 	cmd.AddCommand(export.Cmd())
 	cmd.AddCommand(query.Cmd())
+	cmd.AddCommand(history.Cmd())
 
 	return cmd
 }
@@ -0,0 +1,251 @@
+package clearpackets
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"slices"
+	"time"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/relayer"
+	"github.com/dymensionxyz/roller/relayer/native"
+	"github.com/dymensionxyz/roller/utils/bash"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	relayerutils "github.com/dymensionxyz/roller/utils/relayer"
+)
+
+const (
+	flagPath      = "path"
+	flagSequences = "sequences"
+	flagKind      = "kind"
+	flagYes       = "yes"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "clear",
+		Short: "List and relay the pending packets and acknowledgements of a relayer path",
+		Long: `List and relay the pending packets and acknowledgements of a relayer path.
+
+Without --sequences, rly relays all the pending packets or acknowledgements of
+the channel. With --sequences, only the selected sequences are relayed, their
+messages are built and broadcast by the native relaying engine.
+`,
+		Example: "roller relayer clear --sequences 1,2,5 --kind packet",
+		Run: func(cmd *cobra.Command, args []string) {
+			home, err := filesystem.ExpandHomePath(
+				cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String(),
+			)
+			if err != nil {
+				pterm.Error.Println("failed to expand home directory")
+				return
+			}
+
+			kind, _ := cmd.Flags().GetString(flagKind)
+			if kind != "all" && kind != relayer.PacketKinds.Packet &&
+				kind != relayer.PacketKinds.Ack {
+				pterm.Error.Printfln(
+					"unsupported kind '%s', supported kinds: %s, %s, all",
+					kind,
+					relayer.PacketKinds.Packet,
+					relayer.PacketKinds.Ack,
+				)
+				return
+			}
+			sequences, _ := cmd.Flags().GetUintSlice(flagSequences)
+			skipConfirm, _ := cmd.Flags().GetBool(flagYes)
+
+			var rlyCfg relayer.Config
+			err = rlyCfg.Load(relayer.GetConfigFilePath(relayer.GetHomeDir(home)))
+			if err != nil {
+				pterm.Error.Println("failed to load relayer config: ", err)
+				return
+			}
+
+			pathName, _ := cmd.Flags().GetString(flagPath)
+			pathName, err = relayerutils.SelectPath(rlyCfg.PathNames(), pathName)
+			if err != nil {
+				pterm.Error.Println(err)
+				return
+			}
+
+			hd := rlyCfg.HubDataForPath(pathName)
+			raData := rlyCfg.RaDataForPath(pathName)
+			rly := relayer.NewRelayer(home, *raData, *hd)
+			rly.SetPath(pathName)
+
+			err = rly.LoadActiveChannel(*raData, *hd)
+			if err != nil || !rly.ChannelReady() {
+				pterm.Error.Println("no active channel found: ", err)
+				return
+			}
+
+			pending, err := rly.PendingPackets()
+			if err != nil {
+				pterm.Error.Println("failed to query pending packets: ", err)
+				return
+			}
+
+			selected := filterPackets(pending, kind, sequences)
+			for _, seq := range sequences {
+				found := slices.ContainsFunc(selected, func(pp relayer.PendingPacket) bool {
+					return uint(pp.Sequence) == seq
+				})
+				if !found {
+					pterm.Warning.Printfln("sequence %d is not pending", seq)
+				}
+			}
+			if len(selected) == 0 {
+				pterm.Success.Println("💈 nothing to clear, no matching packets are pending")
+				return
+			}
+			printPackets(selected)
+
+			if !skipConfirm {
+				proceed, _ := pterm.DefaultInteractiveConfirm.WithDefaultValue(true).
+					WithDefaultText(fmt.Sprintf("relay %d pending packets?", len(selected))).
+					Show()
+				if !proceed {
+					return
+				}
+			}
+
+			if len(sequences) > 0 {
+				err := relaySelected(rly, &rlyCfg, selected)
+				if err != nil {
+					pterm.Error.Println("failed to relay the selected sequences: ", err)
+				}
+			}
+			if len(sequences) == 0 && containsKind(selected, relayer.PacketKinds.Packet) {
+				err := relay(rly.GetRelayPacketsCmd(), "packets")
+				if err != nil {
+					pterm.Error.Println("failed to relay packets: ", err)
+				}
+			}
+			if len(sequences) == 0 && containsKind(selected, relayer.PacketKinds.Ack) {
+				err := relay(rly.GetRelayAcksCmd(), "acknowledgements")
+				if err != nil {
+					pterm.Error.Println("failed to relay acknowledgements: ", err)
+				}
+			}
+
+			remaining, err := rly.PendingPackets()
+			if err != nil {
+				pterm.Error.Println("failed to query pending packets: ", err)
+				return
+			}
+			printResults(selected, remaining)
+		},
+	}
+
+	cmd.Flags().String(flagPath, "", "name of the relayer path to clear")
+	cmd.Flags().UintSlice(flagSequences, []uint{}, "sequences to clear, all when empty")
+	cmd.Flags().String(flagKind, "all", "what to clear (packet, ack or all)")
+	cmd.Flags().BoolP(flagYes, "y", false, "relay without asking for confirmation")
+
+	return cmd
+}
+
+// relaySelected relays the selected packets and acknowledgements only
+func relaySelected(
+	rly *relayer.Relayer,
+	rlyCfg *relayer.Config,
+	selected []relayer.PendingPacket,
+) error {
+	engine, err := native.NewEngine(rly, rlyCfg)
+	if err != nil {
+		return err
+	}
+
+	spinner, _ := pterm.DefaultSpinner.Start(
+		fmt.Sprintf("relaying %d selected sequences", len(selected)),
+	)
+	err = engine.RelaySelected(context.Background(), selected)
+	if err != nil {
+		spinner.Fail("failed to relay the selected sequences")
+		return err
+	}
+	spinner.Success("selected sequences relayed")
+	return nil
+}
+
+func relay(c *exec.Cmd, what string) error {
+	spinner, _ := pterm.DefaultSpinner.Start(fmt.Sprintf("relaying %s", what))
+	err := bash.ExecCmd(c)
+	if err != nil {
+		spinner.Fail(fmt.Sprintf("failed to relay %s", what))
+		return err
+	}
+	spinner.Success(fmt.Sprintf("%s relayed", what))
+	return nil
+}
+
+// filterPackets returns the pending packets of the kind, limited to the sequences
+// when provided
+func filterPackets(
+	pending []relayer.PendingPacket,
+	kind string,
+	sequences []uint,
+) []relayer.PendingPacket {
+	var filtered []relayer.PendingPacket
+	for _, pp := range pending {
+		if kind != "all" && pp.Kind != kind {
+			continue
+		}
+		if len(sequences) > 0 && !slices.Contains(sequences, uint(pp.Sequence)) {
+			continue
+		}
+		filtered = append(filtered, pp)
+	}
+
+	return filtered
+}
+
+func containsKind(pending []relayer.PendingPacket, kind string) bool {
+	return slices.ContainsFunc(pending, func(pp relayer.PendingPacket) bool {
+		return pp.Kind == kind
+	})
+}
+
+func printPackets(pending []relayer.PendingPacket) {
+	data := pterm.TableData{{"Sequence", "Kind", "Chain", "Height", "Age", "Amount"}}
+	for _, pp := range pending {
+		age, amount := "unknown", "unknown"
+		if !pp.Time.IsZero() {
+			age = time.Since(pp.Time).Round(time.Second).String()
+		}
+		if pp.Amount != "" {
+			amount = fmt.Sprintf("%s %s", pp.Amount, pp.Denom)
+		}
+
+		data = append(data, []string{
+			fmt.Sprint(pp.Sequence),
+			pp.Kind,
+			pp.ChainID,
+			fmt.Sprint(pp.Height),
+			age,
+			amount,
+		})
+	}
+	_ = pterm.DefaultTable.WithHasHeader().WithData(data).Render()
+}
+
+func printResults(selected, remaining []relayer.PendingPacket) {
+	data := pterm.TableData{{"Sequence", "Kind", "Chain", "Result"}}
+	for _, pp := range selected {
+		result := pterm.Green("relayed")
+		stillPending := slices.ContainsFunc(remaining, func(r relayer.PendingPacket) bool {
+			return r.Sequence == pp.Sequence && r.Kind == pp.Kind && r.ChainID == pp.ChainID
+		})
+		if stillPending {
+			result = pterm.Red("pending")
+		}
+
+		data = append(data, []string{fmt.Sprint(pp.Sequence), pp.Kind, pp.ChainID, result})
+	}
+	_ = pterm.DefaultTable.WithHasHeader().WithData(data).Render()
+}
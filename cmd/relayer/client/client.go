@@ -0,0 +1,18 @@
+package client
+
+import (
+	"github.com/spf13/cobra"
+
+	recoverclient "github.com/dymensionxyz/roller/cmd/relayer/client/recover"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "client",
+		Short: "Commands to manage the IBC light clients of the relayer paths",
+	}
+
+	cmd.AddCommand(recoverclient.Cmd())
+
+	return cmd
+}
@@ -0,0 +1,192 @@
+package recoverclient
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/relayer"
+	"github.com/dymensionxyz/roller/utils/bash"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	relayerutils "github.com/dymensionxyz/roller/utils/relayer"
+)
+
+const (
+	flagPath = "path"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "recover",
+		Short: "Guided recovery of stale, expired or frozen IBC light clients",
+		Long: `Guided recovery of stale, expired or frozen IBC light clients.
+
+Clients that are close to the end of their trusting period are updated directly.
+Expired or frozen clients can only be recovered through governance, for those a
+substitute client is created and a recovery proposal is prepared for submission.
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			home, err := filesystem.ExpandHomePath(
+				cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String(),
+			)
+			if err != nil {
+				pterm.Error.Println("failed to expand home directory")
+				return
+			}
+
+			var rlyCfg relayer.Config
+			err = rlyCfg.Load(relayer.GetConfigFilePath(relayer.GetHomeDir(home)))
+			if err != nil {
+				pterm.Error.Println("failed to load relayer config: ", err)
+				return
+			}
+
+			pathName, _ := cmd.Flags().GetString(flagPath)
+			pathName, err = relayerutils.SelectPath(rlyCfg.PathNames(), pathName)
+			if err != nil {
+				pterm.Error.Println(err)
+				return
+			}
+
+			p := rlyCfg.GetPathByName(pathName)
+			hd := rlyCfg.HubDataForPath(pathName)
+			raData := rlyCfg.RaDataForPath(pathName)
+
+			rly := relayer.NewRelayer(home, *raData, *hd)
+			rly.SetPath(pathName)
+
+			clients, errs := rly.QueryPathClients(p)
+			for _, e := range errs {
+				pterm.Warning.Println(e)
+			}
+			if len(clients) == 0 {
+				pterm.Error.Println("failed to query the clients of the path")
+				return
+			}
+			printClients(clients)
+
+			var stale, broken []relayer.ClientStatus
+			for _, c := range clients {
+				switch relayer.ClientState(c) {
+				case "stale":
+					stale = append(stale, c)
+				case "expired", "frozen":
+					broken = append(broken, c)
+				}
+			}
+
+			if len(stale) == 0 && len(broken) == 0 {
+				pterm.Success.Println("all the clients are active, nothing to recover")
+				return
+			}
+
+			if len(stale) > 0 {
+				proceed, _ := pterm.DefaultInteractiveConfirm.WithDefaultValue(true).
+					WithDefaultText("update the clients of the path?").
+					Show()
+				if proceed {
+					err := bash.ExecCmd(rly.GetUpdateClientsCmd())
+					if err != nil {
+						pterm.Error.Println("failed to update clients: ", err)
+						return
+					}
+					pterm.Success.Println("clients updated")
+				}
+			}
+
+			for _, c := range broken {
+				recoverClient(home, pathName, &rlyCfg, c)
+			}
+		},
+	}
+
+	cmd.Flags().String(flagPath, "", "name of the relayer path to recover the clients of")
+
+	return cmd
+}
+
+// recoverClient creates a substitute client and prepares the governance proposal that
+// recovers the expired or frozen client
+func recoverClient(home, pathName string, rlyCfg *relayer.Config, c relayer.ClientStatus) {
+	hd := rlyCfg.HubDataForPath(pathName)
+	onHub := c.ChainID == hd.ID
+
+	binary := consts.Executables.Dymension
+	node := hd.RpcUrl
+	if !onHub {
+		binary = consts.Executables.RollappEVM
+		node = rlyCfg.RaDataForPath(pathName).RpcUrl
+	}
+
+	pterm.Warning.Printfln(
+		"client %s on %s is %s, it can only be recovered through a governance proposal on %s",
+		c.ClientID,
+		c.ChainID,
+		relayer.ClientState(c),
+		c.ChainID,
+	)
+	proceed, _ := pterm.DefaultInteractiveConfirm.WithDefaultValue(false).
+		WithDefaultText(
+			fmt.Sprintf("create a substitute client on %s and prepare the recovery proposal?", c.ChainID),
+		).
+		Show()
+	if !proceed {
+		return
+	}
+
+	spinner, _ := pterm.DefaultSpinner.Start("creating substitute client")
+	substitute, err := relayerutils.CreateSubstituteClient(home, pathName, onHub)
+	if err != nil {
+		spinner.Fail("failed to create substitute client: ", err)
+		return
+	}
+	spinner.Success(fmt.Sprintf("substitute client %s created", substitute))
+
+	proposalPath, err := relayerutils.WriteRecoverClientProposal(
+		home,
+		binary,
+		node,
+		c.ChainID,
+		rlyCfg.Chains[c.ChainID].Value.AccountPrefix,
+		c.ClientID,
+		substitute,
+	)
+	if err != nil {
+		pterm.Error.Println("failed to write the recovery proposal: ", err)
+		return
+	}
+
+	pterm.Info.Printfln("recovery proposal written to %s", proposalPath)
+	pterm.Info.Printfln(
+		"review the deposit and submit the proposal with an account on %s, e.g.\n%s",
+		c.ChainID,
+		pterm.DefaultBasicText.WithStyle(pterm.FgYellow.ToStyle()).Sprintf(
+			"%s tx gov submit-proposal %s --from <key> --node %s --chain-id %s",
+			binary,
+			proposalPath,
+			node,
+			c.ChainID,
+		),
+	)
+	pterm.Info.Println(
+		"once the proposal passes, restart the relayer e.g. using 'roller relayer services restart'",
+	)
+}
+
+func printClients(clients []relayer.ClientStatus) {
+	data := pterm.TableData{{"Chain", "Client", "Latest Height", "Trusting Period Left", "State"}}
+	for _, c := range clients {
+		data = append(data, []string{
+			c.ChainID,
+			c.ClientID,
+			fmt.Sprint(c.LatestHeight),
+			(time.Duration(c.TrustingPeriodLeft) * time.Second).String(),
+			relayer.ClientState(c),
+		})
+	}
+	_ = pterm.DefaultTable.WithHasHeader().WithData(data).Render()
+}
@@ -0,0 +1,18 @@
+package keys
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/dymensionxyz/roller/cmd/relayer/keys/rotate"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "keys",
+		Short: "Commands to manage the relayer keys",
+	}
+
+	cmd.AddCommand(rotate.Cmd())
+
+	return cmd
+}
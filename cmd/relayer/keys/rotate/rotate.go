@@ -0,0 +1,257 @@
+package rotate
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/relayer"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/keys"
+	relayerutils "github.com/dymensionxyz/roller/utils/relayer"
+	"github.com/dymensionxyz/roller/utils/roller"
+	sequencerutils "github.com/dymensionxyz/roller/utils/sequencer"
+)
+
+const (
+	flagPropagationTimeout = "propagation-timeout"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rotate",
+		Short: "Replace the hub and rollapp relayer keys with new ones",
+		Long: `Replace the hub and rollapp relayer keys with new ones.
+
+The new rollapp relayer key is whitelisted by the sequencer first, the new keys
+are then funded with the balances of the old keys and the relayer switches to
+them right away, so the running relayer keeps its funds while the whitelist
+propagates. The old key is removed from the whitelisted relayers once the switch
+is done.
+
+An interrupted rotation is resumed by running the command again, the new keys
+created by the previous run are reused.
+
+The command has to be executed on the sequencer node of the RollApp.
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			home, err := filesystem.ExpandHomePath(
+				cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String(),
+			)
+			if err != nil {
+				pterm.Error.Println("failed to expand home directory")
+				return
+			}
+			timeout, _ := cmd.Flags().GetDuration(flagPropagationTimeout)
+
+			rollerData, err := roller.LoadConfig(home)
+			if err != nil {
+				pterm.Error.Println("failed to load roller config file", err)
+				return
+			}
+
+			var rlyCfg relayer.Config
+			err = rlyCfg.Load(relayer.GetConfigFilePath(relayer.GetHomeDir(home)))
+			if err != nil {
+				pterm.Error.Println("failed to load relayer config: ", err)
+				return
+			}
+
+			pathName := ""
+			for _, p := range rlyCfg.PathNames() {
+				if rlyCfg.Paths[p].Dst.ChainID == rollerData.RollappID {
+					pathName = p
+					break
+				}
+			}
+			if pathName == "" {
+				pterm.Error.Printfln("no relayer path found for %s", rollerData.RollappID)
+				return
+			}
+			hd := rlyCfg.HubDataForPath(pathName)
+
+			rotations, err := relayerutils.RelayerKeyRotations(
+				&rlyCfg,
+				pathName,
+				keys.GetRelayerKeysConfig(rollerData),
+				rollerData.BaseDenom,
+			)
+			if err != nil {
+				pterm.Error.Println("failed to prepare key rotation: ", err)
+				return
+			}
+			hubRotation, raRotation := rotations[0], rotations[1]
+
+			proceed, _ := pterm.DefaultInteractiveConfirm.WithDefaultValue(false).
+				WithDefaultText(
+					fmt.Sprintf(
+						"rotate the relayer keys %s (hub) and %s (rollapp)?",
+						hubRotation.OldAddress,
+						raRotation.OldAddress,
+					),
+				).Show()
+			if !proceed {
+				return
+			}
+
+			pterm.Info.Println("creating new relayer keys")
+			for _, kr := range rotations {
+				err := kr.CreateKey()
+				if err != nil {
+					pterm.Error.Printfln("failed to create new key for %s: %v", kr.ChainID, err)
+					return
+				}
+				if kr.Resumed {
+					pterm.Info.Printfln(
+						"reusing the new key %s of the interrupted rotation on %s",
+						kr.NewKey.Address,
+						kr.ChainID,
+					)
+					continue
+				}
+				kr.NewKey.Print(keys.WithMnemonic(), keys.WithName())
+			}
+
+			seqAddr, err := sequencerutils.GetSequencerAccountAddress(rollerData)
+			if err != nil {
+				pterm.Error.Println("failed to retrieve sequencer address: ", err)
+				return
+			}
+			raOpAddr, err := sequencerutils.GetSequencerOperatorAddress(
+				home,
+				string(rollerData.KeyringBackend),
+			)
+			if err != nil {
+				pterm.Error.Println("failed to retrieve RollApp's operator address: ", err)
+				return
+			}
+
+			whitelisted, err := sequencerutils.GetWhitelistedRelayersOnHub(seqAddr, *hd)
+			if err != nil {
+				pterm.Error.Println("failed to retrieve whitelisted relayers: ", err)
+				return
+			}
+
+			newAddr := raRotation.NewKey.Address
+			oldAddr := raRotation.OldAddress
+
+			// both keys stay whitelisted until the relayer switched to the new key
+			err = updateWhitelist(
+				home,
+				rollerData,
+				raOpAddr,
+				appendMissing(whitelisted, newAddr),
+				timeout,
+				func(wra []string) bool { return slices.Contains(wra, newAddr) },
+			)
+			if err != nil {
+				pterm.Error.Println("failed to whitelist the new relayer key: ", err)
+				return
+			}
+
+			for _, kr := range rotations {
+				spinner, _ := pterm.DefaultSpinner.Start(
+					fmt.Sprintf("funding the new relayer key on %s", kr.ChainID),
+				)
+				amount, err := kr.Fund()
+				if err != nil {
+					spinner.Fail(fmt.Sprintf("failed to fund the new key on %s: %v", kr.ChainID, err))
+					pterm.Info.Println(
+						"run the command again to resume the rotation, the keys were not switched",
+					)
+					return
+				}
+				spinner.Success(fmt.Sprintf("transferred %s to %s", amount.String(), kr.NewKey.Address))
+			}
+
+			for _, kr := range rotations {
+				err := kr.Switch()
+				if err != nil {
+					pterm.Error.Printfln("failed to switch the relayer key on %s: %v", kr.ChainID, err)
+					return
+				}
+			}
+			pterm.Success.Println("the relayer uses the new keys")
+
+			err = updateWhitelist(
+				home,
+				rollerData,
+				raOpAddr,
+				slices.DeleteFunc(
+					appendMissing(whitelisted, newAddr),
+					func(a string) bool { return a == oldAddr },
+				),
+				timeout,
+				func(wra []string) bool { return !slices.Contains(wra, oldAddr) },
+			)
+			if err != nil {
+				pterm.Error.Println("failed to remove the old key from the whitelisted relayers: ", err)
+				return
+			}
+
+			pterm.Success.Println("relayer keys rotated")
+			pterm.Info.Printf(
+				"restart the relayer to apply the changes, e.g. using %s\n",
+				pterm.DefaultBasicText.WithStyle(pterm.FgYellow.ToStyle()).
+					Sprintf("roller relayer services restart"),
+			)
+		},
+	}
+
+	cmd.Flags().Duration(
+		flagPropagationTimeout,
+		relayer.DefaultWhitelistPropagationTimeout,
+		"maximum time to wait for the whitelisted relayers to propagate to the RollApp",
+	)
+
+	return cmd
+}
+
+func updateWhitelist(
+	home string,
+	rollerData roller.RollappConfig,
+	raOpAddr string,
+	relayers []string,
+	timeout time.Duration,
+	propagated func([]string) bool,
+) error {
+	err := sequencerutils.UpdateWhitelistedRelayers(
+		home,
+		strings.Join(relayers, ","),
+		string(rollerData.KeyringBackend),
+		rollerData.HubData,
+	)
+	if err != nil {
+		return err
+	}
+
+	spinner, _ := pterm.DefaultSpinner.Start(
+		"waiting for the whitelisted relayers to propagate to RollApp (this might take a while)",
+	)
+	err = relayer.WaitForRaWhitelist(
+		relayer.RaWhitelistQuery(raOpAddr),
+		propagated,
+		timeout,
+		relayer.SpinnerProgress(spinner, "waiting for the whitelisted relayers to propagate to RollApp"),
+	)
+	if err != nil {
+		spinner.Fail(err.Error())
+		return err
+	}
+	spinner.Success("whitelisted relayers propagated to RollApp")
+
+	return nil
+}
+
+func appendMissing(list []string, addr string) []string {
+	res := slices.Clone(list)
+	if !slices.Contains(res, addr) {
+		res = append(res, addr)
+	}
+	return res
+}
@@ -0,0 +1,69 @@
+package add
+
+import (
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	relayerutils "github.com/dymensionxyz/roller/utils/relayer"
+)
+
+const flagPathName = "path-name"
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add <rollapp-id>",
+		Short: "Add a relayer path for an additional RollApp",
+		Long: `Add a relayer path for an additional RollApp.
+
+The RollApp must already have an open IBC channel with the hub used by the existing relayer paths.
+`,
+		Example: "roller relayer paths add myrollapp_123-1 --path-name hub-myrollapp",
+		Args:    cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			home, err := filesystem.ExpandHomePath(
+				cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String(),
+			)
+			if err != nil {
+				pterm.Error.Println("failed to expand home directory")
+				return
+			}
+
+			raID := args[0]
+			pathName, _ := cmd.Flags().GetString(flagPathName)
+			if pathName == "" {
+				pathName = raID
+			}
+
+			rly, err := relayerutils.AddRollappPath(home, pathName, raID)
+			if err != nil {
+				pterm.Error.Println("failed to add relayer path: ", err)
+				return
+			}
+
+			pterm.Success.Printf("relayer path %s added\n", pathName)
+			pterm.Info.Println("Hub channel: ", rly.SrcChannel)
+			pterm.Info.Println("Hub connection: ", rly.SrcConnectionID)
+			pterm.Info.Println("Hub client: ", rly.SrcClientID)
+			pterm.Info.Println("RollApp channel: ", rly.DstChannel)
+			pterm.Info.Println("RollApp connection: ", rly.DstConnectionID)
+			pterm.Info.Println("RollApp client: ", rly.DstClientID)
+
+			pterm.Warning.Printf(
+				"make sure the relayer address of %s is whitelisted by the rollapp's sequencer\n",
+				raID,
+			)
+
+			pterm.Info.Printf(
+				"restart the relayer to relay the new path, e.g. using %s\n",
+				pterm.DefaultBasicText.WithStyle(pterm.FgYellow.ToStyle()).
+					Sprintf("roller relayer services restart"),
+			)
+		},
+	}
+
+	cmd.Flags().String(flagPathName, "", "name of the relayer path (default: the rollapp ID)")
+
+	return cmd
+}
@@ -0,0 +1,57 @@
+package list
+
+import (
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/relayer"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the relayer paths",
+		Run: func(cmd *cobra.Command, args []string) {
+			home := cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String()
+			relayerHome := relayer.GetHomeDir(home)
+
+			var rlyCfg relayer.Config
+			err := rlyCfg.Load(relayer.GetConfigFilePath(relayerHome))
+			if err != nil {
+				pterm.Error.Println("failed to load relayer config: ", err)
+				return
+			}
+
+			paths := rlyCfg.PathNames()
+			if len(paths) == 0 {
+				pterm.Info.Println("no relayer paths found")
+				return
+			}
+
+			data := pterm.TableData{
+				{"Path", "Hub", "Hub Client", "Hub Connection", "RollApp", "RollApp Client", "RollApp Connection"},
+			}
+			for _, name := range paths {
+				p := rlyCfg.Paths[name]
+				data = append(data, []string{
+					name,
+					p.Src.ChainID,
+					p.Src.ClientID,
+					p.Src.ConnectionID,
+					p.Dst.ChainID,
+					p.Dst.ClientID,
+					p.Dst.ConnectionID,
+				})
+			}
+
+			err = pterm.DefaultTable.WithHasHeader().WithData(data).Render()
+			if err != nil {
+				pterm.Error.Println("failed to render relayer paths: ", err)
+				return
+			}
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,22 @@
+package paths
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/dymensionxyz/roller/cmd/relayer/paths/add"
+	"github.com/dymensionxyz/roller/cmd/relayer/paths/list"
+	"github.com/dymensionxyz/roller/cmd/relayer/paths/remove"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "paths",
+		Short: "Commands to manage the RollApps the relayer relays packets for",
+	}
+
+	cmd.AddCommand(add.Cmd())
+	cmd.AddCommand(list.Cmd())
+	cmd.AddCommand(remove.Cmd())
+
+	return cmd
+}
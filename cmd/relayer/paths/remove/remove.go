@@ -0,0 +1,53 @@
+package remove
+
+import (
+	"fmt"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	relayerutils "github.com/dymensionxyz/roller/utils/relayer"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "remove <path-name>",
+		Short: "Remove a relayer path",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			home, err := filesystem.ExpandHomePath(
+				cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String(),
+			)
+			if err != nil {
+				pterm.Error.Println("failed to expand home directory")
+				return
+			}
+
+			pathName := args[0]
+			proceed, _ := pterm.DefaultInteractiveConfirm.WithDefaultValue(false).
+				WithDefaultText(
+					fmt.Sprintf("the relayer will stop relaying packets on %s, continue?", pathName),
+				).Show()
+			if !proceed {
+				return
+			}
+
+			err = relayerutils.RemoveRollappPath(home, pathName)
+			if err != nil {
+				pterm.Error.Println("failed to remove relayer path: ", err)
+				return
+			}
+			pterm.Success.Printf("relayer path %s removed\n", pathName)
+
+			pterm.Info.Printf(
+				"restart the relayer to apply the changes, e.g. using %s\n",
+				pterm.DefaultBasicText.WithStyle(pterm.FgYellow.ToStyle()).
+					Sprintf("roller relayer services restart"),
+			)
+		},
+	}
+
+	return cmd
+}
@@ -3,10 +3,15 @@ package relayer
 import (
 	"github.com/spf13/cobra"
 
+	clearpackets "github.com/dymensionxyz/roller/cmd/relayer/clear"
+	"github.com/dymensionxyz/roller/cmd/relayer/client"
+	"github.com/dymensionxyz/roller/cmd/relayer/keys"
+	"github.com/dymensionxyz/roller/cmd/relayer/paths"
 	"github.com/dymensionxyz/roller/cmd/relayer/setup"
 	"github.com/dymensionxyz/roller/cmd/relayer/start"
 	"github.com/dymensionxyz/roller/cmd/relayer/status"
 	"github.com/dymensionxyz/roller/cmd/relayer/update"
+	"github.com/dymensionxyz/roller/cmd/relayer/whitelist"
 	"github.com/dymensionxyz/roller/cmd/services"
 	loadservices "github.com/dymensionxyz/roller/cmd/services/load"
 	restartservices "github.com/dymensionxyz/roller/cmd/services/restart"
@@ -24,6 +29,11 @@ func Cmd() *cobra.Command {
 	cmd.AddCommand(start.Cmd())
 	cmd.AddCommand(status.Cmd())
 	cmd.AddCommand(update.Cmd())
+	cmd.AddCommand(paths.Cmd())
+	cmd.AddCommand(client.Cmd())
+	cmd.AddCommand(clearpackets.Cmd())
+	cmd.AddCommand(keys.Cmd())
+	cmd.AddCommand(whitelist.Cmd())
 
 	sl := []string{"relayer"}
 	cmd.AddCommand(
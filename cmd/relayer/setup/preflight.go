@@ -0,0 +1,40 @@
+package setup
+
+import (
+	"github.com/pterm/pterm"
+
+	relayerutils "github.com/dymensionxyz/roller/utils/relayer"
+)
+
+func printPreflightReport(report *relayerutils.PreflightReport) {
+	checks := pterm.TableData{{"Check", "Status", "Details"}}
+	for _, c := range report.Checks {
+		checks = append(checks, []string{c.Name, checkStatus(c.Status), c.Message})
+	}
+	pterm.DefaultSection.Println("Pre-flight checks")
+	_ = pterm.DefaultTable.WithHasHeader().WithData(checks).Render()
+
+	plan := pterm.TableData{{"Resource", "Action", "Details"}}
+	for _, s := range report.Plan {
+		plan = append(plan, []string{s.Resource, s.Action, s.Details})
+	}
+	pterm.DefaultSection.Println("Setup plan")
+	_ = pterm.DefaultTable.WithHasHeader().WithData(plan).Render()
+
+	if !report.Passed() {
+		pterm.Error.Println("pre-flight checks failed, fix them before running the setup")
+		return
+	}
+	pterm.Success.Println("pre-flight checks passed, nothing was sent to the chains")
+}
+
+func checkStatus(status string) string {
+	switch status {
+	case relayerutils.CheckStatuses.Passed:
+		return pterm.Green("✔ " + status)
+	case relayerutils.CheckStatuses.Warning:
+		return pterm.Yellow("! " + status)
+	default:
+		return pterm.Red("✘ " + status)
+	}
+}
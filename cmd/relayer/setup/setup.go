@@ -27,6 +27,8 @@ import (
 	servicemanager "github.com/dymensionxyz/roller/utils/service_manager"
 )
 
+const flagDryRun = "dry-run"
+
 // TODO: cleanup required, a lot of duplicate code in this cmd
 func Cmd() *cobra.Command {
 	relayerStartCmd := &cobra.Command{
@@ -38,10 +40,14 @@ func Cmd() *cobra.Command {
 				cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String(),
 			)
 
-			err := servicemanager.StopSystemServices(consts.RelayerSystemdServices)
-			if err != nil {
-				pterm.Error.Println("failed to stop system services: ", err)
-				return
+			dryRun, _ := cmd.Flags().GetBool(flagDryRun)
+
+			if !dryRun {
+				err := servicemanager.StopSystemServices(consts.RelayerSystemdServices)
+				if err != nil {
+					pterm.Error.Println("failed to stop system services: ", err)
+					return
+				}
 			}
 
 			raData, hd, kb, err := getPreRunInfo(home)
@@ -73,6 +79,11 @@ func Cmd() *cobra.Command {
 			}
 			pterm.Info.Println("rollapp chain data validation passed")
 
+			if dryRun {
+				printPreflightReport(relayerutils.Preflight(home, rly, *rollappChainData))
+				return
+			}
+
 			err = installRelayerDependencies(home, rly.Rollapp.ID, *hd)
 			if err != nil {
 				pterm.Error.Println("failed to install relayer dependencies: ", err)
@@ -203,7 +214,7 @@ func Cmd() *cobra.Command {
 					return
 				}
 
-				err = rly.UpdateDefaultPath()
+				err = rly.UpdatePath()
 				if err != nil {
 					pterm.Error.Println("failed to update relayer config: ", err)
 					return
@@ -229,6 +240,12 @@ func Cmd() *cobra.Command {
 		},
 	}
 
+	relayerStartCmd.Flags().Bool(
+		flagDryRun,
+		false,
+		"run the pre-flight checks and show what would be created or reused, without sending transactions",
+	)
+
 	return relayerStartCmd
 }
 
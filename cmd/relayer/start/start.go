@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"path/filepath"
+	"strings"
 
 	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
@@ -11,23 +12,38 @@ import (
 	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
 	"github.com/dymensionxyz/roller/cmd/consts"
 	"github.com/dymensionxyz/roller/relayer"
+	"github.com/dymensionxyz/roller/relayer/native"
 	"github.com/dymensionxyz/roller/utils/bash"
 	"github.com/dymensionxyz/roller/utils/errorhandling"
+	"github.com/dymensionxyz/roller/utils/filesystem"
 	"github.com/dymensionxyz/roller/utils/logging"
 	"github.com/dymensionxyz/roller/utils/rollapp"
+	"github.com/dymensionxyz/roller/utils/roller"
 )
 
 const (
-	flagOverride = "override"
+	flagOverride            = "override"
+	flagClientCheckInterval = "client-check-interval"
+	flagEngine              = "engine"
+	flagMetricsAddress      = "metrics-address"
 )
 
 func Cmd() *cobra.Command {
 	relayerStartCmd := &cobra.Command{
 		Use:   "start",
-		Short: "Start the relayer process interactively.",
-		Long: `Start the relayer process interactively.
+		Short: "Start the relayer process interactively for all the relayer paths.",
+		Long: `Start the relayer process interactively for all the relayer paths.
 
 Consider using 'services' if you want to run a 'systemd' service instead.
+
+The packets are relayed by rly by default, '--engine native' relays them from
+within roller instead, without the rly binary. The engine is saved in the
+'Relayer' section of roller.toml and used by the relayer service and the flush
+cron job from then on.
+
+The relayed packets, failed transactions, gas, fees, packet latencies and the
+trusting period left of the light clients are exposed as prometheus metrics on
+'--metrics-address'.
 `,
 		Run: func(cmd *cobra.Command, args []string) {
 			home := cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String()
@@ -45,68 +61,165 @@ Consider using 'services' if you want to run a 'systemd' service instead.
 				return
 			}
 
-			raData := rlyCfg.RaDataFromRelayerConfig()
-			hd := rlyCfg.HubDataFromRelayerConfig()
+			paths := rlyCfg.PathNames()
+			if len(paths) == 0 {
+				pterm.Error.Println("💈 No relayer paths found, ensure you've setup the relayer")
+				return
+			}
 
-			raResponse, err := rollapp.GetMetadataFromChain(raData.ID, *hd)
+			policy, err := relayer.LoadPolicy(home)
 			if err != nil {
-				pterm.Error.Println("failed to fetch rollapp information from hub: ", err)
+				pterm.Error.Println("failed to load relayer policy: ", err)
 				return
 			}
-			raData.Denom = raResponse.Rollapp.GenesisInfo.NativeDenom.Base
 
-			err = relayer.VerifyRelayerBalances(*hd)
+			engine := relayer.Engine(policy)
+			if cmd.Flags().Changed(flagEngine) {
+				engine, _ = cmd.Flags().GetString(flagEngine)
+			}
+			err = relayer.ValidateEngine(engine)
 			if err != nil {
-				pterm.Error.Println("failed to check balances", err)
+				pterm.Error.Println(err)
 				return
 			}
+			if engine != relayer.Engine(policy) {
+				err = saveEngine(home, engine, policy)
+				if err != nil {
+					pterm.Error.Println("failed to save relaying engine: ", err)
+					return
+				}
+			}
+
+			minValues, err := relayer.MinPacketValues(policy)
+			if err != nil {
+				pterm.Error.Println("invalid relayer policy: ", err)
+				return
+			}
+			policy.Engine = engine
+			if relayer.MinPacketValueIgnored(policy) {
+				pterm.Warning.Println(
+					"the minimal packet value of the relayer policy is only enforced by the native engine",
+				)
+			}
+
 			relayerLogFilePath := logging.GetRelayerLogPath(home)
 			logger := logging.GetLogger(relayerLogFilePath)
 			logFileOption := logging.WithLoggerLogging(logger)
-			rly := relayer.NewRelayer(
-				home,
-				*raData,
-				*hd,
-			)
-			rly.SetLogger(logger)
-
-			err = rly.LoadActiveChannel(*raData, *hd)
-			errorhandling.PrettifyErrorIfExists(err)
-
-			// override := cmd.Flag(flagOverride).Changed
-			//
-			// if override {
-			// 	fmt.Println("💈 Overriding the existing relayer channel")
-			// }
-
-			if rly.ChannelReady() {
-				fmt.Println("💈 IBC transfer channel is established!")
-				status := fmt.Sprintf(
-					"Active\nrollapp: %s\n<->\nhub: %s\n",
-					rly.DstChannel, rly.SrcChannel,
+
+			// all the paths share the same hub
+			hd := rlyCfg.HubDataForPath(paths[0])
+			err = relayer.VerifyRelayerBalances(*hd)
+			if err != nil {
+				pterm.Error.Println("failed to check balances", err)
+				return
+			}
+
+			var statuses []string
+			var engines []*native.Engine
+			for _, p := range paths {
+				raData := rlyCfg.RaDataForPath(p)
+				pathHd := rlyCfg.HubDataForPath(p)
+
+				raResponse, err := rollapp.GetMetadataFromChain(raData.ID, *pathHd)
+				if err != nil {
+					pterm.Error.Printf(
+						"failed to fetch %s information from hub: %v\n",
+						raData.ID,
+						err,
+					)
+					return
+				}
+				raData.Denom = raResponse.Rollapp.GenesisInfo.NativeDenom.Base
+
+				rly := relayer.NewRelayer(
+					home,
+					*raData,
+					*pathHd,
 				)
-				err := rly.WriteRelayerStatus(status)
+				rly.SetPath(p)
+				rly.SetLogger(logger)
+
+				err = rly.LoadActiveChannel(*raData, *pathHd)
 				errorhandling.PrettifyErrorIfExists(err)
-			} else {
-				pterm.Error.Println("💈 No channels found, ensure you've setup the relayer")
-				return
+
+				if !rly.ChannelReady() {
+					pterm.Error.Printf(
+						"💈 No channels found for path %s, ensure you've setup the relayer\n",
+						p,
+					)
+					return
+				}
+
+				if engine == relayer.Engines.Native {
+					e, err := native.NewEngine(rly, &rlyCfg)
+					if err != nil {
+						pterm.Error.Printf("failed to create relaying engine for %s: %v\n", p, err)
+						return
+					}
+					e.SetLogger(logger)
+					e.MaxMsgs = relayer.MaxMsgs(policy)
+					e.MinPacketValue = minValues
+					engines = append(engines, e)
+				}
+
+				statuses = append(statuses, fmt.Sprintf(
+					"Path: %s\nrollapp %s: %s\n<->\nhub: %s\n",
+					p, raData.ID, rly.DstChannel, rly.SrcChannel,
+				))
 			}
 
+			fmt.Println("💈 IBC transfer channels are established!")
+
 			ctx, cancel := context.WithCancel(context.Background())
 			defer cancel()
 
-			go bash.RunCmdAsync(
-				ctx,
-				rly.GetStartCmd(),
-				func() {},
-				func(errMessage string) string { return errMessage },
-				logFileOption,
-			)
+			var metrics *relayer.PacketMetrics
+			metricsAddr, _ := cmd.Flags().GetString(flagMetricsAddress)
+			if metricsAddr != "" {
+				metrics = relayer.NewPacketMetrics(&rlyCfg, logger)
+				go func() {
+					err := metrics.Serve(metricsAddr)
+					logger.Printf("relayer metrics server stopped: %v", err)
+				}()
+				fmt.Printf("💈 Relayer metrics: http://%s/metrics\n", metricsAddr)
+			}
+
+			checkInterval, _ := cmd.Flags().GetDuration(flagClientCheckInterval)
+			watchdog := relayer.NewClientWatchdog(home, paths, checkInterval, logger)
+			if metrics != nil {
+				watchdog.SetMetrics(metrics)
+			}
+
+			if engine == relayer.Engines.Native {
+				// the native engine updates the clients that are close to expiry itself,
+				// the watchdog only reports the expired and frozen ones
+				watchdog.SetAlertOnly(true)
+				for _, e := range engines {
+					e.Metrics = metrics
+					go e.Run(ctx)
+				}
+			} else {
+				go bash.RunCmdAsync(
+					ctx,
+					relayer.GetStartPathsCmd(
+						relayer.GetHomeDir(home),
+						paths,
+						relayer.MaxMsgs(policy),
+					),
+					func() {},
+					func(errMessage string) string { return errMessage },
+					logFileOption,
+				)
+
+				if metrics != nil {
+					go metrics.Follow(ctx, relayerLogFilePath)
+				}
+			}
+			go watchdog.Run(ctx)
 
 			fmt.Printf(
-				"💈 The relayer is running successfully on you local machine!\nChannels:\nRollApp: %s\n<->\nHub: %s\n",
-				rly.DstChannel,
-				rly.SrcChannel,
+				"💈 The relayer is running successfully on you local machine!\n%s",
+				strings.Join(statuses, "\n"),
 			)
 			fmt.Println("💈 Log file path: ", relayerLogFilePath)
 
@@ -116,5 +229,48 @@ Consider using 'services' if you want to run a 'systemd' service instead.
 
 	relayerStartCmd.Flags().
 		BoolP(flagOverride, "", false, "override the existing relayer clients and channels")
+	relayerStartCmd.Flags().Duration(
+		flagClientCheckInterval,
+		relayer.DefaultClientCheckInterval,
+		"interval between the checks of the light clients trusting period",
+	)
+	relayerStartCmd.Flags().String(
+		flagMetricsAddress,
+		relayer.DefaultMetricsAddress,
+		"address of the prometheus relayer metrics, empty to disable",
+	)
+	relayerStartCmd.Flags().String(
+		flagEngine,
+		"",
+		fmt.Sprintf(
+			"relaying engine to use (%s or %s), the engine of roller.toml when unset",
+			relayer.Engines.Rly,
+			relayer.Engines.Native,
+		),
+	)
 	return relayerStartCmd
 }
+
+// saveEngine persists the engine in roller.toml and reschedules the flush cron job when
+// the relayer service is loaded, as the native engine doesn't need it
+func saveEngine(home, engine string, policy roller.RelayerPolicy) error {
+	ok, err := filesystem.DoesFileExist(roller.GetConfigPath(home))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		pterm.Warning.Println("roller.toml not found, the relaying engine is not saved")
+		return nil
+	}
+
+	err = relayer.SaveEngine(home, engine)
+	if err != nil {
+		return err
+	}
+
+	if !filesystem.ServiceFilesExist([]string{"relayer"}) {
+		return nil
+	}
+	policy.Engine = engine
+	return relayer.ScheduleFlush(relayer.GetHomeDir(home), policy)
+}
@@ -1,10 +1,12 @@
 package status
 
 import (
-	"errors"
+	"encoding/json"
 	"fmt"
-	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
@@ -12,16 +14,42 @@ import (
 	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
 	"github.com/dymensionxyz/roller/cmd/consts"
 	"github.com/dymensionxyz/roller/relayer"
-	"github.com/dymensionxyz/roller/utils/errorhandling"
 	"github.com/dymensionxyz/roller/utils/logging"
+	"github.com/dymensionxyz/roller/utils/rollapp"
+)
+
+const (
+	flagOutput         = "output"
+	flagMetricsAddress = "metrics-address"
+
+	outputTable = "table"
+	outputJSON  = "json"
 )
 
 func Cmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "status",
-		Short: "Show the status of the relayer on the local machine.",
+		Short: "Show the status of the relayer paths on the local machine.",
+		Long: `Show the status of the relayer paths on the local machine.
+
+For every path the command reports the unrelayed packets and acknowledgements on
+both ends, the state of the light clients, the relayer account balances and the
+relaying metrics derived from the rly logs. The metrics are retrieved from the
+exporter of 'relayer start', the relayer log is parsed when it's not running.
+`,
 		Run: func(cmd *cobra.Command, args []string) {
 			home := cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String()
+			output, _ := cmd.Flags().GetString(flagOutput)
+			if output != outputTable && output != outputJSON {
+				pterm.Error.Printfln(
+					"unsupported output '%s', supported outputs: %s, %s",
+					output,
+					outputTable,
+					outputJSON,
+				)
+				return
+			}
+
 			rlyConfigPath := filepath.Join(
 				home,
 				consts.ConfigDirName.Relayer,
@@ -37,27 +65,203 @@ func Cmd() *cobra.Command {
 				return
 			}
 
-			raData := rlyCfg.RaDataFromRelayerConfig()
-			hd := rlyCfg.HubDataFromRelayerConfig()
+			paths := rlyCfg.PathNames()
+			if len(paths) == 0 {
+				fmt.Println("💈 No relayer paths found, ensure you've setup the relayer")
+				return
+			}
 
-			rly := relayer.NewRelayer(
-				home,
-				*raData,
-				*hd,
-			)
+			// the json output has to be the only thing written to stdout
+			if output == outputJSON {
+				pterm.DisableOutput()
+			}
 
-			bytes, err := os.ReadFile(rly.StatusFilePath())
-			if err != nil {
-				if errors.Is(err, os.ErrNotExist) {
-					fmt.Println("💈 Starting...")
+			metricsAddr, _ := cmd.Flags().GetString(flagMetricsAddress)
+			metrics, metricsErr := relayer.GetMetrics(metricsAddr, relayerLogFilePath)
+
+			var statuses []*relayer.PathStatus
+			for _, p := range paths {
+				s := getPathStatus(home, p, &rlyCfg)
+				if metricsErr != nil {
+					s.Errors = append(s.Errors, fmt.Sprintf("relayer metrics: %v", metricsErr))
+				} else {
+					s.SetMetrics(metrics)
+				}
+				statuses = append(statuses, s)
+			}
+
+			if output == outputJSON {
+				pterm.EnableOutput()
+				j, err := json.MarshalIndent(statuses, "", "  ")
+				if err != nil {
+					pterm.Error.Println("failed to marshal relayer status: ", err)
 					return
 				}
-			} else {
-				errorhandling.PrettifyErrorIfExists(err)
+				fmt.Println(string(j))
+				return
+			}
+
+			for _, s := range statuses {
+				printPathStatus(s)
 			}
-			fmt.Println(string(bytes))
+
 			fmt.Println("💈 Log file path: ", relayerLogFilePath)
 		},
 	}
+
+	cmd.Flags().String(flagOutput, outputTable, "output format (table or json)")
+	cmd.Flags().String(
+		flagMetricsAddress,
+		relayer.DefaultMetricsAddress,
+		"address of the relayer metrics exporter",
+	)
+
 	return cmd
 }
+
+func getPathStatus(home, path string, rlyCfg *relayer.Config) *relayer.PathStatus {
+	raData := rlyCfg.RaDataForPath(path)
+	hd := rlyCfg.HubDataForPath(path)
+
+	rly := relayer.NewRelayer(
+		home,
+		*raData,
+		*hd,
+	)
+	rly.SetPath(path)
+
+	// the rollapp denom is required to query the relayer balance on the rollapp
+	raResponse, err := rollapp.GetMetadataFromChain(raData.ID, *hd)
+	if err == nil {
+		rly.Rollapp.Denom = raResponse.Rollapp.GenesisInfo.NativeDenom.Base
+	}
+
+	// a missing channel is reported as part of the path status
+	// nolint:errcheck
+	rly.LoadActiveChannel(*raData, *hd)
+
+	s := rly.GetPathStatus(rlyCfg.GetPathByName(path))
+	if err != nil {
+		s.Errors = append(s.Errors, fmt.Sprintf("rollapp metadata: %v", err))
+	}
+
+	return s
+}
+
+func printPathStatus(s *relayer.PathStatus) {
+	pterm.DefaultSection.WithIndentCharacter("💈").
+		Printf("Path %s: %s <-> %s\n", s.Path, s.HubID, s.RollappID)
+
+	if s.HubChannel != "" {
+		fmt.Printf("Channels:\nrollapp: %s\n<->\nhub: %s\n\n", s.RollappChannel, s.HubChannel)
+	}
+
+	_ = pterm.DefaultTable.WithHasHeader().WithData(pterm.TableData{
+		{"", "Packets", "Acks"},
+		{
+			"pending on hub",
+			formatSequences(s.Packets.Hub),
+			formatSequences(s.Acks.Hub),
+		},
+		{
+			"pending on rollapp",
+			formatSequences(s.Packets.Rollapp),
+			formatSequences(s.Acks.Rollapp),
+		},
+	}).Render()
+	fmt.Println()
+
+	if len(s.Clients) > 0 {
+		clients := pterm.TableData{
+			{"Chain", "Client", "Latest Height", "Last Update", "Trusting Period Left"},
+		}
+		for _, c := range s.Clients {
+			left := (time.Duration(c.TrustingPeriodLeft) * time.Second).String()
+			if c.Expired || c.Frozen {
+				left = pterm.Red(relayer.ClientState(c))
+			}
+			clients = append(clients, []string{
+				c.ChainID,
+				c.ClientID,
+				strconv.FormatUint(c.LatestHeight, 10),
+				c.LastUpdate.Format(time.RFC3339),
+				left,
+			})
+		}
+		_ = pterm.DefaultTable.WithHasHeader().WithData(clients).Render()
+		fmt.Println()
+	}
+
+	if len(s.Balances) > 0 {
+		balances := pterm.TableData{{"Chain", "Address", "Balance"}}
+		for _, b := range s.Balances {
+			balances = append(balances, []string{b.ChainID, b.Address, b.Balance})
+		}
+		_ = pterm.DefaultTable.WithHasHeader().WithData(balances).Render()
+		fmt.Println()
+	}
+
+	if len(s.Metrics) > 0 {
+		metrics := pterm.TableData{
+			{
+				"Chain", "Channel", "Packets", "Acks", "Timeouts", "Txs", "Failed", "Gas",
+				"Fees", "Avg Latency", "Last Relay",
+			},
+		}
+		for _, m := range s.Metrics {
+			latency, lastRelay := "-", "-"
+			if m.LatencySamples > 0 {
+				latency = m.AvgLatency().Round(time.Second).String()
+			}
+			if !m.LastRelay.IsZero() {
+				lastRelay = m.LastRelay.Format(time.RFC3339)
+			}
+			metrics = append(metrics, []string{
+				m.ChainID,
+				m.Channel,
+				strconv.FormatUint(m.Packets, 10),
+				strconv.FormatUint(m.Acks, 10),
+				strconv.FormatUint(m.Timeouts, 10),
+				strconv.FormatUint(m.Txs, 10),
+				strconv.FormatUint(m.FailedTxs, 10),
+				strconv.FormatUint(m.GasUsed, 10),
+				m.Fees.String(),
+				latency,
+				lastRelay,
+			})
+		}
+		_ = pterm.DefaultTable.WithHasHeader().WithData(metrics).Render()
+		fmt.Println()
+
+		for _, m := range s.Metrics {
+			if m.LastError != "" {
+				pterm.Warning.Printfln("last failed transaction on %s: %s", m.ChainID, m.LastError)
+			}
+		}
+	}
+
+	for _, e := range s.Errors {
+		pterm.Warning.Println(e)
+	}
+}
+
+// formatSequences prints the number of pending sequences followed by the first few
+// of them, e.g. '3 (1, 2, 5)'
+func formatSequences(seqs []uint64) string {
+	const maxShown = 5
+
+	if len(seqs) == 0 {
+		return "0"
+	}
+
+	var shown []string
+	for i, seq := range seqs {
+		if i == maxShown {
+			shown = append(shown, "...")
+			break
+		}
+		shown = append(shown, strconv.FormatUint(seq, 10))
+	}
+
+	return fmt.Sprintf("%d (%s)", len(seqs), strings.Join(shown, ", "))
+}
@@ -1,54 +1,133 @@
 package update
 
 import (
+	"strings"
+
 	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
 
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
 	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/relayer"
 	"github.com/dymensionxyz/roller/utils/dependencies"
 	"github.com/dymensionxyz/roller/utils/dependencies/types"
 	dependencytypes "github.com/dymensionxyz/roller/utils/dependencies/types"
+	"github.com/dymensionxyz/roller/utils/filesystem"
 	"github.com/dymensionxyz/roller/utils/firebase"
+	"github.com/dymensionxyz/roller/utils/roller"
 	servicemanager "github.com/dymensionxyz/roller/utils/service_manager"
 )
 
+const flagPolicyOnly = "policy-only"
+
 func Cmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "update",
-		Short: "Update the Dymension's relayer version",
+		Short: "Update the Dymension's relayer version and apply the relayer policy",
+		Long: `Update the Dymension's relayer version and apply the relayer policy.
+
+The 'Relayer' section of roller.toml (gas adjustment and gas prices per chain,
+max msgs per transaction, flush interval and minimal packet value) is applied to
+the relayer config without running the setup again. Use '--policy-only' to skip
+the relayer binary update.
+`,
 		Run: func(cmd *cobra.Command, args []string) {
-			pterm.Info.Println("preparing update")
+			home, _ := filesystem.ExpandHomePath(
+				cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String(),
+			)
+			policyOnly, _ := cmd.Flags().GetBool(flagPolicyOnly)
 
-			bvi, err := firebase.GetDependencyVersions()
+			policy, err := relayer.LoadPolicy(home)
+			if err != nil {
+				pterm.Error.Println("failed to load relayer policy: ", err)
+				return
+			}
+			err = relayer.ValidatePolicy(policy)
 			if err != nil {
-				pterm.Error.Println("failed to fetch binary versions: ", err)
+				pterm.Error.Println("invalid relayer policy: ", err)
 				return
 			}
 
-			rlyDep := dependencytypes.Dependency{
-				DependencyName:  "go-relayer",
-				RepositoryOwner: "dymensionxyz",
-				RepositoryName:  "go-relayer",
-				RepositoryUrl:   "https://github.com/dymensionxyz/go-relayer",
-				Release:         bvi.Relayer,
-				Binaries: []types.BinaryPathPair{
-					{
-						Binary:            "rly",
-						BinaryDestination: consts.Executables.Relayer,
-					},
-				},
+			if relayer.MinPacketValueIgnored(policy) {
+				pterm.Warning.Printfln(
+					"the minimal packet value of the relayer policy is only enforced by the %s engine, "+
+						"set 'engine = \"%s\"' in the 'Relayer' section of roller.toml to enforce it",
+					relayer.Engines.Native,
+					relayer.Engines.Native,
+				)
 			}
 
+			pterm.Info.Println("preparing update")
+
 			_ = servicemanager.StopSystemServices([]string{"relayer"})
-			err = dependencies.InstallBinaryFromRelease(rlyDep)
+			// the relayer is restarted even when the update fails, so that it keeps relaying
+			// with the previous binary or policy
+			defer func() {
+				_ = servicemanager.StartSystemServices([]string{"relayer"})
+			}()
+
+			if !policyOnly {
+				err = installRelayer()
+				if err != nil {
+					pterm.Error.Println("failed to install relayer: ", err)
+					return
+				}
+			}
+
+			err = applyPolicy(relayer.GetHomeDir(home), policy)
 			if err != nil {
-				pterm.Error.Println("failed to install eibc client: ", err)
+				pterm.Error.Println("failed to apply relayer policy: ", err)
 				return
 			}
-
-			_ = servicemanager.StartSystemServices([]string{"relayer"})
+			pterm.Success.Println("relayer policy applied")
 		},
 	}
 
+	cmd.Flags().Bool(flagPolicyOnly, false, "only apply the relayer policy of roller.toml")
+
 	return cmd
 }
+
+func installRelayer() error {
+	bvi, err := firebase.GetDependencyVersions()
+	if err != nil {
+		return err
+	}
+
+	rlyDep := dependencytypes.Dependency{
+		DependencyName:  "go-relayer",
+		RepositoryOwner: "dymensionxyz",
+		RepositoryName:  "go-relayer",
+		RepositoryUrl:   "https://github.com/dymensionxyz/go-relayer",
+		Release:         bvi.Relayer,
+		Binaries: []types.BinaryPathPair{
+			{
+				Binary:            "rly",
+				BinaryDestination: consts.Executables.Relayer,
+			},
+		},
+	}
+
+	return dependencies.InstallBinaryFromRelease(rlyDep)
+}
+
+// applyPolicy writes the gas settings to the rly config and schedules the flush cron
+// job when the relayer services are loaded
+func applyPolicy(relayerHome string, policy roller.RelayerPolicy) error {
+	skipped, err := relayer.ApplyPolicy(relayer.GetConfigFilePath(relayerHome), policy)
+	if err != nil {
+		return err
+	}
+	if len(skipped) > 0 {
+		pterm.Warning.Printfln(
+			"relayer policy chains not found in the relayer config: %s",
+			strings.Join(skipped, ", "),
+		)
+	}
+
+	if !filesystem.ServiceFilesExist([]string{"relayer"}) {
+		return nil
+	}
+
+	return relayer.ScheduleFlush(relayerHome, policy)
+}
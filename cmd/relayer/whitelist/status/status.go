@@ -0,0 +1,105 @@
+package status
+
+import (
+	"slices"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/relayer"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/roller"
+	sequencerutils "github.com/dymensionxyz/roller/utils/sequencer"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show the whitelisted relayers on the hub and on the RollApp",
+		Long: `Show the whitelisted relayers on the hub and on the RollApp.
+
+The whitelisted relayers are updated on the hub and propagate to the RollApp,
+the command reports the relayers that did not propagate yet.
+
+The command has to be executed on the sequencer node of the RollApp.
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			home, err := filesystem.ExpandHomePath(
+				cmd.Flag(initconfig.GlobalFlagNames.Home).Value.String(),
+			)
+			if err != nil {
+				pterm.Error.Println("failed to expand home directory")
+				return
+			}
+
+			rollerData, err := roller.LoadConfig(home)
+			if err != nil {
+				pterm.Error.Println("failed to load roller config file", err)
+				return
+			}
+
+			seqAddr, err := sequencerutils.GetSequencerAccountAddress(rollerData)
+			if err != nil {
+				pterm.Error.Println("failed to retrieve sequencer address: ", err)
+				return
+			}
+			raOpAddr, err := sequencerutils.GetSequencerOperatorAddress(
+				home,
+				string(rollerData.KeyringBackend),
+			)
+			if err != nil {
+				pterm.Error.Println("failed to retrieve RollApp's operator address: ", err)
+				return
+			}
+
+			ws, err := relayer.GetWhitelistStatus(seqAddr, raOpAddr, rollerData.HubData)
+			if err != nil {
+				pterm.Error.Println(err)
+				return
+			}
+
+			data := pterm.TableData{{"Relayer", "Hub", "RollApp"}}
+			var all []string
+			all = append(all, ws.Hub...)
+			for _, a := range ws.Rollapp {
+				if !slices.Contains(all, a) {
+					all = append(all, a)
+				}
+			}
+			for _, a := range all {
+				data = append(data, []string{
+					a,
+					mark(slices.Contains(ws.Hub, a)),
+					mark(slices.Contains(ws.Rollapp, a)),
+				})
+			}
+
+			if len(all) == 0 {
+				pterm.Info.Println("no relayers are whitelisted")
+				return
+			}
+			_ = pterm.DefaultTable.WithHasHeader().WithData(data).Render()
+
+			if ws.InSync() {
+				pterm.Success.Println("the RollApp whitelist matches the hub whitelist")
+				return
+			}
+			for _, a := range ws.MissingOnRollapp {
+				pterm.Warning.Printfln("%s is whitelisted on the hub but didn't propagate to the RollApp yet", a)
+			}
+			for _, a := range ws.ExtraOnRollapp {
+				pterm.Warning.Printfln("%s was removed on the hub but is still whitelisted on the RollApp", a)
+			}
+		},
+	}
+
+	return cmd
+}
+
+func mark(ok bool) string {
+	if ok {
+		return pterm.Green("✔")
+	}
+	return pterm.Red("✘")
+}
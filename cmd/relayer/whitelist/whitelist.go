@@ -0,0 +1,18 @@
+package whitelist
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/dymensionxyz/roller/cmd/relayer/whitelist/status"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "whitelist",
+		Short: "Commands to inspect the relayers whitelisted by the sequencer",
+	}
+
+	cmd.AddCommand(status.Cmd())
+
+	return cmd
+}
@@ -114,10 +114,20 @@ Consider using 'services' if you want to run a 'systemd'(unix) or 'launchd'(mac)
 			startRollappCmd := seq.GetStartCmd(logLevel, rollappConfig.KeyringBackend)
 			fmt.Println(startRollappCmd.String())
 
-			rollerLogger := logging.GetRollerLogger(rollappConfig.Home)
-
-			if rollappConfig.HubData.ID != "mock" && rollappConfig.HealthAgent.Enabled {
-				go healthagent.Start(home, rollerLogger)
+			// the health agent runs in the health-agent service when it's loaded, it runs
+			// alongside the rollapp otherwise and serves the same status API
+			if rollappConfig.HubData.ID != "mock" && rollappConfig.HealthAgent.Enabled &&
+				!filesystem.ServiceFilesExist([]string{"health-agent"}) {
+				rollerLogger := logging.GetRollerLogger(rollappConfig.Home)
+				agent := healthagent.NewAgent(home, rollerLogger)
+				go agent.Run()
+				go func() {
+					addr := healthagent.ListenAddress(rollappConfig.HealthAgent)
+					err := agent.Serve(addr)
+					if err != nil {
+						rollerLogger.Printf("health agent status API failed: %v", err)
+					}
+				}()
 			}
 
 			done := make(chan error, 1)
@@ -2,13 +2,18 @@ package status
 
 import (
 	"fmt"
+	"strconv"
+	"time"
 
+	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
 
 	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
+	"github.com/dymensionxyz/roller/cmd/consts"
 	"github.com/dymensionxyz/roller/cmd/rollapp/start"
 	"github.com/dymensionxyz/roller/utils/dymint"
 	"github.com/dymensionxyz/roller/utils/healthagent"
+	"github.com/dymensionxyz/roller/utils/liveness"
 	"github.com/dymensionxyz/roller/utils/roller"
 )
 
@@ -35,11 +40,116 @@ func Cmd() *cobra.Command {
 				// TODO: use options pattern, this is ugly af
 				start.PrintOutput(rollerConfig, true, false, true, false, nodeID)
 				fmt.Println("Unhealthy Message: ", msg)
+				printHealthAgentStatus(rollerConfig)
+				printLiveness(rollerConfig)
 				return
 			}
 
 			start.PrintOutput(rollerConfig, true, true, true, true, nodeID)
+			printHealthAgentStatus(rollerConfig)
+			printLiveness(rollerConfig)
 		},
 	}
 	return cmd
 }
+
+// printHealthAgentStatus prints the latest checks and actions reported by the
+// health agent status API
+func printHealthAgentStatus(rollerConfig roller.RollappConfig) {
+	// number of checks and actions to display
+	const n = 5
+
+	pterm.DefaultSection.WithIndentCharacter("💈").
+		Println("Health Agent:")
+
+	s, err := healthagent.QueryStatus(healthagent.ListenAddress(rollerConfig.HealthAgent))
+	if err != nil {
+		pterm.Warning.Printf(
+			"health agent is not running, run %s to start it\n",
+			pterm.DefaultBasicText.WithStyle(pterm.FgYellow.ToStyle()).
+				Sprintf("roller rollapp services start health-agent"),
+		)
+		return
+	}
+
+	fmt.Println("Healthy:", s.Healthy)
+	fmt.Println("Current DA Node:", s.CurrentDaNode)
+	fmt.Println("Remediation Enabled:", s.RemediationEnabled)
+	if !s.LastCheckAt.IsZero() {
+		fmt.Println("Last Check:", s.LastCheckAt.Local().Format(time.RFC3339))
+	}
+
+	if len(s.Checks) > 0 {
+		data := pterm.TableData{
+			{"Time", "DA Node", "DA Node Healthy", "Failed DA Submissions", "Healthy"},
+		}
+		for _, c := range s.Checks[max(0, len(s.Checks)-n):] {
+			data = append(data, []string{
+				c.Time.Local().Format(time.RFC3339),
+				c.DaNode,
+				strconv.FormatBool(c.DaNodeHealthy),
+				strconv.Itoa(c.FailedDaSubmissions),
+				strconv.FormatBool(c.Healthy),
+			})
+		}
+		pterm.Println()
+		// nolint: errcheck
+		pterm.DefaultTable.WithHasHeader().WithData(data).Render()
+	}
+
+	if len(s.Actions) > 0 {
+		data := pterm.TableData{{"Time", "Action", "Details", "Error"}}
+		for _, a := range s.Actions[max(0, len(s.Actions)-n):] {
+			data = append(data, []string{
+				a.Time.Local().Format(time.RFC3339),
+				a.Action,
+				a.Details,
+				a.Error,
+			})
+		}
+		pterm.Println()
+		// nolint: errcheck
+		pterm.DefaultTable.WithHasHeader().WithData(data).Render()
+	}
+}
+
+// printLiveness prints the hub's view of the rollapp, the report of the health
+// agent is used when available
+func printLiveness(rollerConfig roller.RollappConfig) {
+	if rollerConfig.HubData.ID == consts.MockHubID || rollerConfig.NodeType != "sequencer" {
+		return
+	}
+
+	pterm.DefaultSection.WithIndentCharacter("💈").
+		Println("Hub Liveness:")
+
+	var r *liveness.Report
+	s, err := healthagent.QueryStatus(healthagent.ListenAddress(rollerConfig.HealthAgent))
+	if err == nil && s.Liveness != nil {
+		r = s.Liveness
+	} else {
+		r, err = liveness.Check(rollerConfig)
+		if err != nil {
+			pterm.Error.Println("failed to check hub liveness: ", err)
+			return
+		}
+	}
+
+	fmt.Println("Proposer:", r.Proposer)
+	fmt.Println("Is Proposer:", r.IsProposer)
+	fmt.Printf("Rollapp Height (local/hub): %d/%d\n", r.LocalRollappHeight, r.HubRollappHeight)
+	fmt.Println("Last State Update Height:", r.LastStateUpdateHeight)
+	if !r.LastStateUpdateTime.IsZero() {
+		fmt.Println(
+			"Time Since Last State Update:",
+			r.Time.Sub(r.LastStateUpdateTime).Round(time.Second),
+		)
+	}
+	if r.BlocksUntilSlash >= 0 {
+		fmt.Println("Hub Blocks Until Liveness Slash:", r.BlocksUntilSlash)
+	}
+
+	for _, w := range r.Warnings {
+		pterm.Warning.Println(w)
+	}
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"os"
 
+	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
 
 	blockexplorer "github.com/dymensionxyz/roller/cmd/block-explorer"
@@ -10,11 +11,13 @@ import (
 	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
 	da_light_client "github.com/dymensionxyz/roller/cmd/da-light-client"
 	"github.com/dymensionxyz/roller/cmd/eibc"
+	health_agent "github.com/dymensionxyz/roller/cmd/health-agent"
 	"github.com/dymensionxyz/roller/cmd/observability"
 	"github.com/dymensionxyz/roller/cmd/relayer"
 	"github.com/dymensionxyz/roller/cmd/rollapp"
 	"github.com/dymensionxyz/roller/cmd/rollapp/keys"
 	"github.com/dymensionxyz/roller/cmd/version"
+	"github.com/dymensionxyz/roller/utils/tracing"
 )
 
 var rootCmd = &cobra.Command{
@@ -23,17 +26,30 @@ var rootCmd = &cobra.Command{
 	Long: `
 Roller CLI is a tool for registering and running autonomous RollApps built with Dymension RDK. Roller provides everything you need to scaffold, configure, register, and run your RollApp.
 	`,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		err := tracing.Init(initconfig.GetTracingConfig(cmd), cmd.CommandPath())
+		if err != nil {
+			pterm.Warning.Printfln("failed to initialize tracing: %v", err)
+		}
+	},
 }
 
 func Execute() {
 	err := rootCmd.Execute()
+	// nolint errcheck
+	tracing.Shutdown()
 	if err != nil {
 		os.Exit(1)
 	}
 }
 
 func init() {
+	// the tracing hook of the root command still runs when a subcommand defines its own
+	// persistent pre run
+	cobra.EnableTraverseRunHooks = true
+
 	rootCmd.AddCommand(da_light_client.DALightClientCmd())
+	rootCmd.AddCommand(health_agent.Cmd())
 	rootCmd.AddCommand(relayer.Cmd())
 	rootCmd.AddCommand(keys.Cmd())
 	rootCmd.AddCommand(observability.Cmd())
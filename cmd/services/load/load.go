@@ -16,8 +16,8 @@ import (
 	initconfig "github.com/dymensionxyz/roller/cmd/config/init"
 	"github.com/dymensionxyz/roller/cmd/consts"
 	datalayer "github.com/dymensionxyz/roller/data_layer"
+	"github.com/dymensionxyz/roller/relayer"
 	"github.com/dymensionxyz/roller/utils/bash"
-	"github.com/dymensionxyz/roller/utils/config/cronjobs"
 	"github.com/dymensionxyz/roller/utils/config/scripts"
 	"github.com/dymensionxyz/roller/utils/errorhandling"
 	"github.com/dymensionxyz/roller/utils/filesystem"
@@ -68,16 +68,26 @@ func Cmd(services []string, module string) *cobra.Command {
 			}
 
 			if module == "relayer" {
-				schedule := "*/15 * * * *" // Run every hour
-				command := fmt.Sprintf(
-					"%s tx flush hub-rollapp --max-msgs 100 --home %s",
-					consts.Executables.Relayer,
+				err := relayer.ValidatePolicy(rollerData.Relayer)
+				if err != nil {
+					pterm.Error.Println("invalid relayer policy: ", err)
+					return
+				}
+
+				if relayer.MinPacketValueIgnored(rollerData.Relayer) {
+					pterm.Warning.Println(
+						"the minimal packet value of the relayer policy is only enforced by the native engine",
+					)
+				}
+
+				// flushes all the relayer paths, the previous entry is replaced so that
+				// loading the services again doesn't schedule the flush twice
+				err = relayer.ScheduleFlush(
 					filepath.Join(rollerData.Home, consts.ConfigDirName.Relayer),
+					rollerData.Relayer,
 				)
-
-				err := cronjobs.Add(schedule, command)
 				if err != nil {
-					pterm.Error.Println("failed to add flush cronjob", err)
+					pterm.Error.Println("failed to schedule relayer flush", err)
 					return
 				}
 			}
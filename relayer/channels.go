@@ -46,8 +46,6 @@ func (r *Relayer) LoadActiveChannel(
 		},
 	)
 
-	fmt.Println(raIbcChanIndex)
-
 	if raIbcChanIndex == -1 {
 		return ErrNoOpenChannel
 	}
@@ -342,11 +340,6 @@ func (r *Relayer) HandleIbcChannelCreation(
 	)
 
 	pterm.Info.Println(status)
-	err = r.WriteRelayerStatus(status)
-	if err != nil {
-		fmt.Println(err)
-		return err
-	}
 
 	return nil
 }
@@ -3,10 +3,21 @@ package relayer
 import (
 	"os/exec"
 	"path/filepath"
+	"strconv"
 
 	"github.com/dymensionxyz/roller/cmd/consts"
 )
 
+// Engines are the supported relaying engines, rly runs the relayer binary while native
+// relays the packets from within roller
+var Engines = struct {
+	Rly    string
+	Native string
+}{
+	Rly:    "rly",
+	Native: "native",
+}
+
 func (r *Relayer) GetUpdateClientsCmd() *exec.Cmd {
 	args := []string{"tx", "update-clients"}
 	args = append(args, r.getRelayerDefaultArgs()...)
@@ -28,24 +39,35 @@ func (r *Relayer) GetRelayPacketsCmd() *exec.Cmd {
 // @20240319 the flags `--max-msgs` and `--flush-interval` improve the relayer performance
 // a better solution should be implemented as a part of https://github.com/dymensionxyz/roller/issues/769
 func (r *Relayer) GetStartCmd() *exec.Cmd {
-	args := []string{
-		"start",
+	return GetStartPathsCmd(r.RelayerHome, []string{r.Path}, DefaultMaxMsgs)
+}
+
+// GetStartPathsCmd returns the command that relays packets on all the provided paths
+// using a single relayer process, the paths are flushed by a cron job instead of rly
+func GetStartPathsCmd(relayerHome string, paths []string, maxMsgs int) *exec.Cmd {
+	args := []string{"start"}
+	args = append(args, paths...)
+	args = append(
+		args,
 		"--max-msgs",
-		"100",
+		strconv.Itoa(maxMsgs),
 		"--time-threshold",
 		"2h",
 		"--no-flush",
 		"--log-format",
 		"json",
-	}
-	args = append(args, r.getRelayerDefaultArgs()...)
+		"--home",
+		relayerHome,
+	)
 	return exec.Command(consts.Executables.Relayer, args...)
 }
 
+// getArgsWithSrcChannel returns the path arguments followed by the channel on the
+// source chain of the path, which is the hub
 func (r *Relayer) getArgsWithSrcChannel() []string {
 	return []string{
-		consts.DefaultRelayerPath,
-		r.DstChannel,
+		r.Path,
+		r.SrcChannel,
 		"--home",
 		filepath.Join(r.RollerHome, consts.ConfigDirName.Relayer),
 	}
@@ -6,12 +6,15 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/pterm/pterm"
 	yaml "gopkg.in/yaml.v3"
 
 	"github.com/dymensionxyz/roller/cmd/consts"
 	"github.com/dymensionxyz/roller/utils"
+	"github.com/dymensionxyz/roller/utils/bash"
 	"github.com/dymensionxyz/roller/utils/config/yamlconfig"
 	"github.com/dymensionxyz/roller/utils/roller"
 )
@@ -47,30 +50,27 @@ type RelayerChainConfig struct {
 // configuration file
 type Config struct {
 	Chains map[string]RelayerFileChainConfig `yaml:"chains"`
-	Paths  *Paths                            `yaml:"paths"`
-}
-
-type Paths struct {
-	HubRollapp *struct {
-		Dst *struct {
-			ChainID      string `yaml:"chain-id"`
-			ClientID     string `yaml:"client-id"`
-			ConnectionID string `yaml:"connection-id"`
-		} `yaml:"dst"`
-		Src *struct {
-			ChainID      string `yaml:"chain-id"`
-			ClientID     string `yaml:"client-id"`
-			ConnectionID string `yaml:"connection-id"`
-		} `yaml:"src"`
-		SrcChannelFilter *struct {
-			ChannelList []string `yaml:"channel-list"`
-			Rule        string   `yaml:"rule"`
-		} `yaml:"src-channel-filter"`
-	} `yaml:"hub-rollapp"`
+	Paths  map[string]*Path                  `yaml:"paths"`
+}
+
+// Path represents a single relayer path, the source is always the hub and
+// the destination is the rollapp
+type Path struct {
+	Dst              *PathEnd `yaml:"dst"`
+	Src              *PathEnd `yaml:"src"`
+	SrcChannelFilter *struct {
+		ChannelList []string `yaml:"channel-list"`
+		Rule        string   `yaml:"rule"`
+	} `yaml:"src-channel-filter"`
+}
+
+type PathEnd struct {
+	ChainID      string `yaml:"chain-id"`
+	ClientID     string `yaml:"client-id"`
+	ConnectionID string `yaml:"connection-id"`
 }
 
 func (c *Config) Load(rlyConfigPath string) error {
-	fmt.Println("loading config from", rlyConfigPath)
 	data, err := os.ReadFile(rlyConfigPath)
 	if err != nil {
 		return err
@@ -84,52 +84,90 @@ func (c *Config) Load(rlyConfigPath string) error {
 	return nil
 }
 
-func (c *Config) GetPath() *Paths {
-	if c.Paths == nil || c.Paths.HubRollapp == nil {
+// GetPath returns the default relayer path, nil when it doesn't exist
+func (c *Config) GetPath() *Path {
+	return c.GetPathByName(consts.DefaultRelayerPath)
+}
+
+// GetPathByName returns the relayer path with the given name, nil when it doesn't exist
+func (c *Config) GetPathByName(name string) *Path {
+	p, ok := c.Paths[name]
+	if !ok || p == nil || p.Src == nil || p.Dst == nil {
 		return nil
 	}
 
-	return c.Paths
+	return p
+}
+
+// PathNames returns the sorted names of the relayer paths
+func (c *Config) PathNames() []string {
+	var names []string
+	for name := range c.Paths {
+		if c.GetPathByName(name) != nil {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	return names
 }
 
 func (c *Config) CreatePath(rlpCfg roller.RollappConfig) error {
 	relayerHome := filepath.Join(rlpCfg.Home, consts.ConfigDirName.Relayer)
-	pterm.Info.Printf("creating new ibc path from %s to %s\n", rlpCfg.HubData.ID, rlpCfg.RollappID)
+	return CreateNamedPath(relayerHome, rlpCfg.HubData.ID, rlpCfg.RollappID, consts.DefaultRelayerPath)
+}
+
+// CreateNamedPath creates a new relayer path between the hub and the rollapp
+func CreateNamedPath(relayerHome, hubID, raID, name string) error {
+	pterm.Info.Printf("creating new ibc path %s from %s to %s\n", name, hubID, raID)
 
 	newPathCmd := exec.Command(
 		consts.Executables.Relayer,
 		"paths",
 		"new",
-		rlpCfg.HubData.ID,
-		rlpCfg.RollappID,
-		consts.DefaultRelayerPath,
+		hubID,
+		raID,
+		name,
 		"--home",
 		relayerHome,
 	)
-	if err := newPathCmd.Run(); err != nil {
-		return err
-	}
-
-	return nil
+	_, err := bash.ExecCommandWithStdout(newPathCmd)
+	return err
 }
 
 func DeletePath(rlpCfg roller.RollappConfig) error {
 	relayerHome := filepath.Join(rlpCfg.Home, consts.ConfigDirName.Relayer)
 	pterm.Info.Printf("removing ibc path from %s to %s\n", rlpCfg.HubData.ID, rlpCfg.RollappID)
 
-	newPathCmd := exec.Command(
+	return DeleteNamedPath(relayerHome, consts.DefaultRelayerPath)
+}
+
+// DeleteNamedPath removes the relayer path with the given name
+func DeleteNamedPath(relayerHome, name string) error {
+	deletePathCmd := exec.Command(
 		consts.Executables.Relayer,
 		"paths",
 		"delete",
-		consts.DefaultRelayerPath,
+		name,
 		"--home",
 		relayerHome,
 	)
-	if err := newPathCmd.Run(); err != nil {
-		return err
-	}
+	_, err := bash.ExecCommandWithStdout(deletePathCmd)
+	return err
+}
 
-	return nil
+// DeleteChain removes the chain and its configuration from the relayer
+func DeleteChain(relayerHome, chainID string) error {
+	deleteChainCmd := exec.Command(
+		consts.Executables.Relayer,
+		"chains",
+		"delete",
+		chainID,
+		"--home",
+		relayerHome,
+	)
+	_, err := bash.ExecCommandWithStdout(deleteChainCmd)
+	return err
 }
 
 type ChainConfig struct {
@@ -228,6 +266,19 @@ func getRelayerFileChainConfig(relayerChainConfig RelayerChainConfig) RelayerFil
 	}
 }
 
+// AddRollappChain adds the rollapp chain to an existing relayer configuration
+func AddRollappChain(rollappConfig ChainConfig, relayerHome string) error {
+	relayerRollappConfig := getRelayerFileChainConfig(
+		RelayerChainConfig{
+			ChainConfig: rollappConfig,
+			GasPrices:   rollappConfig.GasPrices + rollappConfig.Denom,
+			KeyName:     consts.KeysIds.RollappRelayer,
+		},
+	)
+
+	return addChainToRelayer(relayerRollappConfig, relayerHome)
+}
+
 func addChainToRelayer(fileChainConfig RelayerFileChainConfig, relayerHome string) error {
 	chainFilePath, err := writeTmpChainConfig(fileChainConfig, "chain.json")
 	if err != nil {
@@ -308,42 +359,92 @@ func InitializeConfig(
 }
 
 func (c *Config) HubDataFromRelayerConfig() *consts.HubData {
+	return c.HubDataForPath(consts.DefaultRelayerPath)
+}
+
+func (c *Config) RaDataFromRelayerConfig() *consts.RollappData {
+	return c.RaDataForPath(consts.DefaultRelayerPath)
+}
+
+// HubDataForPath returns the hub data of the path, nil when the path doesn't exist
+func (c *Config) HubDataForPath(name string) *consts.HubData {
+	p := c.GetPathByName(name)
+	if p == nil {
+		return nil
+	}
+
 	hd := consts.HubData{
-		ID:     c.Paths.HubRollapp.Src.ChainID,
-		RpcUrl: c.Chains[c.Paths.HubRollapp.Src.ChainID].Value.RpcAddr,
-		ApiUrl: c.Chains[c.Paths.HubRollapp.Src.ChainID].Value.ApiAddr,
+		ID:     p.Src.ChainID,
+		RpcUrl: c.Chains[p.Src.ChainID].Value.RpcAddr,
+		ApiUrl: c.Chains[p.Src.ChainID].Value.ApiAddr,
 	}
 
 	return &hd
 }
 
-func (c *Config) RaDataFromRelayerConfig() *consts.RollappData {
+// RaDataForPath returns the rollapp data of the path, nil when the path doesn't exist
+func (c *Config) RaDataForPath(name string) *consts.RollappData {
+	p := c.GetPathByName(name)
+	if p == nil {
+		return nil
+	}
+
 	raData := consts.RollappData{
-		ID:     c.Paths.HubRollapp.Dst.ChainID,
-		RpcUrl: c.Chains[c.Paths.HubRollapp.Dst.ChainID].Value.RpcAddr,
+		ID:     p.Dst.ChainID,
+		RpcUrl: c.Chains[p.Dst.ChainID].Value.RpcAddr,
 	}
 
 	return &raData
 }
 
 func (r *Relayer) UpdateConfigWithDefaultValues(rollerData roller.RollappConfig) error {
+	err := r.UpdateRollappChainWithDefaultValues(rollerData.RollappID)
+	if err != nil {
+		return err
+	}
+
 	updates := map[string]interface{}{
 		fmt.Sprintf("chains.%s.value.gas-adjustment", rollerData.HubData.ID): 1.5,
 		fmt.Sprintf("chains.%s.value.gas-prices", rollerData.HubData.ID): fmt.Sprintf(
 			"20000000000%s",
 			consts.Denoms.Hub,
 		),
-		fmt.Sprintf("chains.%s.value.gas-adjustment", rollerData.RollappID): 1.3,
-		fmt.Sprintf("chains.%s.value.is-dym-hub", rollerData.HubData.ID):    true,
+		fmt.Sprintf("chains.%s.value.is-dym-hub", rollerData.HubData.ID): true,
 		fmt.Sprintf(
 			"chains.%s.value.http-addr",
 			rollerData.HubData.ID,
 		): rollerData.HubData.ApiUrl,
-		fmt.Sprintf("chains.%s.value.is-dym-rollapp", rollerData.RollappID): true,
 		"extra-codecs": []string{
 			"ethermint",
 		},
 	}
+	err = yamlconfig.UpdateNestedYAML(r.ConfigFilePath, updates)
+	if err != nil {
+		pterm.Error.Printf("Error updating YAML: %v\n", err)
+		return err
+	}
+
+	// the relayer policy of roller.toml overrides the defaults
+	skipped, err := ApplyPolicy(r.ConfigFilePath, rollerData.Relayer)
+	if err != nil {
+		return fmt.Errorf("failed to apply relayer policy: %w", err)
+	}
+	if len(skipped) > 0 {
+		pterm.Warning.Printfln(
+			"relayer policy chains not found in the relayer config: %s",
+			strings.Join(skipped, ", "),
+		)
+	}
+
+	return nil
+}
+
+// UpdateRollappChainWithDefaultValues sets the default relayer values for the rollapp chain
+func (r *Relayer) UpdateRollappChainWithDefaultValues(raID string) error {
+	updates := map[string]interface{}{
+		fmt.Sprintf("chains.%s.value.gas-adjustment", raID): 1.3,
+		fmt.Sprintf("chains.%s.value.is-dym-rollapp", raID): true,
+	}
 	err := yamlconfig.UpdateNestedYAML(r.ConfigFilePath, updates)
 	if err != nil {
 		pterm.Error.Printf("Error updating YAML: %v\n", err)
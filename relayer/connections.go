@@ -10,12 +10,15 @@ import (
 	"github.com/dymensionxyz/roller/cmd/consts"
 	"github.com/dymensionxyz/roller/utils/bash"
 	"github.com/dymensionxyz/roller/utils/config/yamlconfig"
+	"github.com/dymensionxyz/roller/utils/tracing"
 )
 
 func (r *Relayer) HubIbcConnections(hd consts.HubData) (*ConnectionsQueryResult, error) {
 	cmd := r.queryConnectionHubCmd(hd)
 
-	hubIbcConnectionsOut, err := bash.ExecCommandWithStdout(cmd)
+	ctx, span := tracing.StartHubQuery("relayer.HubIbcConnections", hd.ID, hd.RpcUrl)
+	hubIbcConnectionsOut, err := bash.ExecCommandWithStdoutCtx(ctx, cmd)
+	tracing.End(span, err)
 	if err != nil {
 		return nil, err
 	}
@@ -51,15 +54,16 @@ func (r *Relayer) RaIbcConnections(
 	return &raIbcConnections, nil
 }
 
-func (r *Relayer) UpdateDefaultPath() error {
+// UpdatePath writes the client and connection IDs of the relayer to its path
+func (r *Relayer) UpdatePath() error {
 	updates := map[string]interface{}{
 		// hub
-		fmt.Sprintf("paths.%s.src.client-id", consts.DefaultRelayerPath):     r.SrcClientID,
-		fmt.Sprintf("paths.%s.src.connection-id", consts.DefaultRelayerPath): r.SrcConnectionID,
+		fmt.Sprintf("paths.%s.src.client-id", r.Path):     r.SrcClientID,
+		fmt.Sprintf("paths.%s.src.connection-id", r.Path): r.SrcConnectionID,
 
 		// ra
-		fmt.Sprintf("paths.%s.dst.client-id", consts.DefaultRelayerPath):     r.DstClientID,
-		fmt.Sprintf("paths.%s.dst.connection-id", consts.DefaultRelayerPath): r.DstConnectionID,
+		fmt.Sprintf("paths.%s.dst.client-id", r.Path):     r.DstClientID,
+		fmt.Sprintf("paths.%s.dst.connection-id", r.Path): r.DstConnectionID,
 	}
 	err := yamlconfig.UpdateNestedYAML(r.ConfigFilePath, updates)
 	if err != nil {
@@ -140,6 +144,9 @@ func (r *Relayer) GetActiveConnectionIDs(
 			return conn.ID == hubConnectionID
 		},
 	)
+	if hubConnIndex == -1 {
+		return "", "", nil
+	}
 
 	hubConnection := hubIbcConnections.Connections[hubConnIndex]
 
@@ -194,6 +201,10 @@ func (r *Relayer) GetActiveConnections(raData consts.RollappData, hd consts.HubD
 			return conn.ID == hubConnectionID
 		},
 	)
+	if hubConnIndex == -1 {
+		r.logger.Printf("connection %s not found on the hub", hubConnectionID)
+		return nil, nil, nil
+	}
 
 	hubConnection := hubIbcConnections.Connections[hubConnIndex]
 
@@ -14,6 +14,7 @@ import (
 	"github.com/dymensionxyz/roller/sequencer"
 	"github.com/dymensionxyz/roller/utils/bash"
 	"github.com/dymensionxyz/roller/utils/logging"
+	"github.com/dymensionxyz/roller/utils/tracing"
 )
 
 // CreateIBCChannel Creates an IBC channel between the hub and the client,
@@ -25,7 +26,6 @@ func (r *Relayer) CreateIBCChannel(
 ) (ConnectionChannels, error) {
 	// ctx, cancel := context.WithCancel(context.Background())
 	// defer cancel()
-	var status string
 
 	// TODO: this is probably not true anymore, review and remove the sleep if necessary
 	// Sleep for a few seconds to make sure the clients are created
@@ -39,11 +39,8 @@ func (r *Relayer) CreateIBCChannel(
 
 	if connectionID == "" {
 		pterm.Info.Println("💈 Creating connection...")
-		if err := r.WriteRelayerStatus(status); err != nil {
-			return ConnectionChannels{}, err
-		}
 
-		sp, err := getHubStakingParams(r.Hub)
+		sp, err := GetHubStakingParams(r.Hub)
 		if err != nil {
 			return ConnectionChannels{}, err
 		}
@@ -60,17 +57,10 @@ func (r *Relayer) CreateIBCChannel(
 	createChannelCmd := r.getCreateChannelCmd(true)
 
 	pterm.Info.Println("💈 Creating channel (this may take a while)...")
-	if err := r.WriteRelayerStatus(status); err != nil {
-		return ConnectionChannels{}, err
-	}
 	if err := bash.ExecCmd(createChannelCmd, logFileOption); err != nil {
 		return ConnectionChannels{}, err
 	}
-	status = ""
 	pterm.Info.Println("💈 Validating channel established...")
-	if err := r.WriteRelayerStatus(status); err != nil {
-		return ConnectionChannels{}, err
-	}
 
 	err = r.LoadActiveChannel(raData, hd)
 	if err != nil {
@@ -80,10 +70,6 @@ func (r *Relayer) CreateIBCChannel(
 		return ConnectionChannels{}, fmt.Errorf("could not load channels")
 	}
 
-	if err := r.WriteRelayerStatus(status); err != nil {
-		return ConnectionChannels{}, err
-	}
-
 	return ConnectionChannels{
 		Src: r.SrcChannel,
 		Dst: r.DstChannel,
@@ -139,7 +125,9 @@ type StakingParamsResponse struct {
 	UnbondingTime     string `json:"unbonding_time"`
 }
 
-func getHubStakingParams(hd consts.HubData) (*StakingParamsResponse, error) {
+// GetHubStakingParams returns the staking params of the hub, the unbonding time bounds
+// the trusting period of the clients
+func GetHubStakingParams(hd consts.HubData) (*StakingParamsResponse, error) {
 	cmd := exec.Command(
 		consts.Executables.Dymension,
 		"q",
@@ -153,7 +141,9 @@ func getHubStakingParams(hd consts.HubData) (*StakingParamsResponse, error) {
 		"json",
 	)
 
-	out, err := bash.ExecCommandWithStdout(cmd)
+	ctx, span := tracing.StartHubQuery("relayer.GetHubStakingParams", hd.ID, hd.RpcUrl)
+	out, err := bash.ExecCommandWithStdoutCtx(ctx, cmd)
+	tracing.End(span, err)
 	if err != nil {
 		return nil, err
 	}
@@ -204,7 +194,7 @@ func (r *Relayer) getCreateChannelCmd(override bool) *exec.Cmd {
 
 func (r *Relayer) getRelayerDefaultArgs() []string {
 	return []string{
-		consts.DefaultRelayerPath,
+		r.Path,
 		"--home",
 		filepath.Join(r.RollerHome, consts.ConfigDirName.Relayer),
 	}
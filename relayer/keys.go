@@ -8,7 +8,8 @@ import (
 	"github.com/dymensionxyz/roller/utils/roller"
 )
 
-var oneDayRelayPrice, _ = cosmossdkmath.NewIntFromString(
+// OneDayRelayPrice is the hub balance needed to relay for about a day
+var OneDayRelayPrice, _ = cosmossdkmath.NewIntFromString(
 	"2000000000000000000",
 ) // 2000000000000000000 = 2dym
 
@@ -48,7 +49,7 @@ func getRelayerInsufficientBalances(
 					KeyName:         consts.KeysIds.HubRelayer,
 					Address:         acc.Address,
 					CurrentBalance:  acc.Balance.Amount.BigInt(),
-					RequiredBalance: oneDayRelayPrice.BigInt(),
+					RequiredBalance: OneDayRelayPrice.BigInt(),
 					Denom:           consts.Denoms.Hub,
 					Network:         hd.ID,
 				},
@@ -102,3 +103,40 @@ func getHubRlyAccData(home string, hd consts.HubData) (*keys.AccountData, error)
 		Balance: *HubRlyBalance,
 	}, nil
 }
+
+// GetPathAccountsData returns the hub and rollapp relayer accounts of a relayer path,
+// the rollapp denom is required to query the balance of the rollapp account
+func GetPathAccountsData(
+	home string,
+	hd consts.HubData,
+	raData consts.RollappData,
+) ([]keys.AccountData, error) {
+	hubRlyAcc, err := getHubRlyAccData(home, hd)
+	if err != nil {
+		return nil, err
+	}
+
+	raRlyAddr, err := keys.GetRelayerAddress(home, raData.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	raRlyBalance, err := keys.QueryBalance(
+		keys.ChainQueryConfig{
+			RPC:    raData.RpcUrl,
+			Denom:  raData.Denom,
+			Binary: consts.Executables.RollappEVM,
+		}, raRlyAddr,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return []keys.AccountData{
+		*hubRlyAcc,
+		{
+			Address: raRlyAddr,
+			Balance: *raRlyBalance,
+		},
+	}, nil
+}
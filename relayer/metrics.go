@@ -0,0 +1,442 @@
+package relayer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ChannelMetrics are the relaying metrics of a channel derived from the rly logs or from
+// the native engine transactions, the chain is the one the transactions were sent to
+type ChannelMetrics struct {
+	ChainID string `json:"chain_id"`
+	// Channel is the channel on ChainID, it's empty for transactions that don't relay
+	// packets (e.g. client updates)
+	Channel   string    `json:"channel"`
+	Packets   uint64    `json:"packets"`
+	Acks      uint64    `json:"acks"`
+	Timeouts  uint64    `json:"timeouts"`
+	Txs       uint64    `json:"txs"`
+	FailedTxs uint64    `json:"failed_txs"`
+	GasUsed   uint64    `json:"gas_used"`
+	Fees      sdk.Coins `json:"fees"`
+	// LatencySamples and LatencySum (in seconds) measure the time between the send of a
+	// packet on the counterparty and its receive on ChainID
+	LatencySamples uint64    `json:"latency_samples"`
+	LatencySum     float64   `json:"latency_sum"`
+	LastRelay      time.Time `json:"last_relay"`
+	LastError      string    `json:"last_error,omitempty"`
+}
+
+// AvgLatency returns the average packet latency, zero when it wasn't measured
+func (m ChannelMetrics) AvgLatency() time.Duration {
+	if m.LatencySamples == 0 {
+		return 0
+	}
+	return time.Duration(m.LatencySum / float64(m.LatencySamples) * float64(time.Second))
+}
+
+// rlyLogLine is the subset of the rly json log fields used by the metrics
+type rlyLogLine struct {
+	Ts         json.RawMessage `json:"ts"`
+	Msg        string          `json:"msg"`
+	ChainID    string          `json:"chain_id"`
+	GasUsed    int64           `json:"gas_used"`
+	Fees       string          `json:"fees"`
+	TxHash     string          `json:"tx_hash"`
+	Error      string          `json:"error"`
+	MsgTypes   json.RawMessage `json:"msg_types"`
+	SrcChannel string          `json:"packet_src_channel"`
+	DstChannel string          `json:"packet_dst_channel"`
+	Channels   json.RawMessage `json:"channels"`
+}
+
+type rlyLogChannel struct {
+	SrcChannel string `json:"packet_src_channel"`
+	DstChannel string `json:"packet_dst_channel"`
+}
+
+var failedTxMsgs = []string{
+	"Failed sending cosmos transaction",
+	"Error building or broadcasting transaction",
+}
+
+// PacketMetrics aggregates the relaying metrics of the rly json logs or of the native
+// engine transactions
+type PacketMetrics struct {
+	mu       sync.Mutex
+	channels map[string]*ChannelMetrics
+	// clients are the last statuses of the light clients reported by the watchdog
+	clients map[string]ClientStatus
+
+	// rlyCfg is used to measure the packet latencies, they are not measured when nil
+	rlyCfg *Config
+	// started avoids querying the latencies of the transactions logged before
+	started time.Time
+	logger  *log.Logger
+}
+
+// NewPacketMetrics creates the metrics of the relayer, the packet latencies are queried
+// from the chains of the relayer config when it is provided
+func NewPacketMetrics(rlyCfg *Config, logger *log.Logger) *PacketMetrics {
+	return &PacketMetrics{
+		channels: map[string]*ChannelMetrics{},
+		clients:  map[string]ClientStatus{},
+		rlyCfg:   rlyCfg,
+		started:  time.Now(),
+		logger:   logger,
+	}
+}
+
+// ParseLogFile returns the metrics of all the rly json logs in the file, the packet
+// latencies are not measured
+func ParseLogFile(logPath string) ([]ChannelMetrics, error) {
+	f, err := os.Open(logPath)
+	if err != nil {
+		return nil, err
+	}
+	// nolint: errcheck
+	defer f.Close()
+
+	pm := NewPacketMetrics(nil, log.New(os.Stderr, "", 0))
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		pm.ProcessLine(scanner.Bytes())
+	}
+
+	return pm.Snapshot(), scanner.Err()
+}
+
+// ProcessLine updates the metrics with a line of the relayer log, the lines that are
+// not rly json transaction logs are ignored
+func (pm *PacketMetrics) ProcessLine(line []byte) {
+	line = bytes.TrimSpace(line)
+	if len(line) == 0 || line[0] != '{' {
+		return
+	}
+
+	var l rlyLogLine
+	err := json.Unmarshal(line, &l)
+	if err != nil || l.ChainID == "" {
+		return
+	}
+
+	switch {
+	case l.Msg == "Successful transaction":
+		pm.recordSuccess(l)
+	case slices.Contains(failedTxMsgs, l.Msg):
+		pm.recordFailure(l)
+	}
+}
+
+// Snapshot returns the metrics of all the channels sorted by chain and channel
+func (pm *PacketMetrics) Snapshot() []ChannelMetrics {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	metrics := make([]ChannelMetrics, 0, len(pm.channels))
+	for _, m := range pm.channels {
+		metrics = append(metrics, *m)
+	}
+	slices.SortFunc(metrics, func(a, b ChannelMetrics) int {
+		if c := strings.Compare(a.ChainID, b.ChainID); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Channel, b.Channel)
+	})
+
+	return metrics
+}
+
+// RecordClient stores the last status of the light client
+func (pm *PacketMetrics) RecordClient(c ClientStatus) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	pm.clients[c.ChainID+"/"+c.ClientID] = c
+}
+
+// Clients returns the last statuses of the light clients sorted by chain and client
+func (pm *PacketMetrics) Clients() []ClientStatus {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	clients := make([]ClientStatus, 0, len(pm.clients))
+	for _, c := range pm.clients {
+		clients = append(clients, c)
+	}
+	slices.SortFunc(clients, func(a, b ClientStatus) int {
+		if c := strings.Compare(a.ChainID, b.ChainID); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ClientID, b.ClientID)
+	})
+
+	return clients
+}
+
+func (pm *PacketMetrics) recordSuccess(l rlyLogLine) {
+	packets, acks, timeouts := countMsgTypes(l.MsgTypes)
+	fees, _ := sdk.ParseCoinsNormalized(l.Fees)
+
+	pm.RecordTx(RelayedTx{
+		ChainID:  l.ChainID,
+		Channel:  l.channel(packets > 0),
+		TxHash:   l.TxHash,
+		Packets:  packets,
+		Acks:     acks,
+		Timeouts: timeouts,
+		GasUsed:  l.GasUsed,
+		Fees:     fees,
+		Time:     l.time(),
+	})
+}
+
+func (pm *PacketMetrics) recordFailure(l rlyLogLine) {
+	packets, _, _ := countMsgTypes(l.MsgTypes)
+
+	pm.RecordTx(RelayedTx{
+		ChainID: l.ChainID,
+		Channel: l.channel(packets > 0),
+		Err:     errors.New(l.Error),
+	})
+}
+
+// RelayedTx is a relayer transaction, either logged by rly or sent by the native engine
+type RelayedTx struct {
+	ChainID string
+	// Channel is the channel on ChainID, it's empty for client updates
+	Channel  string
+	TxHash   string
+	Packets  uint64
+	Acks     uint64
+	Timeouts uint64
+	GasUsed  int64
+	Fees     sdk.Coins
+	Time     time.Time
+	// Err is the reason the transaction failed
+	Err error
+}
+
+// RecordTx updates the metrics with a relayer transaction, the latency of the received
+// packets is measured for the transactions sent after the metrics were created
+func (pm *PacketMetrics) RecordTx(tx RelayedTx) {
+	if tx.Err != nil {
+		pm.mu.Lock()
+		defer pm.mu.Unlock()
+
+		m := pm.channel(tx.ChainID, tx.Channel)
+		m.FailedTxs++
+		m.LastError = tx.Err.Error()
+		return
+	}
+
+	var latency time.Duration
+	if tx.Packets > 0 && pm.rlyCfg != nil && tx.TxHash != "" && tx.Time.After(pm.started) {
+		var err error
+		latency, err = pm.packetLatency(tx.ChainID, tx.TxHash, tx.Time)
+		if err != nil {
+			pm.logger.Printf("failed to measure packet latency of %s: %v", tx.TxHash, err)
+		}
+	}
+
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	m := pm.channel(tx.ChainID, tx.Channel)
+	m.Txs++
+	m.Packets += tx.Packets
+	m.Acks += tx.Acks
+	m.Timeouts += tx.Timeouts
+	if tx.GasUsed > 0 {
+		m.GasUsed += uint64(tx.GasUsed)
+	}
+	m.Fees = m.Fees.Add(tx.Fees...)
+	if tx.Time.After(m.LastRelay) {
+		m.LastRelay = tx.Time
+	}
+	if latency > 0 {
+		m.LatencySamples++
+		m.LatencySum += latency.Seconds()
+	}
+}
+
+func (pm *PacketMetrics) channel(chainID, channel string) *ChannelMetrics {
+	key := chainID + "/" + channel
+	m, ok := pm.channels[key]
+	if !ok {
+		m = &ChannelMetrics{ChainID: chainID, Channel: channel}
+		pm.channels[key] = m
+	}
+	return m
+}
+
+// channel returns the channel of the chain the transaction was sent to, the chain is the
+// destination of the received packets and the source of the acknowledged ones
+func (l rlyLogLine) channel(receive bool) string {
+	src, dst := l.SrcChannel, l.DstChannel
+
+	// the format of the nested channels differs between rly versions
+	var channels []rlyLogChannel
+	// nolint: errcheck
+	json.Unmarshal(l.Channels, &channels)
+	for _, c := range channels {
+		if src == "" {
+			src = c.SrcChannel
+		}
+		if dst == "" {
+			dst = c.DstChannel
+		}
+	}
+
+	if receive {
+		return dst
+	}
+	return src
+}
+
+// time parses the timestamp of the log line, rly writes either a RFC3339 string or the
+// unix time in seconds
+func (l rlyLogLine) time() time.Time {
+	var s string
+	if json.Unmarshal(l.Ts, &s) == nil {
+		t, err := time.Parse(time.RFC3339Nano, s)
+		if err == nil {
+			return t
+		}
+	}
+
+	var f float64
+	if json.Unmarshal(l.Ts, &f) == nil && f > 0 {
+		return time.Unix(0, int64(f*float64(time.Second)))
+	}
+
+	return time.Now()
+}
+
+// countMsgTypes counts the packet, acknowledgement and timeout messages of the logged
+// transaction, rly logs the message types either as a list or as an indexed object
+func countMsgTypes(raw json.RawMessage) (packets, acks, timeouts uint64) {
+	var types []string
+	if json.Unmarshal(raw, &types) != nil {
+		var indexed map[string]string
+		if json.Unmarshal(raw, &indexed) != nil {
+			return 0, 0, 0
+		}
+		for _, t := range indexed {
+			types = append(types, t)
+		}
+	}
+
+	for _, t := range types {
+		switch {
+		case strings.HasSuffix(t, "MsgRecvPacket"):
+			packets++
+		case strings.HasSuffix(t, "MsgAcknowledgement"):
+			acks++
+		case strings.HasSuffix(t, "MsgTimeout"), strings.HasSuffix(t, "MsgTimeoutOnClose"):
+			timeouts++
+		}
+	}
+
+	return packets, acks, timeouts
+}
+
+type txResponse struct {
+	Result struct {
+		TxResult struct {
+			Events []struct {
+				Type       string `json:"type"`
+				Attributes []struct {
+					Key   string `json:"key"`
+					Value string `json:"value"`
+				} `json:"attributes"`
+			} `json:"events"`
+		} `json:"tx_result"`
+	} `json:"result"`
+}
+
+// packetLatency returns the time between the send of the first packet received by the
+// transaction and the relay time
+func (pm *PacketMetrics) packetLatency(
+	chainID, txHash string,
+	relayed time.Time,
+) (time.Duration, error) {
+	dst, ok := pm.rlyCfg.Chains[chainID]
+	if !ok {
+		return 0, fmt.Errorf("chain %s not found in the relayer config", chainID)
+	}
+	src := pm.rlyCfg.counterparty(chainID)
+	if src == "" {
+		return 0, fmt.Errorf("no path found for %s", chainID)
+	}
+
+	var resp txResponse
+	err := getJSON(
+		fmt.Sprintf(
+			"%s/tx?hash=0x%s",
+			strings.TrimSuffix(dst.Value.RpcAddr, "/"),
+			strings.TrimPrefix(txHash, "0x"),
+		),
+		&resp,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, e := range resp.Result.TxResult.Events {
+		if e.Type != "recv_packet" {
+			continue
+		}
+
+		var seq uint64
+		var srcChannel string
+		for _, a := range e.Attributes {
+			switch attrValue(a.Key) {
+			case "packet_sequence":
+				seq, _ = strconv.ParseUint(attrValue(a.Value), 10, 64)
+			case "packet_src_channel":
+				srcChannel = attrValue(a.Value)
+			}
+		}
+		if seq == 0 || srcChannel == "" {
+			continue
+		}
+
+		pp := PendingPacket{Sequence: seq, Kind: PacketKinds.Packet, ChainID: src}
+		err := fillPacketInfo(&pp, pm.rlyCfg.Chains[src].Value.RpcAddr, srcChannel)
+		if err != nil {
+			return 0, err
+		}
+
+		return relayed.Sub(pp.Time), nil
+	}
+
+	return 0, fmt.Errorf("no received packet found in %s", txHash)
+}
+
+// counterparty returns the chain on the other end of the first path of the chain
+func (c *Config) counterparty(chainID string) string {
+	for _, name := range c.PathNames() {
+		p := c.Paths[name]
+		switch chainID {
+		case p.Src.ChainID:
+			return p.Dst.ChainID
+		case p.Dst.ChainID:
+			return p.Src.ChainID
+		}
+	}
+	return ""
+}
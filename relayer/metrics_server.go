@@ -0,0 +1,302 @@
+package relayer
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+)
+
+const (
+	DefaultMetricsAddress = "localhost:2114"
+	logPollInterval       = time.Second
+)
+
+// Follow tails the relayer log file and updates the metrics with the new lines until
+// the context is cancelled. The existing lines are processed first and the file is
+// reopened when it's rotated
+func (pm *PacketMetrics) Follow(ctx context.Context, logPath string) {
+	var f *os.File
+	var reader *bufio.Reader
+	var offset int64
+	var partial []byte
+
+	defer func() {
+		if f != nil {
+			// nolint: errcheck
+			f.Close()
+		}
+	}()
+
+	ticker := time.NewTicker(logPollInterval)
+	defer ticker.Stop()
+
+	for {
+		if f == nil {
+			var err error
+			f, err = os.Open(logPath)
+			if err == nil {
+				reader = bufio.NewReader(f)
+				offset = 0
+				partial = nil
+			}
+		}
+
+		if f != nil {
+			for {
+				line, err := reader.ReadBytes('\n')
+				offset += int64(len(line))
+				if err != nil {
+					// the rest of the line is not written yet
+					partial = append(partial, line...)
+					break
+				}
+				if len(partial) > 0 {
+					line = append(partial, line...)
+					partial = nil
+				}
+				pm.ProcessLine(line)
+			}
+
+			if rotated(f, logPath, offset) {
+				// nolint: errcheck
+				f.Close()
+				f = nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// rotated returns true when the log file was replaced or truncated since it was opened
+func rotated(f *os.File, logPath string, offset int64) bool {
+	current, err := os.Stat(logPath)
+	if err != nil {
+		return false
+	}
+	opened, err := f.Stat()
+	if err != nil {
+		return true
+	}
+
+	return !os.SameFile(current, opened) || current.Size() < offset
+}
+
+// Serve exposes the metrics in the prometheus text format on /metrics and as json on
+// /status, it blocks until the server fails
+func (pm *PacketMetrics) Serve(addr string) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/status", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		// nolint: errcheck
+		json.NewEncoder(w).Encode(pm.Snapshot())
+	})
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		writePacketMetrics(w, pm.Snapshot())
+		writeClientMetrics(w, pm.Clients())
+	})
+
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	return srv.ListenAndServe()
+}
+
+// QueryMetrics retrieves the metrics of the relayer exporter listening on addr
+func QueryMetrics(addr string) ([]ChannelMetrics, error) {
+	c := http.Client{Timeout: 5 * time.Second}
+	resp, err := c.Get(fmt.Sprintf("http://%s/status", addr))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query relayer metrics: %w", err)
+	}
+	// nolint: errcheck
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("relayer metrics returned status %d", resp.StatusCode)
+	}
+
+	var metrics []ChannelMetrics
+	err = json.NewDecoder(resp.Body).Decode(&metrics)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode relayer metrics: %w", err)
+	}
+
+	return metrics, nil
+}
+
+// GetMetrics returns the metrics of the running exporter, the relayer log is parsed
+// instead when the exporter is not reachable, without the packet latencies
+func GetMetrics(addr, logPath string) ([]ChannelMetrics, error) {
+	metrics, err := QueryMetrics(addr)
+	if err == nil {
+		return metrics, nil
+	}
+
+	metrics, logErr := ParseLogFile(logPath)
+	if logErr != nil {
+		return nil, errors.Join(err, logErr)
+	}
+
+	return metrics, nil
+}
+
+func writePacketMetrics(w io.Writer, metrics []ChannelMetrics) {
+	family := func(name, kind, help string, value func(m ChannelMetrics) float64) {
+		// nolint: errcheck
+		fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+		for _, m := range metrics {
+			// nolint: errcheck
+			fmt.Fprintf(
+				w,
+				"%s{chain_id=%q,channel=%q} %v\n",
+				name,
+				m.ChainID,
+				m.Channel,
+				value(m),
+			)
+		}
+	}
+
+	family(
+		"roller_relayer_packets_relayed_total",
+		"counter",
+		"Number of packets received on the chain by the relayer",
+		func(m ChannelMetrics) float64 { return float64(m.Packets) },
+	)
+	family(
+		"roller_relayer_acks_relayed_total",
+		"counter",
+		"Number of acknowledgements delivered to the chain by the relayer",
+		func(m ChannelMetrics) float64 { return float64(m.Acks) },
+	)
+	family(
+		"roller_relayer_timeouts_relayed_total",
+		"counter",
+		"Number of packet timeouts delivered to the chain by the relayer",
+		func(m ChannelMetrics) float64 { return float64(m.Timeouts) },
+	)
+	family(
+		"roller_relayer_txs_total",
+		"counter",
+		"Number of successful relayer transactions",
+		func(m ChannelMetrics) float64 { return float64(m.Txs) },
+	)
+	family(
+		"roller_relayer_failed_txs_total",
+		"counter",
+		"Number of failed relayer transactions",
+		func(m ChannelMetrics) float64 { return float64(m.FailedTxs) },
+	)
+	family(
+		"roller_relayer_gas_used_total",
+		"counter",
+		"Gas used by the successful relayer transactions",
+		func(m ChannelMetrics) float64 { return float64(m.GasUsed) },
+	)
+	family(
+		"roller_relayer_packet_latency_seconds_sum",
+		"counter",
+		"Sum of the times between the send and the receive of the sampled packets",
+		func(m ChannelMetrics) float64 { return m.LatencySum },
+	)
+	family(
+		"roller_relayer_packet_latency_seconds_count",
+		"counter",
+		"Number of sampled packet latencies",
+		func(m ChannelMetrics) float64 { return float64(m.LatencySamples) },
+	)
+	family(
+		"roller_relayer_last_relay_timestamp_seconds",
+		"gauge",
+		"Unix time of the last successful relayer transaction",
+		func(m ChannelMetrics) float64 {
+			if m.LastRelay.IsZero() {
+				return 0
+			}
+			return float64(m.LastRelay.Unix())
+		},
+	)
+
+	name := "roller_relayer_fees_total"
+	// nolint: errcheck
+	fmt.Fprintf(
+		w,
+		"# HELP %s Fees paid by the successful relayer transactions\n# TYPE %s counter\n",
+		name,
+		name,
+	)
+	for _, m := range metrics {
+		for _, c := range m.Fees {
+			f, _ := c.Amount.ToLegacyDec().Float64()
+			// nolint: errcheck
+			fmt.Fprintf(
+				w,
+				"%s{chain_id=%q,channel=%q,denom=%q} %v\n",
+				name,
+				m.ChainID,
+				m.Channel,
+				c.Denom,
+				f,
+			)
+		}
+	}
+}
+
+func writeClientMetrics(w io.Writer, clients []ClientStatus) {
+	family := func(name, help string, value func(c ClientStatus) float64) {
+		// nolint: errcheck
+		fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s gauge\n", name, help, name)
+		for _, c := range clients {
+			// nolint: errcheck
+			fmt.Fprintf(
+				w,
+				"%s{chain_id=%q,client_id=%q} %v\n",
+				name,
+				c.ChainID,
+				c.ClientID,
+				value(c),
+			)
+		}
+	}
+
+	family(
+		"roller_relayer_client_trusting_period_left_seconds",
+		"Time left before the light client expires",
+		func(c ClientStatus) float64 { return float64(max(c.TrustingPeriodLeft, 0)) },
+	)
+	family(
+		"roller_relayer_client_trusting_period_seconds",
+		"Trusting period of the light client",
+		func(c ClientStatus) float64 { return float64(c.TrustingPeriod) },
+	)
+	family(
+		"roller_relayer_client_expired",
+		"1 when the light client is expired or frozen",
+		func(c ClientStatus) float64 {
+			if c.Expired || c.Frozen {
+				return 1
+			}
+			return 0
+		},
+	)
+}
@@ -0,0 +1,178 @@
+package native
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	rpcclient "github.com/cometbft/cometbft/rpc/client"
+	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/gogoproto/proto"
+	clienttypes "github.com/cosmos/ibc-go/v7/modules/core/02-client/types"
+	commitmenttypes "github.com/cosmos/ibc-go/v7/modules/core/23-commitment/types"
+	etherminthd "github.com/evmos/ethermint/crypto/hd"
+
+	"github.com/dymensionxyz/roller/relayer"
+)
+
+const transferPort = "transfer"
+
+// Chain represents one end of a relayer path
+type Chain struct {
+	ID  string
+	RPC string
+	// AccountPrefix is the bech32 prefix of the chain accounts
+	AccountPrefix string
+
+	// ClientID is the client on this chain that tracks the counterparty
+	ClientID  string
+	ChannelID string
+	PortID    string
+
+	KeyName    string
+	KeyringDir string
+	GasPrices  string
+	// GasAdjustment multiplies the simulated gas of the relayer transactions
+	GasAdjustment float64
+
+	address  string
+	revision uint64
+	client   *rpchttp.HTTP
+	keyring  keyring.Keyring
+}
+
+func newChain(
+	relayerHome, channelID string,
+	end *relayer.PathEnd,
+	chainCfg relayer.RelayerFileChainConfig,
+) (*Chain, error) {
+	c, err := rpchttp.New(chainCfg.Value.RpcAddr, "/websocket")
+	if err != nil {
+		return nil, err
+	}
+
+	// the relayer keys are stored by rly in a test keyring per chain, the keys of the
+	// evm rollapps are eth_secp256k1 keys
+	keyringDir := filepath.Join(relayerHome, "keys", end.ChainID)
+	kr, err := keyring.New(
+		end.ChainID,
+		keyring.BackendTest,
+		keyringDir,
+		nil,
+		cdc,
+		etherminthd.EthSecp256k1Option(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the relayer keyring of %s: %w", end.ChainID, err)
+	}
+
+	gasAdjustment := chainCfg.Value.GasAdjustment
+	if gasAdjustment == 0 {
+		gasAdjustment = 1.5
+	}
+
+	return &Chain{
+		ID:            end.ChainID,
+		RPC:           chainCfg.Value.RpcAddr,
+		AccountPrefix: chainCfg.Value.AccountPrefix,
+		ClientID:      end.ClientID,
+		ChannelID:     channelID,
+		PortID:        transferPort,
+		KeyName:       chainCfg.Value.Key,
+		KeyringDir:    keyringDir,
+		GasPrices:     chainCfg.Value.GasPrices,
+		GasAdjustment: gasAdjustment,
+		revision:      clienttypes.ParseChainID(end.ChainID),
+		client:        c,
+		keyring:       kr,
+	}, nil
+}
+
+// Address returns the relayer address on the chain
+func (c *Chain) Address() (string, error) {
+	if c.address != "" {
+		return c.address, nil
+	}
+
+	rec, err := c.keyring.Key(c.KeyName)
+	if err != nil {
+		return "", fmt.Errorf("failed to retrieve relayer key on %s: %w", c.ID, err)
+	}
+	addr, err := rec.GetAddress()
+	if err != nil {
+		return "", err
+	}
+	c.address, err = sdk.Bech32ifyAddressBytes(c.AccountPrefix, addr)
+	if err != nil {
+		return "", err
+	}
+
+	return c.address, nil
+}
+
+// LatestHeight returns the latest block height of the chain
+func (c *Chain) LatestHeight(ctx context.Context) (int64, error) {
+	status, err := c.client.Status(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return status.SyncInfo.LatestBlockHeight, nil
+}
+
+// Height returns the ibc height of the block on this chain
+func (c *Chain) Height(h int64) clienttypes.Height {
+	return clienttypes.NewHeight(c.revision, uint64(h))
+}
+
+// queryGRPC executes a gRPC query through abci_query
+func (c *Chain) queryGRPC(
+	ctx context.Context,
+	method string,
+	req, resp proto.Message,
+) error {
+	data, err := proto.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	res, err := c.client.ABCIQuery(ctx, method, data)
+	if err != nil {
+		return err
+	}
+	if !res.Response.IsOK() {
+		return fmt.Errorf("%s failed on %s: %s", method, c.ID, res.Response.Log)
+	}
+
+	return proto.Unmarshal(res.Response.Value, resp)
+}
+
+// queryProof returns the value stored under the ibc store key at height along with its
+// merkle proof, the proof can be verified against the app hash of the next block
+func (c *Chain) queryProof(ctx context.Context, key []byte, height int64) ([]byte, []byte, error) {
+	res, err := c.client.ABCIQueryWithOptions(
+		ctx,
+		"store/ibc/key",
+		key,
+		rpcclient.ABCIQueryOptions{Height: height, Prove: true},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !res.Response.IsOK() {
+		return nil, nil, fmt.Errorf("proof query failed on %s: %s", c.ID, res.Response.Log)
+	}
+
+	merkleProof, err := commitmenttypes.ConvertProofs(res.Response.ProofOps)
+	if err != nil {
+		return nil, nil, err
+	}
+	proof, err := merkleProof.Marshal()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return res.Response.Value, proof, nil
+}
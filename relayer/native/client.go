@@ -0,0 +1,171 @@
+package native
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	cmttypes "github.com/cometbft/cometbft/types"
+	clienttypes "github.com/cosmos/ibc-go/v7/modules/core/02-client/types"
+	ibctm "github.com/cosmos/ibc-go/v7/modules/light-clients/07-tendermint"
+
+	"github.com/dymensionxyz/roller/relayer"
+)
+
+const (
+	queryClientState    = "/ibc.core.client.v1.Query/ClientState"
+	queryConsensusState = "/ibc.core.client.v1.Query/ConsensusState"
+)
+
+// clientState returns the tendermint client state of the client on the chain
+func (c *Chain) clientState(ctx context.Context) (*ibctm.ClientState, error) {
+	var resp clienttypes.QueryClientStateResponse
+	err := c.queryGRPC(ctx, queryClientState, &clienttypes.QueryClientStateRequest{
+		ClientId: c.ClientID,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	var cs ibctm.ClientState
+	err = cs.Unmarshal(resp.ClientState.Value)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported client state of %s: %w", c.ClientID, err)
+	}
+
+	return &cs, nil
+}
+
+// lastClientUpdate returns the timestamp of the latest consensus state of the client
+func (c *Chain) lastClientUpdate(ctx context.Context) (time.Time, error) {
+	var resp clienttypes.QueryConsensusStateResponse
+	err := c.queryGRPC(ctx, queryConsensusState, &clienttypes.QueryConsensusStateRequest{
+		ClientId:     c.ClientID,
+		LatestHeight: true,
+	}, &resp)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var cs ibctm.ConsensusState
+	err = cs.Unmarshal(resp.ConsensusState.Value)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return cs.Timestamp, nil
+}
+
+// updateClientMsg returns the message that updates the client on dst tracking src to
+// at least the target height of src. The returned height is the height the client is at
+// once the message is executed, nil is returned when no update is needed
+func updateClientMsg(
+	ctx context.Context,
+	src, dst *Chain,
+	target int64,
+) (*clienttypes.MsgUpdateClient, int64, error) {
+	cs, err := dst.clientState(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	if cs.FrozenHeight != clienttypes.ZeroHeight() {
+		return nil, 0, fmt.Errorf("client %s on %s is frozen", dst.ClientID, dst.ID)
+	}
+
+	trusted := int64(cs.LatestHeight.GetRevisionHeight())
+	if trusted >= target {
+		return nil, trusted, nil
+	}
+
+	header, err := src.header(ctx, target, trusted)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	signer, err := dst.Address()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	msg, err := clienttypes.NewMsgUpdateClient(dst.ClientID, header, signer)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return msg, target, nil
+}
+
+// header builds the tendermint header of the block at height, trusted is the latest
+// height the counterparty client has a consensus state for
+func (c *Chain) header(ctx context.Context, height, trusted int64) (*ibctm.Header, error) {
+	commit, err := c.client.Commit(ctx, &height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query commit of %s at %d: %w", c.ID, height, err)
+	}
+
+	valSet, err := c.validatorSet(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+
+	// the validators that signed the block after the trusted height are the ones
+	// that are trusted by the client
+	trustedValSet, err := c.validatorSet(ctx, trusted+1)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ibctm.Header{
+		SignedHeader:      commit.SignedHeader.ToProto(),
+		ValidatorSet:      valSet,
+		TrustedHeight:     c.Height(trusted),
+		TrustedValidators: trustedValSet,
+	}, nil
+}
+
+func (c *Chain) validatorSet(ctx context.Context, height int64) (*cmtproto.ValidatorSet, error) {
+	var validators []*cmttypes.Validator
+	page, perPage := 1, 100
+	for {
+		res, err := c.client.Validators(ctx, &height, &page, &perPage)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"failed to query validators of %s at %d: %w",
+				c.ID,
+				height,
+				err,
+			)
+		}
+		validators = append(validators, res.Validators...)
+		if len(validators) >= res.Total || len(res.Validators) == 0 {
+			break
+		}
+		page++
+	}
+
+	vs := cmttypes.NewValidatorSet(validators)
+	return vs.ToProto()
+}
+
+// clientNeedsUpdate returns true when less than relayer.ClientUpdateThreshold of the
+// trusting period of the client on the chain is left
+func (c *Chain) clientNeedsUpdate(ctx context.Context) (bool, error) {
+	cs, err := c.clientState(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	lastUpdate, err := c.lastClientUpdate(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	left := relayer.TrustingPeriodLeft(lastUpdate, cs.TrustingPeriod, time.Now())
+	return relayer.NeedsUpdate(relayer.ClientStatus{
+		TrustingPeriod:     int64(cs.TrustingPeriod.Seconds()),
+		TrustingPeriodLeft: int64(left.Seconds()),
+		Expired:            left <= 0,
+		Frozen:             cs.FrozenHeight != clienttypes.ZeroHeight(),
+	}), nil
+}
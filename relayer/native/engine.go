@@ -0,0 +1,587 @@
+package native
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	cosmossdkmath "cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	transfertypes "github.com/cosmos/ibc-go/v7/modules/apps/transfer/types"
+	clienttypes "github.com/cosmos/ibc-go/v7/modules/core/02-client/types"
+	channeltypes "github.com/cosmos/ibc-go/v7/modules/core/04-channel/types"
+	host "github.com/cosmos/ibc-go/v7/modules/core/24-host"
+
+	"github.com/dymensionxyz/roller/relayer"
+)
+
+const DefaultInterval = 10 * time.Second
+
+// Engine relays the packets, acknowledgements and timeouts of a hub<->rollapp path
+// without the rly binary
+type Engine struct {
+	Path     string
+	Hub      *Chain
+	Rollapp  *Chain
+	Interval time.Duration
+	MaxMsgs  int
+	// MinPacketValue is the smallest transfer amount that is relayed, keyed by denom.
+	// The packets below it are still timed out
+	MinPacketValue map[string]cosmossdkmath.Int
+	// Metrics, when set, records the transactions sent by the engine
+	Metrics *relayer.PacketMetrics
+
+	// ignored are the packets below the minimal value, keyed by chain and sequence
+	ignored map[string]channeltypes.Packet
+	logger  *log.Logger
+}
+
+// NewEngine creates the engine of the relayer path, the active channel of the relayer
+// has to be loaded before calling it
+func NewEngine(r *relayer.Relayer, rlyCfg *relayer.Config) (*Engine, error) {
+	if !r.ChannelReady() {
+		return nil, relayer.ErrNoOpenChannel
+	}
+
+	p := rlyCfg.GetPathByName(r.Path)
+	if p == nil {
+		return nil, fmt.Errorf("path %s not found", r.Path)
+	}
+
+	hub, err := newChain(
+		r.RelayerHome,
+		r.SrcChannel,
+		p.Src,
+		rlyCfg.Chains[p.Src.ChainID],
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	ra, err := newChain(
+		r.RelayerHome,
+		r.DstChannel,
+		p.Dst,
+		rlyCfg.Chains[p.Dst.ChainID],
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Engine{
+		Path:     r.Path,
+		Hub:      hub,
+		Rollapp:  ra,
+		Interval: DefaultInterval,
+		MaxMsgs:  relayer.DefaultMaxMsgs,
+		ignored:  map[string]channeltypes.Packet{},
+		logger:   log.New(log.Writer(), "", log.LstdFlags),
+	}, nil
+}
+
+func (e *Engine) SetLogger(logger *log.Logger) {
+	e.logger = logger
+}
+
+// Run relays the path every interval until the context is cancelled
+func (e *Engine) Run(ctx context.Context) {
+	ticker := time.NewTicker(e.Interval)
+	defer ticker.Stop()
+
+	for {
+		err := e.RelayOnce(ctx)
+		if err != nil {
+			e.logger.Printf("[%s] %v", e.Path, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RelayOnce relays the pending packets and acknowledgements in both directions and
+// updates the clients that are close to expiry
+func (e *Engine) RelayOnce(ctx context.Context) error {
+	var errs []error
+
+	errs = append(errs, e.relayPackets(ctx, e.Hub, e.Rollapp, nil))
+	errs = append(errs, e.relayPackets(ctx, e.Rollapp, e.Hub, nil))
+	errs = append(errs, e.relayAcks(ctx, e.Hub, e.Rollapp, nil))
+	errs = append(errs, e.relayAcks(ctx, e.Rollapp, e.Hub, nil))
+	errs = append(errs, e.refreshClient(ctx, e.Rollapp, e.Hub))
+	errs = append(errs, e.refreshClient(ctx, e.Hub, e.Rollapp))
+
+	return errors.Join(errs...)
+}
+
+// RelaySelected relays the selected pending packets and acknowledgements only, in
+// transactions of up to MaxMsgs messages. The selected packets are relayed regardless
+// of the minimal packet value
+func (e *Engine) RelaySelected(ctx context.Context, selected []relayer.PendingPacket) error {
+	var errs []error
+	for _, src := range []*Chain{e.Hub, e.Rollapp} {
+		dst := e.counterparty(src)
+		packets, acks := selectedChunks(selected, src.ID, e.MaxMsgs)
+
+		// the packets written on src are received by dst, the acknowledgements written
+		// on src are delivered to dst, the sender of the packets
+		for _, chunk := range packets {
+			errs = append(errs, e.relayPackets(ctx, src, dst, chunk))
+		}
+		for _, chunk := range acks {
+			errs = append(errs, e.relayAcks(ctx, dst, src, chunk))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// selectedChunks returns the sequences of the selected packets and acknowledgements
+// written on the chain, in chunks of up to maxMsgs sequences
+func selectedChunks(
+	selected []relayer.PendingPacket,
+	chainID string,
+	maxMsgs int,
+) ([][]uint64, [][]uint64) {
+	var packets, acks []uint64
+	for _, pp := range selected {
+		if pp.ChainID != chainID {
+			continue
+		}
+		switch pp.Kind {
+		case relayer.PacketKinds.Packet:
+			packets = append(packets, pp.Sequence)
+		case relayer.PacketKinds.Ack:
+			acks = append(acks, pp.Sequence)
+		}
+	}
+
+	return slices.Collect(slices.Chunk(packets, max(maxMsgs, 1))),
+		slices.Collect(slices.Chunk(acks, max(maxMsgs, 1)))
+}
+
+func (e *Engine) counterparty(c *Chain) *Chain {
+	if c == e.Hub {
+		return e.Rollapp
+	}
+	return e.Hub
+}
+
+// relayPackets delivers the packets sent by src to dst, the packets that timed out on
+// dst are timed out on src instead. When only is set, the other packets are left pending
+func (e *Engine) relayPackets(ctx context.Context, src, dst *Chain, only []uint64) error {
+	commitments, err := src.packetCommitments(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to query packet commitments on %s: %w", src.ID, err)
+	}
+	seqs, err := dst.unreceivedPackets(ctx, commitments)
+	if err != nil {
+		return fmt.Errorf("failed to query unreceived packets on %s: %w", dst.ID, err)
+	}
+	if only != nil {
+		seqs = selectSequences(seqs, only)
+	} else {
+		e.forgetReceived(src, seqs)
+	}
+	if len(seqs) == 0 {
+		return nil
+	}
+
+	dstHeight, err := dst.LatestHeight(ctx)
+	if err != nil {
+		return err
+	}
+	dstTime, err := dst.latestTime(ctx, dstHeight)
+	if err != nil {
+		return err
+	}
+
+	var deliver, timedOut []channeltypes.Packet
+	for _, seq := range seqs {
+		if len(deliver)+len(timedOut) >= e.MaxMsgs {
+			break
+		}
+
+		key := ignoredKey(src, seq)
+		packet, ok := e.ignored[key]
+		if !ok {
+			p, err := src.sentPacket(ctx, seq)
+			if err != nil {
+				e.logger.Printf("[%s] %v", e.Path, err)
+				continue
+			}
+			packet = *p
+		}
+
+		switch {
+		case isTimedOut(packet, dst.Height(dstHeight), dstTime):
+			delete(e.ignored, key)
+			timedOut = append(timedOut, packet)
+		case only == nil && belowMinValue(packet.GetData(), e.MinPacketValue):
+			if !ok {
+				e.logger.Printf(
+					"[%s] packet %d from %s is below the minimal value, not relaying it",
+					e.Path,
+					seq,
+					src.ID,
+				)
+				e.ignored[key] = packet
+			}
+		default:
+			deliver = append(deliver, packet)
+		}
+	}
+
+	var errs []error
+	if len(deliver) > 0 {
+		errs = append(errs, e.deliverPackets(ctx, src, dst, deliver))
+	}
+	if len(timedOut) > 0 {
+		errs = append(errs, e.timeoutPackets(ctx, src, dst, timedOut))
+	}
+
+	return errors.Join(errs...)
+}
+
+func (e *Engine) deliverPackets(
+	ctx context.Context,
+	src, dst *Chain,
+	packets []channeltypes.Packet,
+) error {
+	target, err := src.LatestHeight(ctx)
+	if err != nil {
+		return err
+	}
+	update, proofHeight, err := updateClientMsg(ctx, src, dst, target)
+	if err != nil {
+		return err
+	}
+
+	signer, err := dst.Address()
+	if err != nil {
+		return err
+	}
+
+	var msgs []sdk.Msg
+	if update != nil {
+		msgs = append(msgs, update)
+	}
+	for _, p := range packets {
+		key := host.PacketCommitmentKey(p.SourcePort, p.SourceChannel, p.Sequence)
+		_, proof, err := src.queryProof(ctx, key, proofHeight-1)
+		if err != nil {
+			return err
+		}
+		msgs = append(
+			msgs,
+			channeltypes.NewMsgRecvPacket(p, proof, src.Height(proofHeight), signer),
+		)
+	}
+
+	txHash, err := e.send(
+		ctx,
+		dst,
+		relayer.RelayedTx{Channel: dst.ChannelID, Packets: uint64(len(packets))},
+		msgs...,
+	)
+	if err != nil {
+		return err
+	}
+	e.logger.Printf(
+		"[%s] relayed %d packets from %s to %s (%s)",
+		e.Path,
+		len(packets),
+		src.ID,
+		dst.ID,
+		txHash,
+	)
+
+	return nil
+}
+
+// timeoutPackets times out the packets sent by src that can no longer be received by dst
+func (e *Engine) timeoutPackets(
+	ctx context.Context,
+	src, dst *Chain,
+	packets []channeltypes.Packet,
+) error {
+	target, err := dst.LatestHeight(ctx)
+	if err != nil {
+		return err
+	}
+	update, proofHeight, err := updateClientMsg(ctx, dst, src, target)
+	if err != nil {
+		return err
+	}
+
+	signer, err := src.Address()
+	if err != nil {
+		return err
+	}
+
+	var msgs []sdk.Msg
+	if update != nil {
+		msgs = append(msgs, update)
+	}
+	for _, p := range packets {
+		key := host.PacketReceiptKey(p.DestinationPort, p.DestinationChannel, p.Sequence)
+		_, proof, err := dst.queryProof(ctx, key, proofHeight-1)
+		if err != nil {
+			return err
+		}
+		// the next sequence receive is only verified for ordered channels
+		msgs = append(
+			msgs,
+			channeltypes.NewMsgTimeout(p, p.Sequence, proof, dst.Height(proofHeight), signer),
+		)
+	}
+
+	txHash, err := e.send(
+		ctx,
+		src,
+		relayer.RelayedTx{Channel: src.ChannelID, Timeouts: uint64(len(packets))},
+		msgs...,
+	)
+	if err != nil {
+		return err
+	}
+	e.logger.Printf(
+		"[%s] timed out %d packets from %s to %s (%s)",
+		e.Path,
+		len(packets),
+		src.ID,
+		dst.ID,
+		txHash,
+	)
+
+	return nil
+}
+
+// relayAcks delivers the acknowledgements written by dst to src, the sender of the packets.
+// When only is set, the other acknowledgements are left pending
+func (e *Engine) relayAcks(ctx context.Context, src, dst *Chain, only []uint64) error {
+	written, err := dst.packetAcknowledgements(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to query acknowledgements on %s: %w", dst.ID, err)
+	}
+	seqs, err := src.unreceivedAcks(ctx, written)
+	if err != nil {
+		return fmt.Errorf("failed to query unreceived acknowledgements on %s: %w", src.ID, err)
+	}
+	if only != nil {
+		seqs = selectSequences(seqs, only)
+	}
+	if len(seqs) == 0 {
+		return nil
+	}
+	if len(seqs) > e.MaxMsgs {
+		seqs = seqs[:e.MaxMsgs]
+	}
+
+	target, err := dst.LatestHeight(ctx)
+	if err != nil {
+		return err
+	}
+	update, proofHeight, err := updateClientMsg(ctx, dst, src, target)
+	if err != nil {
+		return err
+	}
+
+	signer, err := src.Address()
+	if err != nil {
+		return err
+	}
+
+	var msgs []sdk.Msg
+	if update != nil {
+		msgs = append(msgs, update)
+	}
+	acks := 0
+	for _, seq := range seqs {
+		pa, err := dst.writtenAck(ctx, seq)
+		if err != nil {
+			e.logger.Printf("[%s] %v", e.Path, err)
+			continue
+		}
+
+		key := host.PacketAcknowledgementKey(
+			pa.Packet.DestinationPort,
+			pa.Packet.DestinationChannel,
+			pa.Packet.Sequence,
+		)
+		_, proof, err := dst.queryProof(ctx, key, proofHeight-1)
+		if err != nil {
+			return err
+		}
+		msgs = append(
+			msgs,
+			channeltypes.NewMsgAcknowledgement(
+				pa.Packet,
+				pa.Ack,
+				proof,
+				dst.Height(proofHeight),
+				signer,
+			),
+		)
+		acks++
+	}
+
+	if acks == 0 {
+		return nil
+	}
+
+	txHash, err := e.send(
+		ctx,
+		src,
+		relayer.RelayedTx{Channel: src.ChannelID, Acks: uint64(acks)},
+		msgs...,
+	)
+	if err != nil {
+		return err
+	}
+	e.logger.Printf(
+		"[%s] relayed %d acknowledgements from %s to %s (%s)",
+		e.Path,
+		acks,
+		dst.ID,
+		src.ID,
+		txHash,
+	)
+
+	return nil
+}
+
+// refreshClient updates the client on dst that tracks src when it gets close to expiry
+func (e *Engine) refreshClient(ctx context.Context, src, dst *Chain) error {
+	needsUpdate, err := dst.clientNeedsUpdate(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to check client %s on %s: %w", dst.ClientID, dst.ID, err)
+	}
+	if !needsUpdate {
+		return nil
+	}
+
+	target, err := src.LatestHeight(ctx)
+	if err != nil {
+		return err
+	}
+	update, _, err := updateClientMsg(ctx, src, dst, target)
+	if err != nil || update == nil {
+		return err
+	}
+
+	txHash, err := e.send(ctx, dst, relayer.RelayedTx{}, update)
+	if err != nil {
+		return err
+	}
+	e.logger.Printf("[%s] updated client %s on %s (%s)", e.Path, dst.ClientID, dst.ID, txHash)
+
+	return nil
+}
+
+// send broadcasts the messages to the chain and records the transaction in the metrics,
+// relayed holds the channel and the message counts of the transaction
+func (e *Engine) send(
+	ctx context.Context,
+	c *Chain,
+	relayed relayer.RelayedTx,
+	msgs ...sdk.Msg,
+) (string, error) {
+	res, err := c.broadcast(ctx, msgs...)
+
+	relayed.ChainID = c.ID
+	relayed.Time = time.Now()
+	if err != nil {
+		relayed.Err = err
+	} else {
+		relayed.TxHash = res.Hash
+		relayed.GasUsed = res.GasUsed
+		relayed.Fees = res.Fees
+	}
+	if e.Metrics != nil {
+		e.Metrics.RecordTx(relayed)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return res.Hash, nil
+}
+
+// latestTime returns the time of the block at height
+func (c *Chain) latestTime(ctx context.Context, height int64) (time.Time, error) {
+	b, err := c.client.Block(ctx, &height)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return b.Block.Time, nil
+}
+
+// isTimedOut returns true when the packet can no longer be received at the given
+// height and time of the destination chain, the heights of later revisions are past the
+// timeout heights of the earlier ones
+func isTimedOut(p channeltypes.Packet, dstHeight clienttypes.Height, dstTime time.Time) bool {
+	th := p.GetTimeoutHeight()
+	if !th.IsZero() && dstHeight.GTE(th) {
+		return true
+	}
+
+	ts := p.GetTimeoutTimestamp()
+	return ts != 0 && uint64(dstTime.UnixNano()) >= ts
+}
+
+// forgetReceived drops the ignored packets of src that are no longer pending
+func (e *Engine) forgetReceived(src *Chain, pending []uint64) {
+	for key, p := range e.ignored {
+		if key == ignoredKey(src, p.Sequence) && !slices.Contains(pending, p.Sequence) {
+			delete(e.ignored, key)
+		}
+	}
+}
+
+// selectSequences returns the pending sequences that are part of the selection
+func selectSequences(pending, selection []uint64) []uint64 {
+	var selected []uint64
+	for _, seq := range pending {
+		if slices.Contains(selection, seq) {
+			selected = append(selected, seq)
+		}
+	}
+	return selected
+}
+
+func ignoredKey(src *Chain, seq uint64) string {
+	return fmt.Sprintf("%s/%d", src.ID, seq)
+}
+
+// belowMinValue returns true when the packet is a transfer of an amount below the minimal
+// value of its denom, the minimal values are matched against the full and the base denom
+func belowMinValue(data []byte, minValues map[string]cosmossdkmath.Int) bool {
+	if len(minValues) == 0 {
+		return false
+	}
+
+	var ftpd transfertypes.FungibleTokenPacketData
+	err := json.Unmarshal(data, &ftpd)
+	if err != nil {
+		return false
+	}
+
+	minValue, ok := minValues[ftpd.Denom]
+	if !ok {
+		minValue, ok = minValues[transfertypes.ParseDenomTrace(ftpd.Denom).BaseDenom]
+	}
+	if !ok {
+		return false
+	}
+
+	amount, ok := cosmossdkmath.NewIntFromString(ftpd.Amount)
+	return ok && amount.LT(minValue)
+}
@@ -0,0 +1,315 @@
+package native
+
+import (
+	"encoding/json"
+	"log"
+	"slices"
+	"testing"
+	"time"
+
+	cosmossdkmath "cosmossdk.io/math"
+	transfertypes "github.com/cosmos/ibc-go/v7/modules/apps/transfer/types"
+	clienttypes "github.com/cosmos/ibc-go/v7/modules/core/02-client/types"
+	channeltypes "github.com/cosmos/ibc-go/v7/modules/core/04-channel/types"
+
+	"github.com/dymensionxyz/roller/relayer"
+)
+
+func TestIsTimedOut(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+
+	tests := []struct {
+		name          string
+		timeoutHeight clienttypes.Height
+		timeoutTime   time.Time
+		dstHeight     clienttypes.Height
+		dstTime       time.Time
+		want          bool
+	}{
+		{
+			name:      "no timeout",
+			dstHeight: clienttypes.NewHeight(1, 100),
+			dstTime:   now,
+		},
+		{
+			name:          "before timeout height",
+			timeoutHeight: clienttypes.NewHeight(1, 101),
+			dstHeight:     clienttypes.NewHeight(1, 100),
+			dstTime:       now,
+		},
+		{
+			name:          "at timeout height",
+			timeoutHeight: clienttypes.NewHeight(1, 100),
+			dstHeight:     clienttypes.NewHeight(1, 100),
+			dstTime:       now,
+			want:          true,
+		},
+		{
+			name:          "earlier revision with a higher height",
+			timeoutHeight: clienttypes.NewHeight(2, 10),
+			dstHeight:     clienttypes.NewHeight(1, 100),
+			dstTime:       now,
+		},
+		{
+			name:          "later revision with a lower height",
+			timeoutHeight: clienttypes.NewHeight(1, 100),
+			dstHeight:     clienttypes.NewHeight(2, 10),
+			dstTime:       now,
+			want:          true,
+		},
+		{
+			name:        "before timeout timestamp",
+			timeoutTime: now.Add(time.Second),
+			dstHeight:   clienttypes.NewHeight(1, 100),
+			dstTime:     now,
+		},
+		{
+			name:        "at timeout timestamp",
+			timeoutTime: now,
+			dstHeight:   clienttypes.NewHeight(1, 100),
+			dstTime:     now,
+			want:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var timeoutTimestamp uint64
+			if !tt.timeoutTime.IsZero() {
+				timeoutTimestamp = uint64(tt.timeoutTime.UnixNano())
+			}
+			p := channeltypes.Packet{
+				TimeoutHeight:    tt.timeoutHeight,
+				TimeoutTimestamp: timeoutTimestamp,
+			}
+
+			got := isTimedOut(p, tt.dstHeight, tt.dstTime)
+			if got != tt.want {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSelectSequences(t *testing.T) {
+	tests := []struct {
+		name      string
+		pending   []uint64
+		selection []uint64
+		want      []uint64
+	}{
+		{
+			name:      "keeps the pending order",
+			pending:   []uint64{1, 2, 3, 4},
+			selection: []uint64{4, 2},
+			want:      []uint64{2, 4},
+		},
+		{
+			name:      "drops relayed selections",
+			pending:   []uint64{2},
+			selection: []uint64{1, 2},
+			want:      []uint64{2},
+		},
+		{
+			name:      "nothing selected",
+			pending:   []uint64{1, 2},
+			selection: []uint64{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := selectSequences(tt.pending, tt.selection)
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestForgetReceived(t *testing.T) {
+	hub := &Chain{ID: "hub_1-1"}
+	ra := &Chain{ID: "rollapp_1-1"}
+
+	tests := []struct {
+		name    string
+		ignored map[*Chain][]uint64
+		pending []uint64
+		want    []string
+	}{
+		{
+			name:    "forgets received packets of src",
+			ignored: map[*Chain][]uint64{hub: {1, 2, 3}},
+			pending: []uint64{2},
+			want:    []string{"hub_1-1/2"},
+		},
+		{
+			name:    "keeps the packets of the other chain",
+			ignored: map[*Chain][]uint64{hub: {1}, ra: {1, 2}},
+			pending: []uint64{},
+			want:    []string{"rollapp_1-1/1", "rollapp_1-1/2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &Engine{ignored: map[string]channeltypes.Packet{}, logger: log.Default()}
+			for c, seqs := range tt.ignored {
+				for _, seq := range seqs {
+					e.ignored[ignoredKey(c, seq)] = channeltypes.Packet{Sequence: seq}
+				}
+			}
+
+			e.forgetReceived(hub, tt.pending)
+
+			var got []string
+			for key := range e.ignored {
+				got = append(got, key)
+			}
+			slices.Sort(got)
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestBelowMinValue(t *testing.T) {
+	transfer := func(denom, amount string) []byte {
+		data, err := json.Marshal(transfertypes.FungibleTokenPacketData{
+			Denom:  denom,
+			Amount: amount,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return data
+	}
+	minValues := map[string]cosmossdkmath.Int{
+		"adym":                    cosmossdkmath.NewInt(1000),
+		"transfer/channel-0/arax": cosmossdkmath.NewInt(50),
+	}
+
+	tests := []struct {
+		name      string
+		data      []byte
+		minValues map[string]cosmossdkmath.Int
+		want      bool
+	}{
+		{
+			name: "no min values",
+			data: transfer("adym", "1"),
+		},
+		{
+			name:      "below the min value",
+			data:      transfer("adym", "999"),
+			minValues: minValues,
+			want:      true,
+		},
+		{
+			name:      "at the min value",
+			data:      transfer("adym", "1000"),
+			minValues: minValues,
+		},
+		{
+			name:      "matched on the base denom",
+			data:      transfer("transfer/channel-1/adym", "10"),
+			minValues: minValues,
+			want:      true,
+		},
+		{
+			name:      "matched on the full denom",
+			data:      transfer("transfer/channel-0/arax", "10"),
+			minValues: minValues,
+			want:      true,
+		},
+		{
+			name:      "denom without min value",
+			data:      transfer("uatom", "1"),
+			minValues: minValues,
+		},
+		{
+			name:      "invalid amount",
+			data:      transfer("adym", "abc"),
+			minValues: minValues,
+		},
+		{
+			name:      "not a transfer",
+			data:      []byte("not json"),
+			minValues: minValues,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := belowMinValue(tt.data, tt.minValues)
+			if got != tt.want {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSelectedChunks(t *testing.T) {
+	packet := func(chainID string, seq uint64) relayer.PendingPacket {
+		return relayer.PendingPacket{ChainID: chainID, Kind: relayer.PacketKinds.Packet, Sequence: seq}
+	}
+	ack := func(chainID string, seq uint64) relayer.PendingPacket {
+		return relayer.PendingPacket{ChainID: chainID, Kind: relayer.PacketKinds.Ack, Sequence: seq}
+	}
+	selected := []relayer.PendingPacket{
+		packet("hub", 1),
+		packet("hub", 2),
+		ack("hub", 7),
+		packet("rollapp", 3),
+		packet("hub", 3),
+		packet("hub", 4),
+		packet("hub", 5),
+	}
+
+	tests := []struct {
+		name        string
+		chainID     string
+		maxMsgs     int
+		wantPackets [][]uint64
+		wantAcks    [][]uint64
+	}{
+		{
+			name:        "chunks of max msgs",
+			chainID:     "hub",
+			maxMsgs:     2,
+			wantPackets: [][]uint64{{1, 2}, {3, 4}, {5}},
+			wantAcks:    [][]uint64{{7}},
+		},
+		{
+			name:        "single chunk",
+			chainID:     "hub",
+			maxMsgs:     10,
+			wantPackets: [][]uint64{{1, 2, 3, 4, 5}},
+			wantAcks:    [][]uint64{{7}},
+		},
+		{
+			name:        "zero max msgs relays one by one",
+			chainID:     "rollapp",
+			maxMsgs:     0,
+			wantPackets: [][]uint64{{3}},
+		},
+		{
+			name:    "nothing on the chain",
+			chainID: "other",
+			maxMsgs: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			packets, acks := selectedChunks(selected, tt.chainID, tt.maxMsgs)
+			if !slices.EqualFunc(packets, tt.wantPackets, slices.Equal) {
+				t.Fatalf("expected packets %v, got %v", tt.wantPackets, packets)
+			}
+			if !slices.EqualFunc(acks, tt.wantAcks, slices.Equal) {
+				t.Fatalf("expected acks %v, got %v", tt.wantAcks, acks)
+			}
+		})
+	}
+}
@@ -0,0 +1,237 @@
+package native
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	clienttypes "github.com/cosmos/ibc-go/v7/modules/core/02-client/types"
+	channeltypes "github.com/cosmos/ibc-go/v7/modules/core/04-channel/types"
+)
+
+const (
+	queryPacketCommitments      = "/ibc.core.channel.v1.Query/PacketCommitments"
+	queryUnreceivedPackets      = "/ibc.core.channel.v1.Query/UnreceivedPackets"
+	queryPacketAcknowledgements = "/ibc.core.channel.v1.Query/PacketAcknowledgements"
+	queryUnreceivedAcks         = "/ibc.core.channel.v1.Query/UnreceivedAcks"
+)
+
+// packetWithAck is a received packet along with the acknowledgement written for it
+type packetWithAck struct {
+	Packet channeltypes.Packet
+	Ack    []byte
+}
+
+// packetCommitments returns the sequences of the packets sent by the chain that were
+// not acknowledged yet
+func (c *Chain) packetCommitments(ctx context.Context) ([]uint64, error) {
+	var resp channeltypes.QueryPacketCommitmentsResponse
+	err := c.queryGRPC(ctx, queryPacketCommitments, &channeltypes.QueryPacketCommitmentsRequest{
+		PortId:     c.PortID,
+		ChannelId:  c.ChannelID,
+		Pagination: &query.PageRequest{Limit: query.MaxLimit},
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	seqs := make([]uint64, 0, len(resp.Commitments))
+	for _, pc := range resp.Commitments {
+		seqs = append(seqs, pc.Sequence)
+	}
+
+	return seqs, nil
+}
+
+// unreceivedPackets filters the sequences of the packets that were not received by the chain
+func (c *Chain) unreceivedPackets(ctx context.Context, seqs []uint64) ([]uint64, error) {
+	if len(seqs) == 0 {
+		return nil, nil
+	}
+
+	var resp channeltypes.QueryUnreceivedPacketsResponse
+	err := c.queryGRPC(ctx, queryUnreceivedPackets, &channeltypes.QueryUnreceivedPacketsRequest{
+		PortId:                    c.PortID,
+		ChannelId:                 c.ChannelID,
+		PacketCommitmentSequences: seqs,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Sequences, nil
+}
+
+// packetAcknowledgements returns the sequences of the acknowledgements written by the chain
+func (c *Chain) packetAcknowledgements(ctx context.Context) ([]uint64, error) {
+	var resp channeltypes.QueryPacketAcknowledgementsResponse
+	err := c.queryGRPC(
+		ctx,
+		queryPacketAcknowledgements,
+		&channeltypes.QueryPacketAcknowledgementsRequest{
+			PortId:     c.PortID,
+			ChannelId:  c.ChannelID,
+			Pagination: &query.PageRequest{Limit: query.MaxLimit},
+		},
+		&resp,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	seqs := make([]uint64, 0, len(resp.Acknowledgements))
+	for _, a := range resp.Acknowledgements {
+		seqs = append(seqs, a.Sequence)
+	}
+
+	return seqs, nil
+}
+
+// unreceivedAcks filters the sequences of the packets sent by the chain whose
+// acknowledgement was not delivered yet
+func (c *Chain) unreceivedAcks(ctx context.Context, seqs []uint64) ([]uint64, error) {
+	if len(seqs) == 0 {
+		return nil, nil
+	}
+
+	var resp channeltypes.QueryUnreceivedAcksResponse
+	err := c.queryGRPC(ctx, queryUnreceivedAcks, &channeltypes.QueryUnreceivedAcksRequest{
+		PortId:             c.PortID,
+		ChannelId:          c.ChannelID,
+		PacketAckSequences: seqs,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Sequences, nil
+}
+
+// sentPacket searches the send_packet event of the packet sent by the chain
+func (c *Chain) sentPacket(ctx context.Context, seq uint64) (*channeltypes.Packet, error) {
+	attrs, err := c.searchPacketEvent(
+		ctx,
+		channeltypes.EventTypeSendPacket,
+		channeltypes.AttributeKeySrcChannel,
+		seq,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return packetFromAttributes(attrs)
+}
+
+// writtenAck searches the write_acknowledgement event of the packet received by the chain
+func (c *Chain) writtenAck(ctx context.Context, seq uint64) (*packetWithAck, error) {
+	attrs, err := c.searchPacketEvent(
+		ctx,
+		channeltypes.EventTypeWriteAck,
+		channeltypes.AttributeKeyDstChannel,
+		seq,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	packet, err := packetFromAttributes(attrs)
+	if err != nil {
+		return nil, err
+	}
+	ack, err := hex.DecodeString(attrs[channeltypes.AttributeKeyAckHex])
+	if err != nil {
+		return nil, fmt.Errorf("invalid acknowledgement: %w", err)
+	}
+
+	return &packetWithAck{Packet: *packet, Ack: ack}, nil
+}
+
+func (c *Chain) searchPacketEvent(
+	ctx context.Context,
+	eventType, channelAttr string,
+	seq uint64,
+) (map[string]string, error) {
+	q := fmt.Sprintf(
+		"%s.%s='%s' AND %s.%s='%d'",
+		eventType, channelAttr, c.ChannelID,
+		eventType, channeltypes.AttributeKeySequence, seq,
+	)
+
+	page, perPage := 1, 1
+	res, err := c.client.TxSearch(ctx, q, false, &page, &perPage, "desc")
+	if err != nil {
+		return nil, err
+	}
+	if len(res.Txs) == 0 {
+		return nil, fmt.Errorf("%s event of packet %d not found on %s", eventType, seq, c.ID)
+	}
+
+	for _, e := range res.Txs[0].TxResult.Events {
+		if e.Type != eventType {
+			continue
+		}
+		attrs := eventAttributes(e)
+		if attrs[channeltypes.AttributeKeySequence] == strconv.FormatUint(seq, 10) &&
+			attrs[channelAttr] == c.ChannelID {
+			return attrs, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%s event of packet %d not found on %s", eventType, seq, c.ID)
+}
+
+func eventAttributes(e abci.Event) map[string]string {
+	attrs := make(map[string]string, len(e.Attributes))
+	for _, a := range e.Attributes {
+		attrs[a.Key] = a.Value
+	}
+	return attrs
+}
+
+func packetFromAttributes(attrs map[string]string) (*channeltypes.Packet, error) {
+	seq, err := strconv.ParseUint(attrs[channeltypes.AttributeKeySequence], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid packet sequence: %w", err)
+	}
+
+	data, err := hex.DecodeString(attrs[channeltypes.AttributeKeyDataHex])
+	if err != nil {
+		return nil, fmt.Errorf("invalid packet data: %w", err)
+	}
+
+	timeoutHeight := clienttypes.ZeroHeight()
+	if th := attrs[channeltypes.AttributeKeyTimeoutHeight]; th != "" && th != "0-0" {
+		timeoutHeight, err = clienttypes.ParseHeight(th)
+		if err != nil {
+			return nil, fmt.Errorf("invalid packet timeout height: %w", err)
+		}
+	}
+
+	var timeoutTimestamp uint64
+	if ts := attrs[channeltypes.AttributeKeyTimeoutTimestamp]; ts != "" {
+		timeoutTimestamp, err = strconv.ParseUint(ts, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid packet timeout timestamp: %w", err)
+		}
+	}
+
+	packet := channeltypes.NewPacket(
+		data,
+		seq,
+		attrs[channeltypes.AttributeKeySrcPort],
+		attrs[channeltypes.AttributeKeySrcChannel],
+		attrs[channeltypes.AttributeKeyDstPort],
+		attrs[channeltypes.AttributeKeyDstChannel],
+		timeoutHeight,
+		timeoutTimestamp,
+	)
+	if packet.SourcePort == "" || packet.DestinationChannel == "" {
+		return nil, errors.New("incomplete packet event")
+	}
+
+	return &packet, nil
+}
@@ -0,0 +1,213 @@
+package native
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	cosmossdkmath "cosmossdk.io/math"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/std"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	authtx "github.com/cosmos/cosmos-sdk/x/auth/tx"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	vestingtypes "github.com/cosmos/cosmos-sdk/x/auth/vesting/types"
+	clienttypes "github.com/cosmos/ibc-go/v7/modules/core/02-client/types"
+	channeltypes "github.com/cosmos/ibc-go/v7/modules/core/04-channel/types"
+	ibctm "github.com/cosmos/ibc-go/v7/modules/light-clients/07-tendermint"
+	ethermintcodec "github.com/evmos/ethermint/crypto/codec"
+	ethermint "github.com/evmos/ethermint/types"
+)
+
+const (
+	queryAccount  = "/cosmos.auth.v1beta1.Query/Account"
+	querySimulate = "/cosmos.tx.v1beta1.Service/Simulate"
+)
+
+var (
+	interfaceRegistry = newInterfaceRegistry()
+	cdc               = codec.NewProtoCodec(interfaceRegistry)
+	txConfig          = authtx.NewTxConfig(cdc, authtx.DefaultSignModes)
+)
+
+// newInterfaceRegistry registers the ibc messages and the accounts and keys of both the
+// cosmos and the evm chains
+func newInterfaceRegistry() codectypes.InterfaceRegistry {
+	registry := codectypes.NewInterfaceRegistry()
+	std.RegisterInterfaces(registry)
+	authtypes.RegisterInterfaces(registry)
+	vestingtypes.RegisterInterfaces(registry)
+	clienttypes.RegisterInterfaces(registry)
+	channeltypes.RegisterInterfaces(registry)
+	ibctm.RegisterInterfaces(registry)
+	ethermintcodec.RegisterInterfaces(registry)
+	ethermint.RegisterInterfaces(registry)
+
+	return registry
+}
+
+// txInclusionTimeout is how long a broadcast transaction is waited for
+const txInclusionTimeout = time.Minute
+
+// txResult is the outcome of a transaction included in a block
+type txResult struct {
+	Hash    string
+	GasUsed int64
+	Fees    sdk.Coins
+}
+
+// broadcast builds a transaction with the messages, signs it with the relayer key of the
+// chain and broadcasts it through the rpc client, it returns once the transaction is
+// included in a block. The gas of the transaction is simulated by the chain and
+// multiplied by the gas adjustment
+func (c *Chain) broadcast(ctx context.Context, msgs ...sdk.Msg) (*txResult, error) {
+	addr, err := c.Address()
+	if err != nil {
+		return nil, err
+	}
+	accNum, seq, err := c.account(ctx, addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query relayer account on %s: %w", c.ID, err)
+	}
+
+	factory := tx.Factory{}.
+		WithTxConfig(txConfig).
+		WithKeybase(c.keyring).
+		WithFromName(c.KeyName).
+		WithChainID(c.ID).
+		WithAccountNumber(accNum).
+		WithSequence(seq).
+		WithMemo("roller").
+		WithSignMode(signing.SignMode_SIGN_MODE_DIRECT)
+
+	gasUsed, err := c.simulate(ctx, factory, msgs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to simulate transaction on %s: %w", c.ID, err)
+	}
+	gas := uint64(float64(gasUsed) * c.GasAdjustment)
+
+	fees, err := txFees(c.GasPrices, gas)
+	if err != nil {
+		return nil, err
+	}
+	factory = factory.WithGas(gas).WithFees(fees.String())
+
+	builder, err := factory.BuildUnsignedTx(msgs...)
+	if err != nil {
+		return nil, err
+	}
+	err = tx.Sign(factory, c.KeyName, builder, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign transaction on %s: %w", c.ID, err)
+	}
+	txBytes, err := txConfig.TxEncoder()(builder.GetTx())
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.client.BroadcastTxSync(ctx, txBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to broadcast transaction on %s: %w", c.ID, err)
+	}
+	if res.Code != 0 {
+		return nil, fmt.Errorf(
+			"transaction %s rejected by %s with code %d: %s",
+			res.Hash,
+			c.ID,
+			res.Code,
+			res.Log,
+		)
+	}
+
+	result, err := c.waitForTx(ctx, res.Hash)
+	if err != nil {
+		return nil, err
+	}
+	result.Fees = fees
+
+	return result, nil
+}
+
+// simulate returns the gas used by the messages when executed by the chain
+func (c *Chain) simulate(ctx context.Context, factory tx.Factory, msgs ...sdk.Msg) (uint64, error) {
+	simTx, err := factory.WithSimulateAndExecute(true).BuildSimTx(msgs...)
+	if err != nil {
+		return 0, err
+	}
+
+	var resp txtypes.SimulateResponse
+	err = c.queryGRPC(ctx, querySimulate, &txtypes.SimulateRequest{TxBytes: simTx}, &resp)
+	if err != nil {
+		return 0, err
+	}
+	if resp.GasInfo == nil {
+		return 0, fmt.Errorf("no gas info in the simulation of %s", c.ID)
+	}
+
+	return resp.GasInfo.GasUsed, nil
+}
+
+// account returns the account number and the sequence of the address
+func (c *Chain) account(ctx context.Context, addr string) (uint64, uint64, error) {
+	var resp authtypes.QueryAccountResponse
+	err := c.queryGRPC(ctx, queryAccount, &authtypes.QueryAccountRequest{Address: addr}, &resp)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var acc authtypes.AccountI
+	err = interfaceRegistry.UnpackAny(resp.Account, &acc)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return acc.GetAccountNumber(), acc.GetSequence(), nil
+}
+
+// waitForTx polls the chain until the transaction is included in a block
+func (c *Chain) waitForTx(ctx context.Context, hash []byte) (*txResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, txInclusionTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("transaction %X was not included on %s: %w", hash, c.ID, ctx.Err())
+		case <-ticker.C:
+		}
+
+		res, err := c.client.Tx(ctx, hash, false)
+		if err != nil {
+			// the transaction is not indexed yet
+			continue
+		}
+		if res.TxResult.Code != 0 {
+			return nil, fmt.Errorf(
+				"transaction %X failed on %s with code %d: %s",
+				hash,
+				c.ID,
+				res.TxResult.Code,
+				res.TxResult.Log,
+			)
+		}
+
+		return &txResult{Hash: fmt.Sprintf("%X", hash), GasUsed: res.TxResult.GasUsed}, nil
+	}
+}
+
+func txFees(gasPrices string, gas uint64) (sdk.Coins, error) {
+	gp, err := sdk.ParseDecCoin(gasPrices)
+	if err != nil {
+		return nil, fmt.Errorf("invalid gas price '%s': %w", gasPrices, err)
+	}
+
+	amount := gp.Amount.MulInt(cosmossdkmath.NewIntFromUint64(gas)).Ceil().TruncateInt()
+	return sdk.NewCoins(sdk.NewCoin(gp.Denom, amount)), nil
+}
@@ -0,0 +1,194 @@
+package relayer
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var PacketKinds = struct {
+	Packet string
+	Ack    string
+}{
+	Packet: "packet",
+	Ack:    "ack",
+}
+
+// PendingPacket represents a packet or an acknowledgement that is waiting to be relayed
+type PendingPacket struct {
+	Sequence uint64 `json:"sequence"`
+	Kind     string `json:"kind"`
+	// ChainID is the chain the packet or the acknowledgement was written on
+	ChainID string    `json:"chain_id"`
+	Height  int64     `json:"height"`
+	Time    time.Time `json:"time"`
+	Amount  string    `json:"amount"`
+	Denom   string    `json:"denom"`
+}
+
+type fungibleTokenPacketData struct {
+	Amount string `json:"amount"`
+	Denom  string `json:"denom"`
+}
+
+type txSearchResponse struct {
+	Result struct {
+		Txs []struct {
+			Height   string `json:"height"`
+			TxResult struct {
+				Events []struct {
+					Type       string `json:"type"`
+					Attributes []struct {
+						Key   string `json:"key"`
+						Value string `json:"value"`
+					} `json:"attributes"`
+				} `json:"events"`
+			} `json:"tx_result"`
+		} `json:"txs"`
+	} `json:"result"`
+}
+
+type blockResponse struct {
+	Result struct {
+		Block struct {
+			Header struct {
+				Time time.Time `json:"time"`
+			} `json:"header"`
+		} `json:"block"`
+	} `json:"result"`
+}
+
+// PendingPackets returns the packets and acknowledgements of the path that are waiting
+// to be relayed, the active channel has to be loaded before calling it
+func (r *Relayer) PendingPackets() ([]PendingPacket, error) {
+	packets, err := r.queryUnrelayed("unrelayed-packets")
+	if err != nil {
+		return nil, err
+	}
+	acks, err := r.queryUnrelayed("unrelayed-acknowledgements")
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []PendingPacket
+	add := func(kind, chainID, rpc, channel string, seqs []uint64) {
+		for _, seq := range seqs {
+			pp := PendingPacket{Sequence: seq, Kind: kind, ChainID: chainID}
+			// the age and amount are best effort, nodes with tx indexing disabled
+			// can't return them
+			err := fillPacketInfo(&pp, rpc, channel)
+			if err != nil {
+				r.logger.Printf("failed to query %s %d on %s: %v", kind, seq, chainID, err)
+			}
+			pending = append(pending, pp)
+		}
+	}
+
+	add(PacketKinds.Packet, r.Hub.ID, r.Hub.RpcUrl, r.SrcChannel, packets.Hub)
+	add(PacketKinds.Packet, r.Rollapp.ID, r.Rollapp.RpcUrl, r.DstChannel, packets.Rollapp)
+	add(PacketKinds.Ack, r.Hub.ID, r.Hub.RpcUrl, r.SrcChannel, acks.Hub)
+	add(PacketKinds.Ack, r.Rollapp.ID, r.Rollapp.RpcUrl, r.DstChannel, acks.Rollapp)
+
+	return pending, nil
+}
+
+// fillPacketInfo searches the transaction that emitted the packet or the acknowledgement
+// and sets the height, time and token amount of the pending packet
+func fillPacketInfo(pp *PendingPacket, rpc, channel string) error {
+	event, channelAttr := "send_packet", "packet_src_channel"
+	if pp.Kind == PacketKinds.Ack {
+		event, channelAttr = "write_acknowledgement", "packet_dst_channel"
+	}
+
+	query := fmt.Sprintf(
+		"%s.%s='%s' AND %s.packet_sequence='%d'",
+		event, channelAttr, channel, event, pp.Sequence,
+	)
+	endpoint := fmt.Sprintf(
+		"%s/tx_search?query=%s",
+		strings.TrimSuffix(rpc, "/"),
+		url.QueryEscape(fmt.Sprintf("%q", query)),
+	)
+
+	var resp txSearchResponse
+	err := getJSON(endpoint, &resp)
+	if err != nil {
+		return err
+	}
+	if len(resp.Result.Txs) == 0 {
+		return errors.New("transaction not found")
+	}
+
+	tx := resp.Result.Txs[0]
+	pp.Height, _ = strconv.ParseInt(tx.Height, 10, 64)
+
+	for _, e := range tx.TxResult.Events {
+		if e.Type != event {
+			continue
+		}
+		for _, a := range e.Attributes {
+			if attrValue(a.Key) != "packet_data" {
+				continue
+			}
+			var data fungibleTokenPacketData
+			if json.Unmarshal([]byte(attrValue(a.Value)), &data) == nil {
+				pp.Amount = data.Amount
+				pp.Denom = data.Denom
+			}
+		}
+	}
+
+	var block blockResponse
+	err = getJSON(
+		fmt.Sprintf("%s/block?height=%d", strings.TrimSuffix(rpc, "/"), pp.Height),
+		&block,
+	)
+	if err != nil {
+		return err
+	}
+	pp.Time = block.Result.Block.Header.Time
+
+	return nil
+}
+
+// attrValue decodes the event attributes of older CometBFT versions which are base64 encoded
+func attrValue(v string) string {
+	decoded, err := base64.StdEncoding.DecodeString(v)
+	if err != nil || !isPrintable(decoded) {
+		return v
+	}
+	return string(decoded)
+}
+
+func isPrintable(b []byte) bool {
+	for _, c := range b {
+		if c < 0x20 || c > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+func getJSON(endpoint string, v any) error {
+	c := http.Client{Timeout: 10 * time.Second}
+	resp, err := c.Get(endpoint)
+	if err != nil {
+		return err
+	}
+	// nolint: errcheck
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(body, v)
+}
@@ -0,0 +1,243 @@
+package relayer
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	cosmossdkmath "cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/utils/config/cronjobs"
+	"github.com/dymensionxyz/roller/utils/config/tomlconfig"
+	"github.com/dymensionxyz/roller/utils/config/yamlconfig"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/roller"
+)
+
+const (
+	DefaultMaxMsgs       = 100
+	DefaultFlushInterval = 15 * time.Minute
+)
+
+// LoadPolicy returns the relayer policy of roller.toml, an empty policy is returned
+// when the roller config doesn't exist (e.g. on relayer-only machines)
+func LoadPolicy(home string) (roller.RelayerPolicy, error) {
+	ok, err := filesystem.DoesFileExist(roller.GetConfigPath(home))
+	if err != nil || !ok {
+		return roller.RelayerPolicy{}, err
+	}
+
+	rollerData, err := roller.LoadConfig(home)
+	if err != nil {
+		return roller.RelayerPolicy{}, err
+	}
+
+	return rollerData.Relayer, nil
+}
+
+// ValidatePolicy verifies that all the values of the policy can be applied
+func ValidatePolicy(p roller.RelayerPolicy) error {
+	for chainID, ga := range p.GasAdjustment {
+		if ga <= 0 {
+			return fmt.Errorf("gas adjustment of %s must be positive, got %v", chainID, ga)
+		}
+	}
+
+	for chainID, gp := range p.GasPrices {
+		_, err := sdk.ParseDecCoin(gp)
+		if err != nil {
+			return fmt.Errorf("invalid gas price of %s '%s': %w", chainID, gp, err)
+		}
+	}
+
+	if p.MaxMsgs < 0 {
+		return fmt.Errorf("max msgs must not be negative, got %d", p.MaxMsgs)
+	}
+
+	err := ValidateEngine(Engine(p))
+	if err != nil {
+		return err
+	}
+
+	_, err = FlushSchedule(p)
+	if err != nil {
+		return err
+	}
+
+	_, err = MinPacketValues(p)
+	return err
+}
+
+// Engine returns the relaying engine of the policy
+func Engine(p roller.RelayerPolicy) string {
+	if p.Engine == "" {
+		return Engines.Rly
+	}
+	return p.Engine
+}
+
+// ValidateEngine verifies that the engine is supported
+func ValidateEngine(engine string) error {
+	if engine != Engines.Rly && engine != Engines.Native {
+		return fmt.Errorf(
+			"unsupported engine '%s', supported engines: %s, %s",
+			engine,
+			Engines.Rly,
+			Engines.Native,
+		)
+	}
+	return nil
+}
+
+// SaveEngine persists the relaying engine in the relayer policy of roller.toml
+func SaveEngine(home, engine string) error {
+	return tomlconfig.UpdateFieldInFile(roller.GetConfigPath(home), "Relayer.engine", engine)
+}
+
+// MaxMsgs returns the maximum number of messages in a relayer transaction
+func MaxMsgs(p roller.RelayerPolicy) int {
+	if p.MaxMsgs == 0 {
+		return DefaultMaxMsgs
+	}
+	return p.MaxMsgs
+}
+
+// FlushSchedule returns the cron schedule of the flush interval of the policy, cron
+// only supports whole minutes, and whole hours above one hour
+func FlushSchedule(p roller.RelayerPolicy) (string, error) {
+	interval := DefaultFlushInterval
+	if p.FlushInterval != "" {
+		d, err := time.ParseDuration(p.FlushInterval)
+		if err != nil {
+			return "", fmt.Errorf("invalid flush interval '%s': %w", p.FlushInterval, err)
+		}
+		interval = d
+	}
+
+	switch {
+	case interval < time.Minute || interval%time.Minute != 0:
+		return "", fmt.Errorf("flush interval must be a whole number of minutes, got %s", interval)
+	case interval < time.Hour:
+		return fmt.Sprintf("*/%d * * * *", int(interval.Minutes())), nil
+	case interval%time.Hour == 0 && interval < 24*time.Hour:
+		return fmt.Sprintf("0 */%d * * *", int(interval.Hours())), nil
+	case interval == 24*time.Hour:
+		return "0 0 * * *", nil
+	default:
+		return "", fmt.Errorf(
+			"flush interval above one hour must be a whole number of hours up to 24h, got %s",
+			interval,
+		)
+	}
+}
+
+// FlushCommand returns the command that flushes all the relayer paths
+func FlushCommand(relayerHome string, p roller.RelayerPolicy) string {
+	return fmt.Sprintf(
+		"%s tx flush --max-msgs %d --home %s",
+		consts.Executables.Relayer,
+		MaxMsgs(p),
+		relayerHome,
+	)
+}
+
+// ScheduleFlush replaces the cron job that flushes the relayer paths. The native engine
+// relays all the pending packets continuously, so no flush is scheduled for it
+func ScheduleFlush(relayerHome string, p roller.RelayerPolicy) error {
+	_, err := cronjobs.RemoveFunc(func(entry string) bool {
+		return IsFlushCronEntry(entry, relayerHome)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to remove previous flush cronjob: %w", err)
+	}
+	if Engine(p) == Engines.Native {
+		return nil
+	}
+
+	schedule, err := FlushSchedule(p)
+	if err != nil {
+		return err
+	}
+
+	return cronjobs.Add(schedule, FlushCommand(relayerHome, p))
+}
+
+// IsFlushCronEntry returns true when the cron entry runs the rly flush of the relayer home,
+// the flushes of the other relayer homes are left in place
+func IsFlushCronEntry(entry, relayerHome string) bool {
+	fields := strings.Fields(entry)
+	i := slices.Index(fields, "flush")
+	if i < 2 || fields[i-1] != "tx" ||
+		filepath.Base(fields[i-2]) != filepath.Base(consts.Executables.Relayer) {
+		return false
+	}
+
+	home := slices.Index(fields, "--home")
+	return home >= 0 && home+1 < len(fields) &&
+		filepath.Clean(fields[home+1]) == filepath.Clean(relayerHome)
+}
+
+// MinPacketValues returns the minimal transfer amount to relay, keyed by denom
+func MinPacketValues(p roller.RelayerPolicy) (map[string]cosmossdkmath.Int, error) {
+	values := make(map[string]cosmossdkmath.Int, len(p.MinPacketValue))
+	for denom, v := range p.MinPacketValue {
+		amount, ok := cosmossdkmath.NewIntFromString(v)
+		if !ok || amount.IsNegative() {
+			return nil, fmt.Errorf("invalid minimal packet value of %s '%s'", denom, v)
+		}
+		values[denom] = amount
+	}
+
+	return values, nil
+}
+
+// MinPacketValueIgnored returns true when the policy sets minimal packet values that are
+// not enforced, only the native engine enforces them
+func MinPacketValueIgnored(p roller.RelayerPolicy) bool {
+	return len(p.MinPacketValue) > 0 && Engine(p) != Engines.Native
+}
+
+// ApplyPolicy writes the gas settings of the policy to the rly config file. The chains of
+// the policy that are not part of the relayer config are returned, they are not applied
+func ApplyPolicy(configFilePath string, p roller.RelayerPolicy) ([]string, error) {
+	err := ValidatePolicy(p)
+	if err != nil {
+		return nil, err
+	}
+
+	var rlyCfg Config
+	err = rlyCfg.Load(configFilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var skipped []string
+	updates := map[string]interface{}{}
+	for chainID, ga := range p.GasAdjustment {
+		if _, ok := rlyCfg.Chains[chainID]; !ok {
+			skipped = append(skipped, chainID)
+			continue
+		}
+		updates[fmt.Sprintf("chains.%s.value.gas-adjustment", chainID)] = ga
+	}
+	for chainID, gp := range p.GasPrices {
+		if _, ok := rlyCfg.Chains[chainID]; !ok {
+			if !slices.Contains(skipped, chainID) {
+				skipped = append(skipped, chainID)
+			}
+			continue
+		}
+		updates[fmt.Sprintf("chains.%s.value.gas-prices", chainID)] = strings.TrimSpace(gp)
+	}
+	slices.Sort(skipped)
+
+	if len(updates) == 0 {
+		return skipped, nil
+	}
+
+	return skipped, yamlconfig.UpdateNestedYAML(configFilePath, updates)
+}
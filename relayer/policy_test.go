@@ -0,0 +1,176 @@
+package relayer
+
+import (
+	"fmt"
+	"testing"
+
+	cosmossdkmath "cosmossdk.io/math"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/utils/roller"
+)
+
+func TestFlushSchedule(t *testing.T) {
+	tests := []struct {
+		name     string
+		interval string
+		want     string
+		wantErr  bool
+	}{
+		{name: "default", want: "*/15 * * * *"},
+		{name: "minutes", interval: "5m", want: "*/5 * * * *"},
+		{name: "hours", interval: "2h", want: "0 */2 * * *"},
+		{name: "one hour", interval: "60m", want: "0 */1 * * *"},
+		{name: "daily", interval: "24h", want: "0 0 * * *"},
+		{name: "seconds", interval: "30s", wantErr: true},
+		{name: "partial minutes", interval: "90s", wantErr: true},
+		{name: "partial hours", interval: "90m", wantErr: true},
+		{name: "above a day", interval: "48h", wantErr: true},
+		{name: "invalid", interval: "often", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FlushSchedule(roller.RelayerPolicy{FlushInterval: tt.interval})
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got schedule %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestIsFlushCronEntry(t *testing.T) {
+	home := "/root/.roller/relayer"
+	rly := consts.Executables.Relayer
+
+	tests := []struct {
+		name  string
+		entry string
+		want  bool
+	}{
+		{
+			name:  "current flush",
+			entry: "*/15 * * * * " + FlushCommand(home, roller.RelayerPolicy{}),
+			want:  true,
+		},
+		{
+			name:  "path flush",
+			entry: fmt.Sprintf("*/15 * * * * %s tx flush hub-rollapp --max-msgs 100 --home %s", rly, home),
+			want:  true,
+		},
+		{
+			name:  "trailing slash",
+			entry: fmt.Sprintf("*/15 * * * * %s tx flush --home %s/", rly, home),
+			want:  true,
+		},
+		{
+			name:  "other relayer home",
+			entry: fmt.Sprintf("*/15 * * * * %s tx flush --home %s-2", rly, home),
+		},
+		{
+			name:  "default rly home",
+			entry: fmt.Sprintf("*/15 * * * * %s tx flush", rly),
+		},
+		{
+			name:  "other binary",
+			entry: fmt.Sprintf("*/15 * * * * /usr/bin/hermes tx flush --home %s", home),
+		},
+		{
+			name:  "other job",
+			entry: fmt.Sprintf("0 0 * * * %s keys list --home %s", rly, home),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := IsFlushCronEntry(tt.entry, home)
+			if got != tt.want {
+				t.Fatalf("expected %v for %q, got %v", tt.want, tt.entry, got)
+			}
+		})
+	}
+}
+
+func TestMinPacketValues(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  map[string]string
+		want    map[string]cosmossdkmath.Int
+		wantErr bool
+	}{
+		{name: "empty", want: map[string]cosmossdkmath.Int{}},
+		{
+			name:   "valid",
+			values: map[string]string{"adym": "1000", "arax": "0"},
+			want: map[string]cosmossdkmath.Int{
+				"adym": cosmossdkmath.NewInt(1000),
+				"arax": cosmossdkmath.ZeroInt(),
+			},
+		},
+		{name: "negative", values: map[string]string{"adym": "-1"}, wantErr: true},
+		{name: "not a number", values: map[string]string{"adym": "1dym"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MinPacketValues(roller.RelayerPolicy{MinPacketValue: tt.values})
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+			for denom, v := range tt.want {
+				if !got[denom].Equal(v) {
+					t.Fatalf("expected %s for %s, got %v", v, denom, got[denom])
+				}
+			}
+		})
+	}
+}
+
+func TestMinPacketValueIgnored(t *testing.T) {
+	minValues := map[string]string{"adym": "1000"}
+
+	tests := []struct {
+		name string
+		p    roller.RelayerPolicy
+		want bool
+	}{
+		{name: "no min values", p: roller.RelayerPolicy{}},
+		{name: "default engine", p: roller.RelayerPolicy{MinPacketValue: minValues}, want: true},
+		{
+			name: "rly engine",
+			p:    roller.RelayerPolicy{MinPacketValue: minValues, Engine: Engines.Rly},
+			want: true,
+		},
+		{
+			name: "native engine",
+			p:    roller.RelayerPolicy{MinPacketValue: minValues, Engine: Engines.Native},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := MinPacketValueIgnored(tt.p)
+			if got != tt.want {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
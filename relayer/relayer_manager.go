@@ -1,21 +1,18 @@
 package relayer
 
 import (
-	"errors"
-	"fmt"
 	"io"
 	"log"
-	"os"
-	"path/filepath"
 
 	"github.com/dymensionxyz/roller/cmd/consts"
-	"github.com/dymensionxyz/roller/utils/roller"
 )
 
 type Relayer struct {
 	RollerHome     string
 	RelayerHome    string
 	ConfigFilePath string
+	// Path is the name of the relayer path between the hub and the rollapp
+	Path string
 
 	Rollapp consts.RollappData
 	Hub     consts.HubData
@@ -39,6 +36,7 @@ func NewRelayer(home string, raData consts.RollappData, hd consts.HubData) *Rela
 		RollerHome:     home,
 		RelayerHome:    relayerHome,
 		ConfigFilePath: relayerConfigPath,
+		Path:           consts.DefaultRelayerPath,
 
 		Rollapp: raData,
 		Hub:     hd,
@@ -51,31 +49,8 @@ func (r *Relayer) SetLogger(logger *log.Logger) {
 	r.logger = logger
 }
 
-// TODO: review the servicemanager.Service implementation
-func (r *Relayer) GetRelayerStatus(roller.RollappConfig) string {
-	if r.ChannelReady() {
-		return fmt.Sprintf(
-			"Active Channels:\nrollapp: %s\n<->\nhub: %s",
-			r.SrcChannel,
-			r.DstChannel,
-		)
-	}
-	bytes, err := os.ReadFile(r.StatusFilePath())
-	if err != nil {
-		if errors.Is(err, os.ErrNotExist) {
-			return "Starting..."
-		}
-	}
-	return string(bytes)
-}
-
-func (r *Relayer) WriteRelayerStatus(status string) error {
-	// nolint:gofumpt
-	return os.WriteFile(r.StatusFilePath(), []byte(status), 0o644)
-}
-
-func (r *Relayer) StatusFilePath() string {
-	return filepath.Join(r.RollerHome, consts.ConfigDirName.Relayer, "relayer_status.txt")
+func (r *Relayer) SetPath(path string) {
+	r.Path = path
 }
 
 type ConnectionChannels struct {
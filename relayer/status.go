@@ -0,0 +1,277 @@
+package relayer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/utils/bash"
+	"github.com/dymensionxyz/roller/utils/keys"
+	"github.com/dymensionxyz/roller/utils/tracing"
+)
+
+// PathStatus represents the packet level status of a single relayer path
+type PathStatus struct {
+	Path           string             `json:"path"`
+	HubID          string             `json:"hub_id"`
+	RollappID      string             `json:"rollapp_id"`
+	HubChannel     string             `json:"hub_channel"`
+	RollappChannel string             `json:"rollapp_channel"`
+	Packets        UnrelayedSequences `json:"unrelayed_packets"`
+	Acks           UnrelayedSequences `json:"unrelayed_acks"`
+	Clients        []ClientStatus     `json:"clients"`
+	Balances       []AccountBalance   `json:"balances"`
+	// Metrics are the relaying metrics of the path channels derived from the rly logs
+	Metrics []ChannelMetrics `json:"metrics,omitempty"`
+	// Errors contains the queries that failed, the rest of the status is still
+	// reported so that a single unreachable endpoint doesn't hide everything else
+	Errors []string `json:"errors,omitempty"`
+}
+
+// UnrelayedSequences holds the packet sequences that are pending on each end of the path,
+// the field names follow the output of 'rly q unrelayed-packets'
+type UnrelayedSequences struct {
+	Hub     []uint64 `json:"src"`
+	Rollapp []uint64 `json:"dst"`
+}
+
+// ClientStatus represents the light client that tracks the counterparty chain
+type ClientStatus struct {
+	// ChainID is the chain the client lives on
+	ChainID      string    `json:"chain_id"`
+	ClientID     string    `json:"client_id"`
+	LatestHeight uint64    `json:"latest_height"`
+	LastUpdate   time.Time `json:"last_update"`
+	// TrustingPeriod and TrustingPeriodLeft are in seconds
+	TrustingPeriod     int64 `json:"trusting_period"`
+	TrustingPeriodLeft int64 `json:"trusting_period_left"`
+	Expired            bool  `json:"expired"`
+	Frozen             bool  `json:"frozen"`
+}
+
+type AccountBalance struct {
+	ChainID string `json:"chain_id"`
+	Address string `json:"address"`
+	Balance string `json:"balance"`
+}
+
+type clientStateResponse struct {
+	ClientState struct {
+		ChainID        string `json:"chain_id"`
+		TrustingPeriod string `json:"trusting_period"`
+		LatestHeight   struct {
+			RevisionHeight string `json:"revision_height"`
+		} `json:"latest_height"`
+		FrozenHeight struct {
+			RevisionHeight string `json:"revision_height"`
+		} `json:"frozen_height"`
+	} `json:"client_state"`
+}
+
+type consensusStateResponse struct {
+	ConsensusState struct {
+		Timestamp time.Time `json:"timestamp"`
+	} `json:"consensus_state"`
+}
+
+// GetPathStatus collects the unrelayed packets and acknowledgements, the state of
+// both light clients and the relayer balances of the path. The active channel
+// has to be loaded before calling it
+func (r *Relayer) GetPathStatus(p *Path) *PathStatus {
+	s := &PathStatus{
+		Path:           r.Path,
+		HubID:          r.Hub.ID,
+		RollappID:      r.Rollapp.ID,
+		HubChannel:     r.SrcChannel,
+		RollappChannel: r.DstChannel,
+	}
+
+	if r.ChannelReady() {
+		packets, err := r.queryUnrelayed("unrelayed-packets")
+		if err != nil {
+			s.Errors = append(s.Errors, fmt.Sprintf("unrelayed packets: %v", err))
+		} else {
+			s.Packets = *packets
+		}
+
+		acks, err := r.queryUnrelayed("unrelayed-acknowledgements")
+		if err != nil {
+			s.Errors = append(s.Errors, fmt.Sprintf("unrelayed acks: %v", err))
+		} else {
+			s.Acks = *acks
+		}
+	} else {
+		s.Errors = append(s.Errors, "no active channel found")
+	}
+
+	clients, errs := r.QueryPathClients(p)
+	s.Clients = clients
+	s.Errors = append(s.Errors, errs...)
+
+	accData, err := GetPathAccountsData(r.RollerHome, r.Hub, r.Rollapp)
+	if err != nil {
+		s.Errors = append(s.Errors, fmt.Sprintf("balances: %v", err))
+	} else {
+		s.Balances = accountBalances(accData, r.Hub.ID, r.Rollapp.ID)
+	}
+
+	return s
+}
+
+// QueryPathClients queries the status of the hub and rollapp clients of the path,
+// the clients that couldn't be queried are reported as errors
+func (r *Relayer) QueryPathClients(p *Path) ([]ClientStatus, []string) {
+	var clients []ClientStatus
+	var errs []string
+
+	if p != nil && p.Src != nil && p.Src.ClientID != "" {
+		c, err := QueryClientStatus(
+			consts.Executables.Dymension,
+			r.Hub.RpcUrl,
+			r.Hub.ID,
+			p.Src.ClientID,
+		)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("hub client: %v", err))
+		} else {
+			clients = append(clients, *c)
+		}
+	}
+
+	if p != nil && p.Dst != nil && p.Dst.ClientID != "" {
+		c, err := QueryClientStatus(
+			consts.Executables.RollappEVM,
+			r.Rollapp.RpcUrl,
+			r.Rollapp.ID,
+			p.Dst.ClientID,
+		)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("rollapp client: %v", err))
+		} else {
+			clients = append(clients, *c)
+		}
+	}
+
+	return clients, errs
+}
+
+// QueryClientStatus queries the client state and the latest consensus state of
+// an ibc light client and computes how much of its trusting period is left
+func QueryClientStatus(binary, node, chainID, clientID string) (*ClientStatus, error) {
+	stateCmd := exec.Command(
+		binary,
+		"q", "ibc", "client", "state", clientID,
+		"--node", node, "--chain-id", chainID, "-o", "json",
+	)
+	ctx, span := tracing.StartChainQuery("relayer.QueryClientStatus", chainID, node)
+	out, err := bash.ExecCommandWithStdoutCtx(ctx, stateCmd)
+	tracing.End(span, err)
+	if err != nil {
+		return nil, err
+	}
+
+	var state clientStateResponse
+	err = json.Unmarshal(out.Bytes(), &state)
+	if err != nil {
+		return nil, err
+	}
+
+	consensusCmd := exec.Command(
+		binary,
+		"q", "ibc", "client", "consensus-state", clientID, "--latest-height",
+		"--node", node, "--chain-id", chainID, "-o", "json",
+	)
+	out, err = bash.ExecCommandWithStdout(consensusCmd)
+	if err != nil {
+		return nil, err
+	}
+
+	var consensus consensusStateResponse
+	err = json.Unmarshal(out.Bytes(), &consensus)
+	if err != nil {
+		return nil, err
+	}
+
+	height, err := strconv.ParseUint(state.ClientState.LatestHeight.RevisionHeight, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid client height: %w", err)
+	}
+
+	trustingPeriod, err := time.ParseDuration(state.ClientState.TrustingPeriod)
+	if err != nil {
+		return nil, fmt.Errorf("invalid trusting period: %w", err)
+	}
+
+	c := &ClientStatus{
+		ChainID:        chainID,
+		ClientID:       clientID,
+		LatestHeight:   height,
+		LastUpdate:     consensus.ConsensusState.Timestamp,
+		TrustingPeriod: int64(trustingPeriod.Seconds()),
+	}
+	left := TrustingPeriodLeft(c.LastUpdate, trustingPeriod, time.Now())
+	c.TrustingPeriodLeft = int64(left.Seconds())
+	c.Expired = left <= 0
+	frozenHeight := state.ClientState.FrozenHeight.RevisionHeight
+	c.Frozen = frozenHeight != "" && frozenHeight != "0"
+
+	return c, nil
+}
+
+// TrustingPeriodLeft returns the time left before a client that was last updated
+// at lastUpdate expires, a negative value means the client is already expired
+func TrustingPeriodLeft(lastUpdate time.Time, trustingPeriod time.Duration, now time.Time) time.Duration {
+	return lastUpdate.Add(trustingPeriod).Sub(now)
+}
+
+func (r *Relayer) queryUnrelayed(query string) (*UnrelayedSequences, error) {
+	cmd := exec.Command(
+		consts.Executables.Relayer,
+		"q", query, r.Path, r.SrcChannel,
+		"--home", r.RelayerHome,
+	)
+
+	out, err := bash.ExecCommandWithStdout(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	var seqs UnrelayedSequences
+	err = json.Unmarshal([]byte(strings.TrimSpace(out.String())), &seqs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &seqs, nil
+}
+
+func accountBalances(accData []keys.AccountData, hubID, raID string) []AccountBalance {
+	chains := []string{hubID, raID}
+	var balances []AccountBalance
+	for i, acc := range accData {
+		b := AccountBalance{
+			Address: acc.Address,
+			Balance: acc.Balance.String(),
+		}
+		if i < len(chains) {
+			b.ChainID = chains[i]
+		}
+		balances = append(balances, b)
+	}
+
+	return balances
+}
+
+// SetMetrics keeps the metrics of the hub and rollapp channels of the path
+func (s *PathStatus) SetMetrics(metrics []ChannelMetrics) {
+	for _, m := range metrics {
+		if (m.ChainID == s.HubID && m.Channel == s.HubChannel && s.HubChannel != "") ||
+			(m.ChainID == s.RollappID && m.Channel == s.RollappChannel && s.RollappChannel != "") {
+			s.Metrics = append(s.Metrics, m)
+		}
+	}
+}
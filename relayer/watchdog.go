@@ -0,0 +1,170 @@
+package relayer
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/pterm/pterm"
+
+	"github.com/dymensionxyz/roller/utils/bash"
+)
+
+const (
+	DefaultClientCheckInterval = 10 * time.Minute
+	// ClientUpdateThreshold is the fraction of the trusting period that can be left
+	// before the clients are updated proactively
+	ClientUpdateThreshold = 1.0 / 3
+)
+
+// ClientWatchdog periodically checks the light clients of the relayer paths and runs
+// 'update-clients' before they expire
+type ClientWatchdog struct {
+	home     string
+	paths    []string
+	interval time.Duration
+	logger   *log.Logger
+	// alertOnly disables the client updates, for engines that update the clients themselves
+	alertOnly bool
+	// metrics, when set, records the statuses of the checked clients
+	metrics *PacketMetrics
+}
+
+func NewClientWatchdog(
+	home string,
+	paths []string,
+	interval time.Duration,
+	logger *log.Logger,
+) *ClientWatchdog {
+	return &ClientWatchdog{
+		home:     home,
+		paths:    paths,
+		interval: interval,
+		logger:   logger,
+	}
+}
+
+// SetAlertOnly makes the watchdog only report the expired and frozen clients
+func (w *ClientWatchdog) SetAlertOnly(alertOnly bool) {
+	w.alertOnly = alertOnly
+}
+
+// SetMetrics exports the statuses of the checked clients with the relayer metrics
+func (w *ClientWatchdog) SetMetrics(metrics *PacketMetrics) {
+	w.metrics = metrics
+}
+
+// Run checks the clients every interval until the context is cancelled
+func (w *ClientWatchdog) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		w.checkPaths()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *ClientWatchdog) checkPaths() {
+	var rlyCfg Config
+	err := rlyCfg.Load(GetConfigFilePath(GetHomeDir(w.home)))
+	if err != nil {
+		w.logger.Printf("client watchdog: failed to load relayer config: %v", err)
+		return
+	}
+
+	for _, name := range w.paths {
+		p := rlyCfg.GetPathByName(name)
+		if p == nil {
+			w.logger.Printf("client watchdog: path %s not found", name)
+			continue
+		}
+
+		r := NewRelayer(w.home, *rlyCfg.RaDataForPath(name), *rlyCfg.HubDataForPath(name))
+		r.SetPath(name)
+		r.SetLogger(w.logger)
+		w.checkPath(r, p)
+	}
+}
+
+func (w *ClientWatchdog) checkPath(r *Relayer, p *Path) {
+	clients, errs := r.QueryPathClients(p)
+	for _, e := range errs {
+		w.logger.Printf("client watchdog: path %s: %s", r.Path, e)
+	}
+
+	update := false
+	for _, c := range clients {
+		if w.metrics != nil {
+			w.metrics.RecordClient(c)
+		}
+
+		if c.Expired || c.Frozen {
+			pterm.Error.Printfln(
+				"💈 client %s on %s (path %s) is %s, run 'roller relayer client recover' to recover it",
+				c.ClientID,
+				c.ChainID,
+				r.Path,
+				ClientState(c),
+			)
+			w.logger.Printf(
+				"client watchdog: ALERT client %s on %s is %s",
+				c.ClientID,
+				c.ChainID,
+				ClientState(c),
+			)
+			continue
+		}
+
+		if NeedsUpdate(c) {
+			w.logger.Printf(
+				"client watchdog: client %s on %s has %s of its trusting period left",
+				c.ClientID,
+				c.ChainID,
+				time.Duration(c.TrustingPeriodLeft)*time.Second,
+			)
+			update = true
+		}
+	}
+
+	if !update || w.alertOnly {
+		return
+	}
+
+	// update-clients updates both clients of the path
+	err := bash.ExecCmd(r.GetUpdateClientsCmd())
+	if err != nil {
+		w.logger.Printf("client watchdog: failed to update clients of %s: %v", r.Path, err)
+		return
+	}
+	w.logger.Printf("client watchdog: updated clients of %s", r.Path)
+}
+
+// NeedsUpdate returns true when the client is still active but less than
+// ClientUpdateThreshold of its trusting period is left
+func NeedsUpdate(c ClientStatus) bool {
+	if c.Expired || c.Frozen || c.TrustingPeriod == 0 {
+		return false
+	}
+
+	return float64(c.TrustingPeriodLeft) < float64(c.TrustingPeriod)*ClientUpdateThreshold
+}
+
+// ClientState returns a human readable state of the client
+func ClientState(c ClientStatus) string {
+	switch {
+	case c.Frozen:
+		return "frozen"
+	case c.Expired:
+		return "expired"
+	case NeedsUpdate(c):
+		return "stale"
+	default:
+		return "active"
+	}
+}
@@ -1,7 +1,9 @@
 package relayer
 
 import (
+	"fmt"
 	"slices"
+	"strings"
 	"time"
 
 	"github.com/pterm/pterm"
@@ -11,6 +13,11 @@ import (
 	sequencerutils "github.com/dymensionxyz/roller/utils/sequencer"
 )
 
+const DefaultWhitelistPropagationTimeout = 10 * time.Minute
+
+// whitelistPollInterval is the interval between two queries of the rollapp whitelist
+var whitelistPollInterval = 5 * time.Second
+
 func (r *Relayer) HandleWhitelisting(
 	addr string,
 	rollappChainData *roller.RollappConfig,
@@ -22,24 +29,21 @@ func (r *Relayer) HandleWhitelisting(
 		return err
 	}
 
-	isRlyKeyWhitelisted, err := isRelayerRollappKeyWhitelisted(
-		seqAddr,
-		addr,
-		r.Hub,
-	)
+	whitelisted, err := sequencerutils.GetWhitelistedRelayersOnHub(seqAddr, r.Hub)
 	if err != nil {
 		return err
 	}
 
-	if !isRlyKeyWhitelisted {
+	if !slices.Contains(whitelisted, addr) {
 		pterm.Warning.Printfln(
 			"relayer key (%s) is not whitelisted, updating whitelisted relayers",
 			addr,
 		)
 
+		// the update replaces the whitelisted relayers, the existing ones are kept
 		err := sequencerutils.UpdateWhitelistedRelayers(
 			r.RollerHome,
-			addr,
+			strings.Join(append(whitelisted, addr), ","),
 			string(kb),
 			r.Hub,
 		)
@@ -61,35 +65,143 @@ func (r *Relayer) HandleWhitelisting(
 	wrSpinner, _ := pterm.DefaultSpinner.Start(
 		"waiting for the whitelisted relayer to propagate to RollApp (this might take a while)",
 	)
-	for {
-		wra, err := sequencerutils.GetWhitelistedRelayersOnRa(raOpAddr)
+	err = WaitForRaWhitelist(
+		RaWhitelistQuery(raOpAddr),
+		func(wra []string) bool { return slices.Contains(wra, addr) },
+		DefaultWhitelistPropagationTimeout,
+		SpinnerProgress(wrSpinner, "waiting for the whitelisted relayer to propagate to RollApp"),
+	)
+	if err != nil {
+		wrSpinner.Fail(err.Error())
+		return err
+	}
+	// nolint: errcheck
+	wrSpinner.Success("relayer whitelisted and propagated to rollapp")
+
+	return nil
+}
+
+// WhitelistProgress is called after every poll of the rollapp whitelisted relayers, err is
+// the error of the poll when the query failed
+type WhitelistProgress func(elapsed time.Duration, relayers []string, err error)
+
+// SpinnerProgress reports the propagation progress in the spinner text
+func SpinnerProgress(spinner *pterm.SpinnerPrinter, text string) WhitelistProgress {
+	return func(elapsed time.Duration, relayers []string, err error) {
 		if err != nil {
-			pterm.Error.Println("failed to get whitelisted relayers:", err)
-			return err
+			spinner.UpdateText(
+				fmt.Sprintf(
+					"%s (%s elapsed, failed to query RollApp, retrying: %v)",
+					text,
+					elapsed.Round(time.Second),
+					err,
+				),
+			)
+			return
+		}
+		spinner.UpdateText(
+			fmt.Sprintf(
+				"%s (%s elapsed, %d relayers whitelisted on RollApp)",
+				text,
+				elapsed.Round(time.Second),
+				len(relayers),
+			),
+		)
+	}
+}
+
+// WhitelistQuery returns the whitelisted relayers on the rollapp
+type WhitelistQuery func() ([]string, error)
+
+// RaWhitelistQuery queries the relayers whitelisted by the rollapp operator
+func RaWhitelistQuery(raOpAddr string) WhitelistQuery {
+	return func() ([]string, error) {
+		return sequencerutils.GetWhitelistedRelayersOnRa(raOpAddr)
+	}
+}
+
+// WaitForRaWhitelist polls the whitelisted relayers on the rollapp until done returns
+// true for them or the timeout is reached, the failed queries are retried until the
+// timeout and the last error is returned with the timeout
+func WaitForRaWhitelist(
+	query WhitelistQuery,
+	done func(relayers []string) bool,
+	timeout time.Duration,
+	progress WhitelistProgress,
+) error {
+	start := time.Now()
+	for {
+		wra, err := query()
+		if err == nil && done(wra) {
+			return nil
 		}
 
-		if len(wra) == 0 &&
-			slices.Contains(wra, addr) {
-			wrSpinner.UpdateText(
-				"waiting for the whitelisted relayer to propagate to RollApp...",
+		elapsed := time.Since(start)
+		if progress != nil {
+			progress(elapsed, wra, err)
+		}
+		if elapsed >= timeout {
+			if err != nil {
+				return fmt.Errorf(
+					"whitelisted relayers did not propagate to the rollapp within %s: %w",
+					timeout,
+					err,
+				)
+			}
+			return fmt.Errorf(
+				"whitelisted relayers did not propagate to the rollapp within %s",
+				timeout,
 			)
-			time.Sleep(time.Second * 5)
-			continue
-		} else {
-			// nolint: errcheck
-			wrSpinner.Success("relayer whitelisted and propagated to rollapp")
-			break
 		}
+		time.Sleep(whitelistPollInterval)
 	}
+}
 
-	return nil
+// WhitelistStatus represents the whitelisted relayers of a sequencer on the hub and
+// on the rollapp, the hub is the source of truth that propagates to the rollapp
+type WhitelistStatus struct {
+	Hub     []string `json:"hub"`
+	Rollapp []string `json:"rollapp"`
+	// MissingOnRollapp are the relayers whitelisted on the hub that didn't propagate yet
+	MissingOnRollapp []string `json:"missing_on_rollapp"`
+	// ExtraOnRollapp are the relayers that were removed on the hub but not on the rollapp
+	ExtraOnRollapp []string `json:"extra_on_rollapp"`
+}
+
+// InSync returns true when the rollapp whitelist matches the hub whitelist
+func (ws WhitelistStatus) InSync() bool {
+	return len(ws.MissingOnRollapp) == 0 && len(ws.ExtraOnRollapp) == 0
 }
 
-func isRelayerRollappKeyWhitelisted(seqAddr, relAddr string, hd consts.HubData) (bool, error) {
-	relayers, err := sequencerutils.GetWhitelistedRelayersOnHub(seqAddr, hd)
+// NewWhitelistStatus compares the hub and rollapp whitelisted relayers
+func NewWhitelistStatus(hub, rollapp []string) WhitelistStatus {
+	ws := WhitelistStatus{Hub: hub, Rollapp: rollapp}
+	for _, a := range hub {
+		if !slices.Contains(rollapp, a) {
+			ws.MissingOnRollapp = append(ws.MissingOnRollapp, a)
+		}
+	}
+	for _, a := range rollapp {
+		if !slices.Contains(hub, a) {
+			ws.ExtraOnRollapp = append(ws.ExtraOnRollapp, a)
+		}
+	}
+
+	return ws
+}
+
+// GetWhitelistStatus queries the whitelisted relayers of the sequencer on both chains
+func GetWhitelistStatus(seqAddr, raOpAddr string, hd consts.HubData) (*WhitelistStatus, error) {
+	hub, err := sequencerutils.GetWhitelistedRelayersOnHub(seqAddr, hd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query whitelisted relayers on the hub: %w", err)
+	}
+
+	ra, err := sequencerutils.GetWhitelistedRelayersOnRa(raOpAddr)
 	if err != nil {
-		return false, err
+		return nil, fmt.Errorf("failed to query whitelisted relayers on the rollapp: %w", err)
 	}
 
-	return slices.Contains(relayers, relAddr), nil
+	ws := NewWhitelistStatus(hub, ra)
+	return &ws, nil
 }
@@ -0,0 +1,190 @@
+package relayer
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+// fakeWhitelistQuery returns the outputs in turn, the last output is repeated
+func fakeWhitelistQuery(outputs [][]string, errs []error) (WhitelistQuery, *int) {
+	calls := 0
+	return func() ([]string, error) {
+		i := min(calls, len(outputs)-1)
+		calls++
+		if i < len(errs) && errs[i] != nil {
+			return nil, errs[i]
+		}
+		return outputs[i], nil
+	}, &calls
+}
+
+func TestWaitForRaWhitelist(t *testing.T) {
+	interval := whitelistPollInterval
+	whitelistPollInterval = time.Millisecond
+	t.Cleanup(func() { whitelistPollInterval = interval })
+
+	queryErr := errors.New("rollapp unreachable")
+	contains := func(addr string) func([]string) bool {
+		return func(wra []string) bool { return slices.Contains(wra, addr) }
+	}
+
+	tests := []struct {
+		name        string
+		outputs     [][]string
+		errs        []error
+		done        func([]string) bool
+		timeout     time.Duration
+		wantErr     error
+		wantTimeout bool
+		wantCalls   int
+	}{
+		{
+			name:      "already propagated",
+			outputs:   [][]string{{"relayer1"}},
+			done:      contains("relayer1"),
+			timeout:   time.Second,
+			wantCalls: 1,
+		},
+		{
+			name:      "late propagation",
+			outputs:   [][]string{{}, {}, {"relayer1"}},
+			done:      contains("relayer1"),
+			timeout:   time.Second,
+			wantCalls: 3,
+		},
+		{
+			name:      "removal propagates",
+			outputs:   [][]string{{"old", "new"}, {"new"}},
+			done:      func(wra []string) bool { return !slices.Contains(wra, "old") },
+			timeout:   time.Second,
+			wantCalls: 2,
+		},
+		{
+			name:        "mismatch times out",
+			outputs:     [][]string{{"other"}},
+			done:        contains("relayer1"),
+			timeout:     20 * time.Millisecond,
+			wantTimeout: true,
+		},
+		{
+			name:      "transient query error is retried",
+			outputs:   [][]string{{}, {"relayer1"}},
+			errs:      []error{queryErr},
+			done:      contains("relayer1"),
+			timeout:   time.Second,
+			wantCalls: 2,
+		},
+		{
+			name:        "persistent query error times out",
+			outputs:     [][]string{{}},
+			errs:        []error{queryErr},
+			done:        contains("relayer1"),
+			timeout:     20 * time.Millisecond,
+			wantErr:     queryErr,
+			wantTimeout: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, calls := fakeWhitelistQuery(tt.outputs, tt.errs)
+
+			var progressCalls, progressErrs int
+			err := WaitForRaWhitelist(
+				query,
+				tt.done,
+				tt.timeout,
+				func(_ time.Duration, _ []string, err error) {
+					progressCalls++
+					if err != nil {
+						progressErrs++
+					}
+				},
+			)
+
+			switch {
+			case tt.wantTimeout:
+				if err == nil {
+					t.Fatal("expected a timeout error")
+				}
+				if progressCalls == 0 {
+					t.Fatal("expected the progress to be reported while waiting")
+				}
+			case err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if len(tt.errs) > 0 && progressErrs == 0 {
+				t.Fatal("expected the query errors to be reported")
+			}
+
+			if tt.wantCalls > 0 && *calls != tt.wantCalls {
+				t.Fatalf("expected %d queries, got %d", tt.wantCalls, *calls)
+			}
+			if tt.wantCalls > 0 && progressCalls != tt.wantCalls-1 {
+				t.Fatalf("expected %d progress reports, got %d", tt.wantCalls-1, progressCalls)
+			}
+		})
+	}
+}
+
+func TestNewWhitelistStatus(t *testing.T) {
+	tests := []struct {
+		name        string
+		hub         []string
+		rollapp     []string
+		wantMissing []string
+		wantExtra   []string
+		wantInSync  bool
+	}{
+		{
+			name:       "in sync",
+			hub:        []string{"a", "b"},
+			rollapp:    []string{"b", "a"},
+			wantInSync: true,
+		},
+		{
+			name:       "both empty",
+			wantInSync: true,
+		},
+		{
+			name:        "not propagated yet",
+			hub:         []string{"a", "b"},
+			rollapp:     []string{"a"},
+			wantMissing: []string{"b"},
+		},
+		{
+			name:      "removal not propagated yet",
+			hub:       []string{"a"},
+			rollapp:   []string{"a", "b"},
+			wantExtra: []string{"b"},
+		},
+		{
+			name:        "mismatch",
+			hub:         []string{"a", "b"},
+			rollapp:     []string{"c"},
+			wantMissing: []string{"a", "b"},
+			wantExtra:   []string{"c"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ws := NewWhitelistStatus(tt.hub, tt.rollapp)
+
+			if !slices.Equal(ws.MissingOnRollapp, tt.wantMissing) {
+				t.Fatalf("expected missing %v, got %v", tt.wantMissing, ws.MissingOnRollapp)
+			}
+			if !slices.Equal(ws.ExtraOnRollapp, tt.wantExtra) {
+				t.Fatalf("expected extra %v, got %v", tt.wantExtra, ws.ExtraOnRollapp)
+			}
+			if ws.InSync() != tt.wantInSync {
+				t.Fatalf("expected in sync %v, got %v", tt.wantInSync, ws.InSync())
+			}
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/dymensionxyz/roller/cmd/consts"
 	"github.com/dymensionxyz/roller/utils/bash"
+	"github.com/dymensionxyz/roller/utils/tracing"
 )
 
 type NodeInfo struct {
@@ -90,7 +91,13 @@ func (seq *Sequencer) GetHubHeight() (string, error) {
 		"--chain-id",
 		seq.RlpCfg.HubData.ID,
 	)
-	out, err := bash.ExecCommandWithStdout(cmd)
+	ctx, span := tracing.StartHubQuery(
+		"sequencer.GetHubHeight",
+		seq.RlpCfg.HubData.ID,
+		seq.RlpCfg.HubData.RpcUrl,
+	)
+	out, err := bash.ExecCommandWithStdoutCtx(ctx, cmd)
+	tracing.End(span, err)
 	if err != nil {
 		return "", err
 	}
@@ -18,6 +18,7 @@ import (
 	"github.com/pterm/pterm"
 
 	"github.com/dymensionxyz/roller/utils/errorhandling"
+	"github.com/dymensionxyz/roller/utils/tracing"
 )
 
 func RunCommandEvery(
@@ -108,11 +109,20 @@ func RunCmdAsync(
 }
 
 func ExecCommandWithStdout(cmd *exec.Cmd) (*bytes.Buffer, error) {
+	return ExecCommandWithStdoutCtx(tracing.Context(), cmd)
+}
+
+// ExecCommandWithStdoutCtx is ExecCommandWithStdout where ctx carries the parent
+// trace span of the command, e.g. the span of a hub query helper
+func ExecCommandWithStdoutCtx(ctx context.Context, cmd *exec.Cmd) (*bytes.Buffer, error) {
 	var stderr bytes.Buffer
 	var stdout bytes.Buffer
 	cmd.Stderr = &stderr
 	cmd.Stdout = &stdout
+
+	span, start := tracing.StartCommand(ctx, cmd)
 	err := cmd.Run()
+	tracing.EndCommand(span, start, cmd, err)
 	if err != nil {
 		return &stderr, fmt.Errorf("command execution failed: %w, stderr: %s", err, stderr.String())
 	}
@@ -124,7 +134,10 @@ func ExecCommandWithStdErr(cmd *exec.Cmd) (*bytes.Buffer, error) {
 	var stdout bytes.Buffer
 	cmd.Stderr = &stderr
 	cmd.Stdout = &stdout
+
+	span, start := tracing.StartCommand(tracing.Context(), cmd)
 	err := cmd.Run()
+	tracing.EndCommand(span, start, cmd, err)
 	if err != nil {
 		return &stdout, fmt.Errorf("command execution failed: %w, stderr: %s", err, stderr.String())
 	}
@@ -135,7 +148,10 @@ func ExecCmd(cmd *exec.Cmd, options ...CommandOption) error {
 	for _, option := range options {
 		option(cmd)
 	}
+
+	span, start := tracing.StartCommand(tracing.Context(), cmd)
 	err := cmd.Run()
+	tracing.EndCommand(span, start, cmd, err)
 	if err != nil {
 		return fmt.Errorf("command execution failed: %v", err)
 	}
@@ -181,6 +197,7 @@ func ExecCmdFollow(
 				}
 			case <-ctx.Done():
 				_ = cmd.Process.Signal(syscall.SIGTERM)
+				return
 			}
 		}
 	}()
@@ -255,11 +272,15 @@ func ExecCommandWithInteractions(cmdName string, args ...string) error {
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 
+	span, start := tracing.StartCommand(tracing.Context(), cmd)
 	if err := cmd.Start(); err != nil {
+		tracing.EndCommand(span, start, cmd, err)
 		return fmt.Errorf("error starting command: %w", err)
 	}
 
-	if err := cmd.Wait(); err != nil {
+	err := cmd.Wait()
+	tracing.EndCommand(span, start, cmd, err)
+	if err != nil {
 		return fmt.Errorf("command finished with error: %w", err)
 	}
 
@@ -292,7 +313,9 @@ func ExecCommandWithInput(
 		return "", fmt.Errorf("error creating stderr pipe: %w", err)
 	}
 
+	span, start := tracing.StartCommand(tracing.Context(), cmd)
 	if err := cmd.Start(); err != nil {
+		tracing.EndCommand(span, start, cmd, err)
 		return "", fmt.Errorf("error starting command: %w", err)
 	}
 	//nolint:errcheck
@@ -327,13 +350,17 @@ func ExecCommandWithInput(
 				if _, err := stdin.Write([]byte("n\n")); err != nil {
 					return "", err
 				}
-				return "", errors.New("cancelled by user")
+				err := errors.New("cancelled by user")
+				tracing.End(span, err)
+				return "", err
 			}
 
 		}
 	}
 
-	if err := cmd.Wait(); err != nil {
+	err = cmd.Wait()
+	tracing.EndCommand(span, start, cmd, err)
+	if err != nil {
 		return "", fmt.Errorf("command finished with error: %w", err)
 	}
 
@@ -383,7 +410,9 @@ func ExecuteCommandWithPrompts(
 	go handlePrompts(stdin, promptResponses)
 
 	// Capture output
+	span, start := tracing.StartCommand(tracing.Context(), cmd)
 	output, err := cmd.CombinedOutput()
+	tracing.EndCommand(span, start, cmd, err)
 	if err != nil {
 		return nil, fmt.Errorf("command execution failed: %v\nOutput: %s", err, string(output))
 	}
@@ -419,7 +448,9 @@ func ExecuteCommandWithPromptHandler(
 		return nil, fmt.Errorf("failed to create stderr pipe: %v", err)
 	}
 
+	span, start := tracing.StartCommand(tracing.Context(), cmd)
 	if err := cmd.Start(); err != nil {
+		tracing.EndCommand(span, start, cmd, err)
 		return nil, fmt.Errorf("error starting command: %v", err)
 	}
 
@@ -454,9 +485,13 @@ func ExecuteCommandWithPromptHandler(
 				}
 
 				if !shouldContinue {
-					return nil, errors.New("cancelled by user")
+					err := errors.New("cancelled by user")
+					tracing.End(span, err)
+					return nil, err
 				}
 
+				// the command is re-executed with '-y' in a separate span
+				tracing.End(span, nil)
 				args = append(args, "-y")
 				out, err := ExecuteCommandWithPrompts(command, args, promptResponses)
 				if err != nil {
@@ -468,10 +503,13 @@ func ExecuteCommandWithPromptHandler(
 	}
 
 	if err := scanner.Err(); err != nil {
+		tracing.End(span, err)
 		return nil, fmt.Errorf("error reading output: %v", err)
 	}
 
-	if err := cmd.Wait(); err != nil {
+	err = cmd.Wait()
+	tracing.EndCommand(span, start, cmd, err)
+	if err != nil {
 		return nil, fmt.Errorf("command finished with error: %w", err)
 	}
 
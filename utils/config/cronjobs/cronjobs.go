@@ -21,3 +21,42 @@ func Add(schedule, command string) error {
 	setCrontabCmd.Stdin = strings.NewReader(newCrontab)
 	return setCrontabCmd.Run()
 }
+
+// Remove deletes the cron entries whose command contains match, it returns true when
+// at least one entry was removed
+func Remove(match string) (bool, error) {
+	return RemoveFunc(func(entry string) bool { return strings.Contains(entry, match) })
+}
+
+// RemoveFunc deletes the cron entries for which match returns true, it returns true when
+// at least one entry was removed
+func RemoveFunc(match func(entry string) bool) (bool, error) {
+	getCurrentCmd := exec.Command("crontab", "-l")
+	currentCrontab, err := getCurrentCmd.Output()
+	if err != nil {
+		// no crontab for the user
+		return false, nil
+	}
+
+	var kept []string
+	removed := false
+	for _, l := range strings.Split(strings.TrimRight(string(currentCrontab), "\n"), "\n") {
+		if match(l) && !strings.HasPrefix(strings.TrimSpace(l), "#") {
+			removed = true
+			continue
+		}
+		kept = append(kept, l)
+	}
+	if !removed {
+		return false, nil
+	}
+
+	newCrontab := strings.Join(kept, "\n")
+	if len(kept) > 0 {
+		newCrontab += "\n"
+	}
+
+	setCrontabCmd := exec.Command("crontab", "-")
+	setCrontabCmd.Stdin = strings.NewReader(newCrontab)
+	return true, setCrontabCmd.Run()
+}
@@ -2,11 +2,17 @@ package eibc
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
 	"os/exec"
+	"path/filepath"
 	"time"
 
 	"github.com/dymensionxyz/roller/cmd/consts"
 	"github.com/dymensionxyz/roller/utils/bash"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/keys"
 )
 
 func GetGroups(home, admin string, hd consts.HubData) (*GroupsResponse, error) {
@@ -61,3 +67,259 @@ type Group struct {
 type GroupsResponse struct {
 	Groups []Group `json:"groups"`
 }
+
+// GroupInfo identifies the operator group of the eibc client
+type GroupInfo struct {
+	EibcHome      string
+	Admin         string
+	GroupID       string
+	PolicyAddress string
+	Hub           consts.HubData
+	// Member is the key the group transactions are signed with, the whale account unless
+	// another member is selected
+	Member GroupMemberKey
+}
+
+// GroupMemberKey is the key of a member of the operator group
+type GroupMemberKey struct {
+	Name           string
+	Address        string
+	KeyringBackend consts.SupportedKeyringBackend
+	KeyringDir     string
+	// home holds the passphrase file of the os keyring backend
+	home string
+}
+
+// GroupOverrides select another group than the one of the eibc client, or another member
+// than the whale account, the zero value selects the eibc client group and whale account
+type GroupOverrides struct {
+	GroupID string
+	// From is the key name of the member, KeyringDir defaults to the eibc home
+	From           string
+	KeyringBackend consts.SupportedKeyringBackend
+	KeyringDir     string
+}
+
+// LoadGroupInfo returns the operator group of the eibc client initialized in the home
+// directory, signed by the whale account
+func LoadGroupInfo(home string) (*GroupInfo, error) {
+	return LoadMemberGroupInfo(home, GroupOverrides{})
+}
+
+// LoadMemberGroupInfo returns the operator group of the overrides, signed by the selected
+// member, so that the members that don't administer the group can take part in it
+func LoadMemberGroupInfo(home string, o GroupOverrides) (*GroupInfo, error) {
+	eibcHome := filepath.Join(home, consts.ConfigDirName.Eibc)
+	isEibcClientInitialized, err := filesystem.DirNotEmpty(eibcHome)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check eibc client initialized: %w", err)
+	}
+	if !isEibcClientInitialized {
+		return nil, errors.New("eibc client not initialized")
+	}
+
+	var cfg Config
+	hd, err := cfg.HubDataFromHubRpc(filepath.Join(eibcHome, "config.yaml"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve hub data: %w", err)
+	}
+
+	member, err := loadMemberKey(home, eibcHome, o)
+	if err != nil {
+		return nil, err
+	}
+
+	gi := &GroupInfo{
+		EibcHome:      eibcHome,
+		GroupID:       cfg.OperatorConfig.GroupID,
+		PolicyAddress: cfg.Fulfillers.PolicyAddress,
+		Hub:           *hd,
+		Member:        *member,
+	}
+
+	if o.GroupID != "" && o.GroupID != gi.GroupID {
+		gi.GroupID = o.GroupID
+		gi.PolicyAddress = ""
+	}
+
+	if gi.GroupID == "" {
+		if o.GroupID != "" || o.From != "" {
+			return nil, errors.New("no operator group in the eibc config, set the group id")
+		}
+		groups, err := GetGroups(eibcHome, member.Address, *hd)
+		if err != nil {
+			return nil, err
+		}
+		if len(groups.Groups) == 0 {
+			return nil, errors.New("no operator group found, run 'roller eibc init'")
+		}
+		gi.GroupID = groups.Groups[0].ID
+	}
+
+	// the admin is either the whale account or the group policy once the group
+	// administers itself
+	g, err := GetGroup(*gi)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve group %s: %w", gi.GroupID, err)
+	}
+	gi.Admin = g.Admin
+
+	if gi.PolicyAddress == "" {
+		p, err := GetGroupPolicy(*gi)
+		if err != nil {
+			return nil, err
+		}
+		gi.PolicyAddress = p.Address
+	}
+
+	return gi, nil
+}
+
+// loadMemberKey returns the key of the member selected by the overrides, the whale
+// account by default
+func loadMemberKey(home, eibcHome string, o GroupOverrides) (*GroupMemberKey, error) {
+	if o.From == "" {
+		ki, err := GetKeyConfig().Info(home)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve whale account: %w", err)
+		}
+		return &GroupMemberKey{
+			Name:           consts.KeysIds.Eibc,
+			Address:        ki.Address,
+			KeyringBackend: consts.SupportedKeyringBackends.Test,
+			KeyringDir:     eibcHome,
+			home:           home,
+		}, nil
+	}
+
+	m := GroupMemberKey{
+		Name:           o.From,
+		KeyringBackend: o.KeyringBackend,
+		KeyringDir:     o.KeyringDir,
+		home:           home,
+	}
+	if m.KeyringBackend == "" {
+		m.KeyringBackend = consts.SupportedKeyringBackends.Test
+	}
+	if m.KeyringDir == "" {
+		m.KeyringDir = eibcHome
+	}
+
+	out, err := keys.RunCmdBasedOnKeyringBackend(
+		home,
+		consts.Executables.Dymension,
+		[]string{
+			"keys", "show", m.Name,
+			"--keyring-backend", string(m.KeyringBackend),
+			"--keyring-dir", m.KeyringDir,
+			"--output", "json",
+		},
+		m.KeyringBackend,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve key %s: %w", m.Name, err)
+	}
+	ki, err := keys.ParseAddressFromOutput(out)
+	if err != nil {
+		return nil, err
+	}
+	m.Address = ki.Address
+
+	return &m, nil
+}
+
+// GroupMember is a member of the operator group, its weight is its share of the votes
+type GroupMember struct {
+	Address  string    `json:"address"`
+	Weight   string    `json:"weight"`
+	Metadata string    `json:"metadata"`
+	AddedAt  time.Time `json:"added_at,omitempty"`
+}
+
+type groupMembersResponse struct {
+	Members []struct {
+		GroupID string      `json:"group_id"`
+		Member  GroupMember `json:"member"`
+	} `json:"members"`
+}
+
+// GetGroupMembers returns the members of the group
+func GetGroupMembers(gi GroupInfo) ([]GroupMember, error) {
+	cmd := exec.Command(
+		consts.Executables.Dymension,
+		"q", "group", "group-members", gi.GroupID,
+		"-o", "json",
+		"--node", gi.Hub.RpcUrl,
+		"--chain-id", gi.Hub.ID,
+		"--home", gi.EibcHome,
+	)
+
+	out, err := bash.ExecCommandWithStdout(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp groupMembersResponse
+	err = json.Unmarshal(out.Bytes(), &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]GroupMember, 0, len(resp.Members))
+	for _, m := range resp.Members {
+		members = append(members, m.Member)
+	}
+
+	return members, nil
+}
+
+// UpdateGroupMembers adds, updates or removes the members of the group with the admin
+// account, a member with a zero weight is removed. The signing member has to be the admin,
+// the members of a group administered by its policy are updated with a proposal
+func UpdateGroupMembers(gi GroupInfo, members []GroupMember) error {
+	if gi.Admin != gi.Member.Address {
+		return fmt.Errorf(
+			"%s is not the admin of group %s (%s), submit a proposal to update the members",
+			gi.Member.Address,
+			gi.GroupID,
+			gi.Admin,
+		)
+	}
+
+	b, err := json.MarshalIndent(map[string][]GroupMember{"members": members}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	membersPath := filepath.Join(gi.EibcHome, "init", "members-update.json")
+	err = os.MkdirAll(filepath.Dir(membersPath), 0o755)
+	if err != nil {
+		return err
+	}
+	err = os.WriteFile(membersPath, b, 0o644)
+	if err != nil {
+		return err
+	}
+
+	_, err = execMemberTx(
+		gi,
+		"tx", "group", "update-group-members", gi.Admin, gi.GroupID, membersPath,
+	)
+	return err
+}
+
+// GetGroupPolicy returns the policy of the group with the address
+func GetGroupPolicy(gi GroupInfo) (*GroupPolicy, error) {
+	pol, err := GetPolicies(gi.EibcHome, gi.GroupID, gi.Hub)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range pol.GroupPolicies {
+		if gi.PolicyAddress == "" || p.Address == gi.PolicyAddress {
+			return &p, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no policy found for group %s", gi.GroupID)
+}
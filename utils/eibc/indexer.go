@@ -0,0 +1,222 @@
+package eibc
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/utils/config/yamlconfig"
+)
+
+// OrderSources are the sources of the orders polled by the eibc client
+var OrderSources = struct {
+	Auto    string
+	Indexer string
+	Hub     string
+}{
+	Auto:    "auto",
+	Indexer: "indexer",
+	Hub:     "hub",
+}
+
+// OrderCheckResults are the outcomes of the comparison of an indexer order with the hub
+var OrderCheckResults = struct {
+	Ok       string
+	Missing  string
+	Stale    string
+	Mismatch string
+}{
+	Ok:       "ok",
+	Missing:  "missing on hub",
+	Stale:    "already fulfilled",
+	Mismatch: "mismatch",
+}
+
+// maxInconsistency is the share of sampled orders that can differ from the hub before
+// the indexer is considered inconsistent, i.e. one of the ten orders sampled by the eibc
+// start check
+const maxInconsistency = 0.1
+
+// OrderCheck is the comparison of an order served by the indexer with the hub state
+type OrderCheck struct {
+	ID     string
+	Result string
+	Detail string
+}
+
+// IndexerReport is the health and consistency of the indexer compared to the hub
+type IndexerReport struct {
+	URL     string
+	Latency time.Duration
+	// Err is set when the indexer can't be queried
+	Err error
+
+	IndexerOrders int
+	HubOrders     int
+	Checks        []OrderCheck
+	// Unindexed are the unfulfilled pending orders of the hub the indexer doesn't serve
+	Unindexed []string
+}
+
+// Healthy returns true when the indexer answered
+func (r *IndexerReport) Healthy() bool {
+	return r.Err == nil
+}
+
+// Inconsistencies returns the number of sampled orders that differ from the hub
+func (r *IndexerReport) Inconsistencies() int {
+	var n int
+	for _, c := range r.Checks {
+		if c.Result != OrderCheckResults.Ok {
+			n++
+		}
+	}
+	return n
+}
+
+// Consistent returns true when at most maxInconsistency of the sampled orders differ from
+// the hub
+func (r *IndexerReport) Consistent() bool {
+	if len(r.Checks) == 0 {
+		return true
+	}
+	return float64(r.Inconsistencies())/float64(len(r.Checks)) <= maxInconsistency
+}
+
+// Usable returns true when the eibc client can poll the orders from the indexer
+func (r *IndexerReport) Usable() bool {
+	return r.Healthy() && r.Consistent()
+}
+
+// CheckIndexer queries the pending orders from the indexer and from the hub and compares a
+// random sample of up to sampleSize indexer orders with the hub state
+func CheckIndexer(indexerURL string, hd consts.HubData, sampleSize int) (*IndexerReport, error) {
+	report := &IndexerReport{URL: indexerURL}
+
+	start := time.Now()
+	indexed, err := QueryIndexerDemandOrders(indexerURL, hd.ID)
+	report.Latency = time.Since(start)
+	if err != nil {
+		report.Err = err
+		return report, nil
+	}
+	report.IndexerOrders = len(indexed)
+
+	onHub, err := QueryHubDemandOrders(hd, OrderStatuses.Pending)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the hub orders: %w", err)
+	}
+	report.HubOrders = len(onHub)
+
+	hubByID := make(map[string]DemandOrder, len(onHub))
+	for _, o := range onHub {
+		hubByID[o.ID] = o
+	}
+	indexedIDs := make(map[string]bool, len(indexed))
+	for _, o := range indexed {
+		indexedIDs[o.ID] = true
+	}
+
+	sample := make([]DemandOrder, len(indexed))
+	copy(sample, indexed)
+	rand.Shuffle(len(sample), func(i, j int) { sample[i], sample[j] = sample[j], sample[i] })
+	if sampleSize > 0 && len(sample) > sampleSize {
+		sample = sample[:sampleSize]
+	}
+
+	for _, o := range sample {
+		report.Checks = append(report.Checks, compareOrder(o, hubByID))
+	}
+
+	for _, o := range onHub {
+		if !o.Fulfilled() && !indexedIDs[o.ID] {
+			report.Unindexed = append(report.Unindexed, o.ID)
+		}
+	}
+
+	return report, nil
+}
+
+func compareOrder(indexed DemandOrder, hubByID map[string]DemandOrder) OrderCheck {
+	check := OrderCheck{ID: indexed.ID, Result: OrderCheckResults.Ok}
+
+	o, ok := hubByID[indexed.ID]
+	switch {
+	case !ok:
+		check.Result = OrderCheckResults.Missing
+		check.Detail = "not pending on the hub, finalized or never created"
+	case o.Fulfilled() && !indexed.Fulfilled():
+		check.Result = OrderCheckResults.Stale
+		check.Detail = fmt.Sprintf("fulfilled by %s", o.FulfillerAddress)
+	case !o.Price.Equal(indexed.Price) || !o.Fee.Equal(indexed.Fee):
+		check.Result = OrderCheckResults.Mismatch
+		check.Detail = fmt.Sprintf(
+			"indexer price %s fee %s, hub price %s fee %s",
+			indexed.Price,
+			indexed.Fee,
+			o.Price,
+			o.Fee,
+		)
+	case indexed.RollappID != "" && o.RollappID != indexed.RollappID:
+		check.Result = OrderCheckResults.Mismatch
+		check.Detail = fmt.Sprintf("indexer rollapp %s, hub rollapp %s", indexed.RollappID, o.RollappID)
+	}
+
+	return check
+}
+
+// ApplyOrderSource enables the order polling of the eibc client when the indexer is used
+// and disables it otherwise, the eibc client then gets the orders from the hub. In auto
+// mode the indexer is used when it's healthy and consistent with the hub. The indexer of
+// the eibc config is replaced by indexerURL when it's set
+func ApplyOrderSource(
+	eibcConfigPath, source, indexerURL string,
+	hd consts.HubData,
+) (*IndexerReport, bool, error) {
+	var cfg Config
+	err := cfg.LoadConfig(eibcConfigPath)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if indexerURL == "" {
+		indexerURL = cfg.OrderPolling.IndexerURL
+	}
+
+	var report *IndexerReport
+	useIndexer := false
+	switch source {
+	case OrderSources.Hub:
+	case OrderSources.Indexer:
+		if indexerURL == "" {
+			return nil, false, fmt.Errorf(
+				"no indexer configured for %s, set it with --indexer-url",
+				hd.ID,
+			)
+		}
+		useIndexer = true
+	case OrderSources.Auto:
+		if indexerURL == "" {
+			break
+		}
+		report, err = CheckIndexer(indexerURL, hd, 10)
+		if err != nil {
+			return nil, false, err
+		}
+		useIndexer = report.Usable()
+	default:
+		return nil, false, fmt.Errorf("invalid order source: %s", source)
+	}
+
+	updates := map[string]interface{}{
+		"order_polling.indexer_url": indexerURL,
+		"order_polling.enabled":     useIndexer,
+	}
+	err = yamlconfig.UpdateNestedYAML(eibcConfigPath, updates)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to update config: %v", err)
+	}
+
+	return report, useIndexer, nil
+}
@@ -0,0 +1,573 @@
+package eibc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	cosmossdkmath "cosmossdk.io/math"
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+	cometclient "github.com/cometbft/cometbft/rpc/client/http"
+	comettypes "github.com/cometbft/cometbft/types"
+	"github.com/pterm/pterm"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+)
+
+const (
+	// DefaultMongoDbURI is the uri of the container created by CreateMongoDbContainer
+	DefaultMongoDbURI = "mongodb://localhost:27017"
+
+	indexerDatabase   = "eibc_indexer"
+	indexerCollection = "demand_orders"
+	indexerSubscriber = "eibc-indexer"
+	// indexerPendingStatus is the only status of the graphql filter used by the eibc client
+	indexerPendingStatus = "EibcPending"
+
+	minResubscribeBackoff = time.Second
+	maxResubscribeBackoff = time.Minute
+)
+
+// indexedOrder is the demand order document stored in mongodb
+type indexedOrder struct {
+	ID             string    `bson:"_id"`
+	Network        string    `bson:"network"`
+	RollappID      string    `bson:"rollapp_id"`
+	Denom          string    `bson:"denom"`
+	Price          string    `bson:"price"`
+	Fee            string    `bson:"fee"`
+	Recipient      string    `bson:"recipient"`
+	Fulfiller      string    `bson:"fulfiller_address"`
+	PacketStatus   string    `bson:"packet_status"`
+	BlockHeight    int64     `bson:"block_height"`
+	BlockTimestamp time.Time `bson:"block_timestamp"`
+	ProofHeight    int64     `bson:"proof_height"`
+	UpdatedAt      time.Time `bson:"updated_at"`
+}
+
+// IndexerOrder converts the document to the format served to the eibc client
+func (o indexedOrder) IndexerOrder() IndexerOrder {
+	price, _ := cosmossdkmath.NewIntFromString(o.Price)
+	fee, _ := cosmossdkmath.NewIntFromString(o.Fee)
+	amount := ""
+	if !price.IsNil() && !fee.IsNil() {
+		amount = price.Add(fee).String()
+	}
+
+	n := IndexerOrder{
+		EibcOrderID:      o.ID,
+		Amount:           amount,
+		Denom:            o.Denom,
+		Price:            o.Price,
+		EibcFee:          o.Fee,
+		RollappID:        o.RollappID,
+		BlockHeight:      strconv.FormatInt(o.BlockHeight, 10),
+		ProofHeight:      strconv.FormatInt(o.ProofHeight, 10),
+		PacketStatus:     o.PacketStatus,
+		FulfillerAddress: o.Fulfiller,
+		Recipient:        o.Recipient,
+	}
+	if !o.BlockTimestamp.IsZero() {
+		n.BlockTimestamp = o.BlockTimestamp.UTC().Format(time.RFC3339Nano)
+	}
+	return n
+}
+
+// OrderStore stores the demand orders of a hub in mongodb
+type OrderStore struct {
+	client *mongo.Client
+	coll   *mongo.Collection
+	hubID  string
+}
+
+// NewOrderStore connects to mongodb and creates the indexes of the order collection
+func NewOrderStore(ctx context.Context, uri, hubID string) (*OrderStore, error) {
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to mongodb: %w", err)
+	}
+
+	err = client.Ping(ctx, nil)
+	if err != nil {
+		// nolint errcheck
+		client.Disconnect(ctx)
+		return nil, fmt.Errorf("failed to reach mongodb at %s: %w", uri, err)
+	}
+
+	coll := client.Database(indexerDatabase).Collection(indexerCollection)
+	_, err = coll.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "network", Value: 1}, {Key: "packet_status", Value: 1}},
+	})
+	if err != nil {
+		// nolint errcheck
+		client.Disconnect(ctx)
+		return nil, fmt.Errorf("failed to create the order indexes: %w", err)
+	}
+
+	return &OrderStore{client: client, coll: coll, hubID: hubID}, nil
+}
+
+// Close disconnects from mongodb
+func (s *OrderStore) Close(ctx context.Context) error {
+	return s.client.Disconnect(ctx)
+}
+
+// Upsert stores the order, the empty fields of the order don't overwrite the stored ones
+func (s *OrderStore) Upsert(ctx context.Context, o DemandOrder) error {
+	set := bson.M{"network": s.hubID, "updated_at": time.Now()}
+	for k, v := range map[string]string{
+		"rollapp_id":        o.RollappID,
+		"denom":             o.Denom,
+		"recipient":         o.Recipient,
+		"fulfiller_address": o.FulfillerAddress,
+		"packet_status":     o.Status,
+	} {
+		if v != "" {
+			set[k] = v
+		}
+	}
+	if !o.Price.IsNil() && o.Price.IsPositive() {
+		set["price"] = o.Price.String()
+	}
+	if !o.Fee.IsNil() && !o.Fee.IsNegative() {
+		set["fee"] = o.Fee.String()
+	}
+	if o.CreationHeight > 0 {
+		set["block_height"] = o.CreationHeight
+	}
+	if !o.CreatedAt.IsZero() {
+		set["block_timestamp"] = o.CreatedAt
+	}
+	if o.ProofHeight > 0 {
+		set["proof_height"] = o.ProofHeight
+	}
+
+	_, err := s.coll.UpdateOne(
+		ctx,
+		bson.M{"_id": o.ID},
+		bson.M{"$set": set},
+		options.Update().SetUpsert(true),
+	)
+	return err
+}
+
+// Delete removes the order
+func (s *OrderStore) Delete(ctx context.Context, orderID string) error {
+	_, err := s.coll.DeleteOne(ctx, bson.M{"_id": orderID})
+	return err
+}
+
+// Pending returns the pending orders that are not fulfilled yet, oldest first
+func (s *OrderStore) Pending(ctx context.Context) ([]indexedOrder, error) {
+	cur, err := s.coll.Find(
+		ctx,
+		bson.M{
+			"network":           s.hubID,
+			"packet_status":     OrderStatuses.Pending,
+			"fulfiller_address": bson.M{"$in": bson.A{"", nil}},
+		},
+		options.Find().SetSort(bson.D{{Key: "block_height", Value: 1}}),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var orders []indexedOrder
+	err = cur.All(ctx, &orders)
+	return orders, err
+}
+
+// MarkNotPending sets the stored pending orders missing from the pending ids as finalized,
+// the hub only lists the pending orders so the reverted ones are marked finalized too,
+// either way they can no longer be fulfilled
+func (s *OrderStore) MarkNotPending(ctx context.Context, pendingIDs []string) (int64, error) {
+	res, err := s.coll.UpdateMany(
+		ctx,
+		bson.M{
+			"network":       s.hubID,
+			"packet_status": OrderStatuses.Pending,
+			"_id":           bson.M{"$nin": pendingIDs},
+		},
+		bson.M{"$set": bson.M{
+			"packet_status": OrderStatuses.Finalized,
+			"updated_at":    time.Now(),
+		}},
+	)
+	if err != nil {
+		return 0, err
+	}
+	return res.ModifiedCount, nil
+}
+
+// IndexerStatus is the progress of the indexer
+type IndexerStatus struct {
+	Network    string    `json:"network"`
+	LastHeight int64     `json:"last_height"`
+	LastEvent  time.Time `json:"last_event"`
+	LastSync   time.Time `json:"last_sync"`
+	Pending    int       `json:"pending"`
+}
+
+// OrderIndexer indexes the demand orders of the hub from the events of its websocket and
+// serves them with the graphql api polled by the eibc client
+type OrderIndexer struct {
+	hd    consts.HubData
+	store *OrderStore
+	bt    *blockTimes
+
+	mu     sync.Mutex
+	status IndexerStatus
+}
+
+// NewOrderIndexer returns an indexer of the hub orders that stores them in the store
+func NewOrderIndexer(hd consts.HubData, store *OrderStore) *OrderIndexer {
+	return &OrderIndexer{
+		hd:     hd,
+		store:  store,
+		bt:     newBlockTimes(hd.RpcUrl),
+		status: IndexerStatus{Network: hd.ID},
+	}
+}
+
+// Status returns the progress of the indexer
+func (ix *OrderIndexer) Status() IndexerStatus {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	return ix.status
+}
+
+// Run indexes the orders until the context is cancelled. The pending orders of the hub are
+// synced at start and then every resyncInterval, which recovers the events missed while
+// the websocket was disconnected. The websocket subscriptions are renewed with a backoff
+// when they are lost
+func (ix *OrderIndexer) Run(ctx context.Context, resyncInterval time.Duration) error {
+	err := ix.resync(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to sync the pending orders: %w", err)
+	}
+
+	ticker := time.NewTicker(resyncInterval)
+	defer ticker.Stop()
+
+	backoff := minResubscribeBackoff
+	for {
+		subscribed, err := ix.follow(ctx, ticker.C)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if subscribed {
+			backoff = minResubscribeBackoff
+		}
+
+		pterm.Warning.Printfln("lost the hub websocket: %v, resubscribing in %s", err, backoff)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, maxResubscribeBackoff)
+	}
+}
+
+// follow indexes the orders from the events of the hub websocket until the context is
+// cancelled or the subscriptions are lost, the orders are resynced on every tick of resync.
+// It returns whether the subscriptions were established
+func (ix *OrderIndexer) follow(ctx context.Context, resync <-chan time.Time) (bool, error) {
+	client, err := cometclient.New(ix.hd.RpcUrl, "/websocket")
+	if err != nil {
+		return false, fmt.Errorf("error creating client: %v", err)
+	}
+	err = client.Start()
+	if err != nil {
+		return false, fmt.Errorf("error starting client: %v", err)
+	}
+	// nolint errcheck
+	defer client.Stop()
+
+	txs, err := client.Subscribe(ctx, indexerSubscriber, "tm.event='Tx'", 1000)
+	if err != nil {
+		return false, fmt.Errorf("error subscribing to txs: %v", err)
+	}
+	headers, err := client.Subscribe(ctx, indexerSubscriber, "tm.event='NewBlockHeader'", 100)
+	if err != nil {
+		return false, fmt.Errorf("error subscribing to blocks: %v", err)
+	}
+	// nolint errcheck
+	defer client.UnsubscribeAll(context.Background(), indexerSubscriber)
+
+	pterm.Info.Printfln("indexing the demand orders of %s from %s", ix.hd.ID, ix.hd.RpcUrl)
+	for {
+		select {
+		case <-ctx.Done():
+			return true, nil
+		case ev, ok := <-headers:
+			if !ok {
+				return true, fmt.Errorf("block subscription closed")
+			}
+			h, ok := ev.Data.(comettypes.EventDataNewBlockHeader)
+			if !ok {
+				continue
+			}
+			// the block header is published before the transactions of the block
+			ix.bt.times[h.Header.Height] = h.Header.Time
+			ix.handleEvents(ctx, h.Header.Height, h.ResultEndBlock.Events)
+		case ev, ok := <-txs:
+			if !ok {
+				return true, fmt.Errorf("tx subscription closed")
+			}
+			t, ok := ev.Data.(comettypes.EventDataTx)
+			if !ok || t.Result.Code != 0 {
+				continue
+			}
+			ix.handleEvents(ctx, t.Height, t.Result.Events)
+		case <-resync:
+			err := ix.resync(ctx)
+			if err != nil {
+				pterm.Warning.Println("failed to sync the pending orders: ", err)
+			}
+		}
+	}
+}
+
+// resync stores the pending orders of the hub and marks the stored ones that are no longer
+// pending on the hub. Only the block times of the pending orders are kept in the cache
+func (ix *OrderIndexer) resync(ctx context.Context) error {
+	orders, err := QueryHubDemandOrders(ix.hd, OrderStatuses.Pending)
+	if err != nil {
+		return err
+	}
+
+	ids := make([]string, 0, len(orders))
+	heights := make(map[int64]bool, len(orders))
+	for _, o := range orders {
+		if o.CreationHeight > 0 {
+			o.CreatedAt, _ = ix.bt.get(o.CreationHeight)
+			heights[o.CreationHeight] = true
+		}
+		err := ix.store.Upsert(ctx, o)
+		if err != nil {
+			return err
+		}
+		ids = append(ids, o.ID)
+	}
+	ix.bt.retain(func(height int64) bool { return heights[height] })
+
+	closed, err := ix.store.MarkNotPending(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	ix.mu.Lock()
+	ix.status.LastSync = time.Now()
+	ix.mu.Unlock()
+
+	pterm.Info.Printfln(
+		"synced %d pending orders from the hub, %d orders no longer pending",
+		len(orders),
+		closed,
+	)
+	return nil
+}
+
+// handleEvents applies the demand order events of a transaction or of the end of a block
+func (ix *OrderIndexer) handleEvents(
+	ctx context.Context,
+	height int64,
+	events []abcitypes.Event,
+) {
+	for _, e := range events {
+		if !strings.Contains(e.Type, "EventDemandOrder") {
+			continue
+		}
+
+		ev := txEvent{kind: e.Type, attrs: map[string]string{}}
+		for _, a := range e.Attributes {
+			ev.attrs[a.Key] = unquote(a.Value)
+		}
+
+		err := ix.applyEvent(ctx, height, ev)
+		if err != nil {
+			pterm.Warning.Printfln("failed to index %s: %v", e.Type, err)
+			continue
+		}
+
+		ix.mu.Lock()
+		ix.status.LastHeight = height
+		ix.status.LastEvent = time.Now()
+		ix.mu.Unlock()
+	}
+}
+
+func (ix *OrderIndexer) applyEvent(ctx context.Context, height int64, e txEvent) error {
+	orderID := e.attrs["order_id"]
+	if orderID == "" {
+		return fmt.Errorf("no order id")
+	}
+
+	switch {
+	case strings.HasSuffix(e.kind, "EventDemandOrderCreated"):
+		o := orderFromEvent(e)
+		if o.CreationHeight == 0 {
+			o.CreationHeight = height
+		}
+		o.CreatedAt, _ = ix.bt.get(o.CreationHeight)
+		if o.Status == "" {
+			o.Status = OrderStatuses.Pending
+		}
+		pterm.Info.Printfln("new demand order %s of %s", orderID, o.RollappID)
+		return ix.store.Upsert(ctx, o)
+	case strings.HasSuffix(e.kind, "EventDemandOrderDeleted"):
+		return ix.store.Delete(ctx, orderID)
+	default:
+		// fulfilments, fee updates and packet status updates
+		o := orderFromEvent(e)
+		if s := e.attrs["new_packet_status"]; s != "" {
+			o.Status = s
+		}
+		if f, _ := parseEventAmount(e.attrs["new_fee"]); f.IsPositive() {
+			o.Fee = f
+		}
+		if strings.HasSuffix(e.kind, "EventDemandOrderFulfilled") && o.FulfillerAddress == "" {
+			o.FulfillerAddress = "unknown"
+		}
+		return ix.store.Upsert(ctx, o)
+	}
+}
+
+// orderFromEvent parses the order fields of a demand order event, the missing fields are
+// left empty
+func orderFromEvent(e txEvent) DemandOrder {
+	price, priceDenom := parseEventAmount(e.attrs["price"])
+	fee, feeDenom := parseEventAmount(e.attrs["fee"])
+	denom := e.attrs["denom"]
+	if denom == "" {
+		denom = priceDenom
+	}
+	if denom == "" {
+		denom = feeDenom
+	}
+
+	o := DemandOrder{
+		ID:        e.attrs["order_id"],
+		RollappID: e.attrs["rollapp_id"],
+		Denom:     denom,
+		Price:     price,
+		Fee:       fee,
+		Status:    e.attrs["packet_status"],
+		Recipient: e.attrs["recipient"],
+	}
+	if e.attrs["fee"] == "" {
+		o.Fee = cosmossdkmath.Int{}
+	}
+	for _, k := range []string{"fulfiller", "fulfiller_address"} {
+		if v := e.attrs[k]; v != "" {
+			o.FulfillerAddress = v
+		}
+	}
+	o.CreationHeight, _ = strconv.ParseInt(e.attrs["creation_height"], 10, 64)
+	o.ProofHeight, _ = strconv.ParseInt(e.attrs["proof_height"], 10, 64)
+
+	return o
+}
+
+var (
+	graphqlNetworkRegex = regexp.MustCompile(`network:\s*\{\s*equalTo:\s*"([^"]*)"`)
+	graphqlStatusRegex  = regexp.MustCompile(`status:\s*\{\s*equalTo:\s*"?(\w+)"?`)
+)
+
+type graphqlRequest struct {
+	Query string `json:"query"`
+}
+
+type graphqlError struct {
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// Handler returns the http api of the indexer, the graphql endpoint only answers the
+// pending orders query of the eibc client order polling
+func (ix *OrderIndexer) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/health", ix.serveHealth)
+	mux.HandleFunc("/", ix.serveGraphql)
+	return mux
+}
+
+func (ix *OrderIndexer) serveHealth(w http.ResponseWriter, r *http.Request) {
+	status := ix.Status()
+	orders, err := ix.store.Pending(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	status.Pending = len(orders)
+	writeJSON(w, status)
+}
+
+func (ix *OrderIndexer) serveGraphql(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "only POST is supported", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req graphqlRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		http.Error(w, "invalid graphql request", http.StatusBadRequest)
+		return
+	}
+
+	if !strings.Contains(req.Query, "ibcTransferDetails") {
+		writeGraphqlError(w, "only the ibcTransferDetails query is supported")
+		return
+	}
+	if m := graphqlStatusRegex.FindStringSubmatch(req.Query); m != nil &&
+		m[1] != indexerPendingStatus {
+		writeGraphqlError(w, fmt.Sprintf("only the %s status is supported", indexerPendingStatus))
+		return
+	}
+
+	var resp IndexerOrdersResponse
+	resp.Data.IbcTransferDetails.Nodes = []IndexerOrder{}
+
+	m := graphqlNetworkRegex.FindStringSubmatch(req.Query)
+	if m == nil || m[1] == ix.hd.ID {
+		orders, err := ix.store.Pending(r.Context())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		for _, o := range orders {
+			resp.Data.IbcTransferDetails.Nodes = append(
+				resp.Data.IbcTransferDetails.Nodes,
+				o.IndexerOrder(),
+			)
+		}
+	}
+
+	writeJSON(w, resp)
+}
+
+func writeGraphqlError(w http.ResponseWriter, msg string) {
+	var resp graphqlError
+	resp.Errors = append(resp.Errors, struct {
+		Message string `json:"message"`
+	}{Message: msg})
+	writeJSON(w, resp)
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	// nolint errcheck
+	json.NewEncoder(w).Encode(v)
+}
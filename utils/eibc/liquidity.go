@@ -0,0 +1,442 @@
+package eibc
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	cosmossdkmath "cosmossdk.io/math"
+	cosmossdktypes "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/utils/bash"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/keys"
+	"github.com/dymensionxyz/roller/utils/tracing"
+)
+
+// AccountRoles are the roles of the accounts holding the eibc funds
+var AccountRoles = struct {
+	Whale     string
+	Policy    string
+	Fulfiller string
+}{
+	Whale:     "whale",
+	Policy:    "policy",
+	Fulfiller: "fulfiller",
+}
+
+// AccountFunds are the balances of an account used by the eibc client
+type AccountFunds struct {
+	Name     string
+	Address  string
+	Role     string
+	Balances cosmossdktypes.Coins
+}
+
+// DenomLiquidity is the liquidity of a denom across the eibc accounts. Demand is the price
+// of the unfulfilled orders pending finalization on the hub, i.e. the orders that still
+// need funds
+type DenomLiquidity struct {
+	Denom      string
+	Whale      cosmossdkmath.Int
+	Policy     cosmossdkmath.Int
+	Fulfillers cosmossdkmath.Int
+	Demand     cosmossdkmath.Int
+	Orders     int
+}
+
+// Available returns the funds that can be used to fulfill orders, the orders are
+// fulfilled with the funds of the policy
+func (l DenomLiquidity) Available() cosmossdkmath.Int {
+	return l.Policy
+}
+
+// Coverage returns the available funds as a multiple of the demand, it's negative when
+// there is no demand
+func (l DenomLiquidity) Coverage() float64 {
+	if !l.Demand.IsPositive() {
+		return -1
+	}
+	c, _ := cosmossdkmath.LegacyNewDecFromInt(l.Available()).QuoInt(l.Demand).Float64()
+	return c
+}
+
+// Low returns true when the available funds cover less than minCoverage times the demand
+func (l DenomLiquidity) Low(minCoverage float64) bool {
+	c := l.Coverage()
+	return c >= 0 && c < minCoverage
+}
+
+// QueryBalances returns all the balances of the address on the hub
+func QueryBalances(hd consts.HubData, address string) (cosmossdktypes.Coins, error) {
+	cmd := exec.Command(
+		consts.Executables.Dymension,
+		"q", "bank", "balances", address,
+		"--node", hd.RpcUrl,
+		"--chain-id", hd.ID,
+		"--output", "json",
+	)
+
+	ctx, span := tracing.StartHubQuery("eibc.QueryBalances", hd.ID, hd.RpcUrl)
+	out, err := bash.ExecCommandWithStdoutCtx(ctx, cmd)
+	tracing.End(span, err)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp keys.BalancesResp
+	err = json.Unmarshal(out.Bytes(), &resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse balances of %s: %w", address, err)
+	}
+
+	return cosmossdktypes.NewCoins(resp.Balances...), nil
+}
+
+// ListFulfillers returns the fulfiller keys created by the eibc client
+func ListFulfillers(cfg Config) ([]keys.KeyInfo, error) {
+	keyringDir, backend, err := fulfillersKeyring(cfg)
+	if err != nil || keyringDir == "" {
+		return nil, err
+	}
+
+	cmd := exec.Command(
+		consts.Executables.Dymension,
+		"keys", "list",
+		"--keyring-backend", backend,
+		"--keyring-dir", keyringDir,
+		"--output", "json",
+	)
+	out, err := bash.ExecCommandWithStdout(cmd)
+	if err != nil {
+		return nil, err
+	}
+	if strings.Contains(out.String(), "No records were found in keyring") {
+		return nil, nil
+	}
+
+	var ki []keys.KeyInfo
+	err = json.Unmarshal(out.Bytes(), &ki)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse fulfiller keys: %w", err)
+	}
+
+	return ki, nil
+}
+
+// fulfillersKeyring returns the keyring directory and backend of the fulfiller keys, the
+// directory is empty when the eibc client has no fulfillers
+func fulfillersKeyring(cfg Config) (string, string, error) {
+	keyringDir := cfg.Fulfillers.KeyringDir
+	if strings.HasPrefix(keyringDir, "~/") {
+		var err error
+		keyringDir, err = filesystem.ExpandHomePath(keyringDir)
+		if err != nil {
+			return "", "", err
+		}
+	}
+
+	backend := string(cfg.Fulfillers.KeyringBackend)
+	if backend == "" {
+		backend = string(consts.SupportedKeyringBackends.Test)
+	}
+
+	return keyringDir, backend, nil
+}
+
+// QueryFunds returns the balances of the whale account, the group policy and the
+// fulfillers
+func QueryFunds(home string, cfg Config, hd consts.HubData) ([]AccountFunds, error) {
+	whale, err := GetKeyConfig().Info(home)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve whale account: %w", err)
+	}
+
+	accounts := []AccountFunds{
+		{Name: consts.KeysIds.Eibc, Address: whale.Address, Role: AccountRoles.Whale},
+	}
+	if cfg.Fulfillers.PolicyAddress != "" {
+		accounts = append(accounts, AccountFunds{
+			Name:    "group policy",
+			Address: cfg.Fulfillers.PolicyAddress,
+			Role:    AccountRoles.Policy,
+		})
+	}
+
+	fulfillers, err := ListFulfillers(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list fulfillers: %w", err)
+	}
+	for _, f := range fulfillers {
+		if f.Address == whale.Address {
+			continue
+		}
+		accounts = append(accounts, AccountFunds{
+			Name:    f.Name,
+			Address: f.Address,
+			Role:    AccountRoles.Fulfiller,
+		})
+	}
+
+	for i := range accounts {
+		accounts[i].Balances, err = QueryBalances(hd, accounts[i].Address)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return accounts, nil
+}
+
+// Liquidity returns the liquidity of every denom held by the accounts or requested by
+// the unfulfilled orders pending finalization
+func Liquidity(accounts []AccountFunds, pending []DemandOrder) []DenomLiquidity {
+	byDenom := map[string]*DenomLiquidity{}
+	get := func(denom string) *DenomLiquidity {
+		l, ok := byDenom[denom]
+		if !ok {
+			l = &DenomLiquidity{
+				Denom:      denom,
+				Whale:      cosmossdkmath.ZeroInt(),
+				Policy:     cosmossdkmath.ZeroInt(),
+				Fulfillers: cosmossdkmath.ZeroInt(),
+				Demand:     cosmossdkmath.ZeroInt(),
+			}
+			byDenom[denom] = l
+		}
+		return l
+	}
+
+	for _, a := range accounts {
+		for _, c := range a.Balances {
+			l := get(c.Denom)
+			switch a.Role {
+			case AccountRoles.Whale:
+				l.Whale = l.Whale.Add(c.Amount)
+			case AccountRoles.Policy:
+				l.Policy = l.Policy.Add(c.Amount)
+			default:
+				l.Fulfillers = l.Fulfillers.Add(c.Amount)
+			}
+		}
+	}
+
+	for _, o := range pending {
+		// the fulfilled orders stay pending until finalization but no longer need funds
+		if o.Denom == "" || o.Fulfilled() {
+			continue
+		}
+		l := get(o.Denom)
+		l.Demand = l.Demand.Add(o.Price)
+		l.Orders++
+	}
+
+	result := make([]DenomLiquidity, 0, len(byDenom))
+	for _, l := range byDenom {
+		result = append(result, *l)
+	}
+	slices.SortFunc(result, func(a, b DenomLiquidity) int {
+		return strings.Compare(a.Denom, b.Denom)
+	})
+
+	return result
+}
+
+// QueryLiquidity returns the funds of the eibc accounts and the liquidity per denom, the
+// demand only includes the orders of the supported rollapps
+func QueryLiquidity(
+	home string,
+	cfg Config,
+	hd consts.HubData,
+) ([]AccountFunds, []DenomLiquidity, error) {
+	accounts, err := QueryFunds(home, cfg, hd)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pending, err := QueryHubDemandOrders(hd, OrderStatuses.Pending)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query the pending orders: %w", err)
+	}
+	if len(cfg.Rollapps) > 0 {
+		pending = slices.DeleteFunc(pending, func(o DemandOrder) bool {
+			_, ok := cfg.Rollapps[o.RollappID]
+			return !ok
+		})
+	}
+
+	return accounts, Liquidity(accounts, pending), nil
+}
+
+// Transfer moves funds between the whale account and the group policy
+type Transfer struct {
+	Denom    string
+	Amount   cosmossdkmath.Int
+	ToPolicy bool
+}
+
+// RebalanceTargets are the shares of the funds of each denom that should be held by the
+// group policy, the rest stays in the whale account
+type RebalanceTargets struct {
+	// Ratios are the policy shares per denom, Default is used for the other denoms
+	Ratios  map[string]float64
+	Default float64
+	// GasReserve is the amount of the hub denom kept in the whale account for the fees
+	GasReserve cosmossdkmath.Int
+	// MinTransfer skips the transfers smaller than this share of the denom funds
+	MinTransfer float64
+}
+
+// Ratio returns the policy share of the denom
+func (t RebalanceTargets) Ratio(denom string) float64 {
+	if r, ok := t.Ratios[denom]; ok {
+		return r
+	}
+	return t.Default
+}
+
+// Validate checks that the ratios are shares
+func (t RebalanceTargets) Validate() error {
+	ratios := map[string]float64{"default": t.Default}
+	for d, r := range t.Ratios {
+		ratios[d] = r
+	}
+	for d, r := range ratios {
+		if r < 0 || r > 1 {
+			return fmt.Errorf("ratio of %s must be between 0 and 1, got %v", d, r)
+		}
+	}
+	if t.GasReserve.IsNegative() {
+		return fmt.Errorf("gas reserve can't be negative")
+	}
+	return nil
+}
+
+// PlanRebalance returns the transfers that bring the policy share of every denom to its
+// target ratio, the gas reserve of the whale account is never transferred
+func PlanRebalance(liquidity []DenomLiquidity, targets RebalanceTargets) []Transfer {
+	var transfers []Transfer
+	for _, l := range liquidity {
+		whale := l.Whale
+		if l.Denom == consts.Denoms.Hub && !targets.GasReserve.IsNil() {
+			whale = whale.Sub(targets.GasReserve)
+			if whale.IsNegative() {
+				whale = cosmossdkmath.ZeroInt()
+			}
+		}
+
+		total := whale.Add(l.Policy)
+		if !total.IsPositive() {
+			continue
+		}
+
+		totalDec := cosmossdkmath.LegacyNewDecFromInt(total)
+		desired := totalDec.Mul(decFromFloat(targets.Ratio(l.Denom))).TruncateInt()
+		diff := desired.Sub(l.Policy)
+
+		minTransfer := totalDec.Mul(decFromFloat(targets.MinTransfer)).TruncateInt()
+		if diff.Abs().IsZero() || diff.Abs().LT(minTransfer) {
+			continue
+		}
+
+		if diff.IsPositive() {
+			transfers = append(transfers, Transfer{
+				Denom:    l.Denom,
+				Amount:   cosmossdkmath.MinInt(diff, whale),
+				ToPolicy: true,
+			})
+		} else {
+			transfers = append(transfers, Transfer{Denom: l.Denom, Amount: diff.Neg()})
+		}
+	}
+
+	return transfers
+}
+
+func decFromFloat(f float64) cosmossdkmath.LegacyDec {
+	d, err := cosmossdkmath.LegacyNewDecFromStr(strconv.FormatFloat(f, 'f', 6, 64))
+	if err != nil {
+		return cosmossdkmath.LegacyZeroDec()
+	}
+	return d
+}
+
+// RebalanceResult are the transfers executed by a rebalance, the withdrawal from the policy
+// is only done once its proposal is executed
+type RebalanceResult struct {
+	Funded     cosmossdktypes.Coins
+	Withdrawal cosmossdktypes.Coins
+	// Proposal is the outcome of the withdrawal proposal, nil without withdrawal
+	Proposal *ProposalOutcome
+}
+
+// ExecuteRebalance sends the transfers to the policy from the whale account and submits
+// a group proposal, executed immediately when the decision policy allows it, for the
+// transfers from the policy
+func ExecuteRebalance(
+	home string,
+	cfg Config,
+	transfers []Transfer,
+	hd consts.HubData,
+) (*RebalanceResult, error) {
+	eibcHome := filepath.Join(home, consts.ConfigDirName.Eibc)
+	whale, err := GetKeyConfig().Info(home)
+	if err != nil {
+		return nil, err
+	}
+
+	var toPolicy, toWhale cosmossdktypes.Coins
+	for _, t := range transfers {
+		if !t.Amount.IsPositive() {
+			continue
+		}
+		c := cosmossdktypes.NewCoin(t.Denom, t.Amount)
+		if t.ToPolicy {
+			toPolicy = toPolicy.Add(c)
+		} else {
+			toWhale = toWhale.Add(c)
+		}
+	}
+
+	result := &RebalanceResult{}
+	if !toPolicy.Empty() {
+		_, err := execEibcTx(
+			eibcHome,
+			hd,
+			"tx", "bank", "send", consts.KeysIds.Eibc, cfg.Fulfillers.PolicyAddress,
+			toPolicy.String(),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fund the policy: %w", err)
+		}
+		result.Funded = toPolicy
+	}
+
+	if !toWhale.Empty() {
+		txHash, err := SubmitGroupProposal(eibcHome, GroupProposal{
+			GroupPolicyAddress: cfg.Fulfillers.PolicyAddress,
+			Messages: []any{
+				NewBankSendMsg(cfg.Fulfillers.PolicyAddress, whale.Address, toWhale),
+			},
+			Proposers: []string{whale.Address},
+			Title:     "eibc liquidity rebalance",
+			Summary:   fmt.Sprintf("withdraw %s to the whale account", toWhale),
+		}, true, hd)
+		if err != nil {
+			return result, fmt.Errorf("failed to withdraw from the policy: %w", err)
+		}
+		result.Withdrawal = toWhale
+
+		result.Proposal, err = QueryProposalOutcome(eibcHome, txHash, hd)
+		if err != nil {
+			return result, fmt.Errorf("failed to query the withdrawal proposal: %w", err)
+		}
+	}
+
+	return result, nil
+}
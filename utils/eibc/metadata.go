@@ -0,0 +1,347 @@
+package eibc
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	cosmossdkmath "cosmossdk.io/math"
+	cosmossdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/pterm/pterm"
+	"gopkg.in/yaml.v3"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/utils/bash"
+)
+
+// OperatorMetadataFile is the draft of the operator metadata edited with
+// 'roller eibc operator metadata edit', relative to the eibc home
+const OperatorMetadataFile = "operator-metadata.yaml"
+
+const operatorMetadataHeader = `# eibc operator metadata, published in the operator group metadata
+# run 'roller eibc operator metadata apply' to publish the changes
+`
+
+// OperatorMetadataPath returns the path of the operator metadata draft
+func OperatorMetadataPath(eibcHome string) string {
+	return filepath.Join(eibcHome, OperatorMetadataFile)
+}
+
+// GetGroup returns the operator group
+func GetGroup(gi GroupInfo) (*Group, error) {
+	cmd := exec.Command(
+		consts.Executables.Dymension,
+		"q", "group", "group-info", gi.GroupID,
+		"-o", "json",
+		"--node", gi.Hub.RpcUrl,
+		"--chain-id", gi.Hub.ID,
+		"--home", gi.EibcHome,
+	)
+
+	out, err := bash.ExecCommandWithStdout(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Info Group `json:"info"`
+	}
+	err = json.Unmarshal(out.Bytes(), &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.Info, nil
+}
+
+// OperatorMetadataOnChain returns the operator group and its metadata
+func OperatorMetadataOnChain(gi GroupInfo) (*EibcOperatorMetadata, *Group, error) {
+	g, err := GetGroup(gi)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to retrieve the operator group: %w", err)
+	}
+
+	m, err := decodeOperatorMetadata(g.Metadata)
+	if err != nil {
+		return nil, nil, err
+	}
+	return m, g, nil
+}
+
+// decodeOperatorMetadata decodes the base64 json metadata of the group, metadata that isn't
+// base64 encoded is the moniker set by older versions of roller
+func decodeOperatorMetadata(raw string) (*EibcOperatorMetadata, error) {
+	var m EibcOperatorMetadata
+	if raw == "" {
+		return &m, nil
+	}
+
+	b, err := base64.StdEncoding.DecodeString(raw)
+	if err != nil {
+		m.Moniker = raw
+		return &m, nil
+	}
+
+	err = json.Unmarshal(b, &m)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse operator metadata: %w", err)
+	}
+	return &m, nil
+}
+
+// ParseOperatorMetadata parses the yaml metadata, unknown fields are rejected
+func ParseOperatorMetadata(b []byte) (*EibcOperatorMetadata, error) {
+	var m EibcOperatorMetadata
+	dec := yaml.NewDecoder(bytes.NewReader(b))
+	dec.KnownFields(true)
+
+	err := dec.Decode(&m)
+	if err != nil {
+		return nil, fmt.Errorf("invalid operator metadata: %w", err)
+	}
+	return &m, nil
+}
+
+// LoadOperatorMetadataFile reads the yaml metadata from the file
+func LoadOperatorMetadataFile(path string) (*EibcOperatorMetadata, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseOperatorMetadata(b)
+}
+
+// WriteOperatorMetadataFile writes the metadata to the file in yaml
+func WriteOperatorMetadataFile(path string, m *EibcOperatorMetadata) error {
+	b, err := yaml.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append([]byte(operatorMetadataHeader), b...), 0o644)
+}
+
+// Validate checks the metadata against the eibc client config
+func (m *EibcOperatorMetadata) Validate(cfg Config) error {
+	var errs []error
+
+	if strings.TrimSpace(m.Moniker) == "" {
+		errs = append(errs, errors.New("moniker can't be empty"))
+	}
+	if m.FeeShare < 0 || m.FeeShare > 1 {
+		errs = append(errs, fmt.Errorf("fee_share must be between 0 and 1, got %v", m.FeeShare))
+	}
+
+	if m.PolicyAddress != "" {
+		_, err := cosmossdktypes.GetFromBech32(m.PolicyAddress, consts.AddressPrefixes.Hub)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid policy_address: %w", err))
+		} else if cfg.Fulfillers.PolicyAddress != "" &&
+			m.PolicyAddress != cfg.Fulfillers.PolicyAddress {
+			errs = append(errs, fmt.Errorf(
+				"policy_address %s is not the policy of the eibc client %s",
+				m.PolicyAddress,
+				cfg.Fulfillers.PolicyAddress,
+			))
+		}
+	}
+
+	for i, raID := range m.SupportedRollapps {
+		if slices.Index(m.SupportedRollapps, raID) != i {
+			errs = append(errs, fmt.Errorf("%s is listed twice in supported_rollapps", raID))
+			continue
+		}
+		if _, ok := cfg.Rollapps[raID]; !ok {
+			errs = append(errs, fmt.Errorf("%s is not supported by the eibc client", raID))
+		}
+	}
+
+	if w := m.ContactDetails.Website; w != "" {
+		u, err := url.Parse(w)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errs = append(errs, fmt.Errorf("website must be an http(s) url, got %s", w))
+		}
+	}
+
+	if m.FulfillPolicy != nil {
+		for _, e := range m.FulfillPolicy.Entries() {
+			var maxSize *cosmossdkmath.Int
+			if e.MaxOrderSize != "" {
+				size, err := ParseMaxOrderSize(e.MaxOrderSize)
+				if err != nil {
+					errs = append(errs, err)
+					continue
+				}
+				maxSize = &size
+			}
+			err := ValidatePolicyEntry(cfg, e.Target, e.Key, e.MinFeePercentage, maxSize)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("fulfill_policy %s %s: %w", e.Target, e.Key, err))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// OperatorMetadataDiff returns the unified diff of the yaml metadata, it's empty when the
+// metadata are identical
+func OperatorMetadataDiff(onChain, edited *EibcOperatorMetadata) (string, error) {
+	a, err := yaml.Marshal(onChain)
+	if err != nil {
+		return "", err
+	}
+	b, err := yaml.Marshal(edited)
+	if err != nil {
+		return "", err
+	}
+
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(a)),
+		B:        difflib.SplitLines(string(b)),
+		FromFile: "on-chain",
+		ToFile:   "edited",
+		Context:  3,
+	})
+}
+
+// ApplyOperatorMetadata publishes the metadata in the operator group. The group is updated
+// with a proposal of the group policy when the policy administers the group, and by the
+// signing member when it administers the group itself
+func ApplyOperatorMetadata(gi GroupInfo, g Group, m *EibcOperatorMetadata) (string, error) {
+	mb, err := m.ToBytes()
+	if err != nil {
+		return "", err
+	}
+	mbs := base64.StdEncoding.EncodeToString(mb)
+
+	switch g.Admin {
+	case gi.PolicyAddress:
+		p := GroupProposal{
+			GroupPolicyAddress: gi.PolicyAddress,
+			Messages: []any{
+				map[string]any{
+					"@type":    "/cosmos.group.v1.MsgUpdateGroupMetadata",
+					"admin":    gi.PolicyAddress,
+					"group_id": gi.GroupID,
+					"metadata": mbs,
+				},
+			},
+			Proposers: []string{gi.Member.Address},
+			Title:     "update eibc operator metadata",
+			Summary:   fmt.Sprintf("update the metadata of the eibc operator %s", m.Moniker),
+		}
+		return SubmitGroupProposal(gi.EibcHome, p, true, gi.Hub)
+	case gi.Member.Address:
+		return execMemberTx(
+			gi,
+			"tx", "group", "update-group-metadata", g.Admin, gi.GroupID, mbs,
+		)
+	default:
+		return "", fmt.Errorf(
+			"the group admin %s is neither %s nor the group policy",
+			g.Admin,
+			gi.Member.Address,
+		)
+	}
+}
+
+// UpdateOperatorMetadata applies the update to the onchain metadata of the operator group
+// and publishes it with ApplyOperatorMetadata, the metadata of a group without metadata is
+// created first. The updated metadata is returned with the transaction hash
+func UpdateOperatorMetadata(
+	gi GroupInfo,
+	update func(m *EibcOperatorMetadata),
+) (*EibcOperatorMetadata, string, error) {
+	m, g, err := operatorMetadataOrNew(gi)
+	if err != nil {
+		return nil, "", err
+	}
+
+	update(m)
+	txHash, err := ApplyOperatorMetadata(gi, *g, m)
+	if err != nil {
+		return nil, "", err
+	}
+	return m, txHash, nil
+}
+
+// operatorMetadataOrNew returns the onchain metadata of the operator group, the metadata of
+// a group without metadata is created first
+func operatorMetadataOrNew(gi GroupInfo) (*EibcOperatorMetadata, *Group, error) {
+	m, g, err := OperatorMetadataOnChain(gi)
+	if err != nil {
+		return nil, nil, err
+	}
+	if g.Metadata == "" {
+		raIDs, err := LoadSupportedRollapps(filepath.Join(gi.EibcHome, "config.yaml"))
+		if err != nil {
+			return nil, nil, err
+		}
+		m = NewEibcOperatorMetadata(raIDs)
+	}
+	return m, g, nil
+}
+
+// UpdateGroupOperatorMetadata applies the update to the onchain metadata of the operator
+// group of the eibc client and prints the new metadata
+func UpdateGroupOperatorMetadata(
+	eibcConfigPath string,
+	cfg Config,
+	home string,
+	update func(m *EibcOperatorMetadata),
+) error {
+	rspn, _ := pterm.DefaultSpinner.Start("updating eibc operator metadata")
+	hd, err := cfg.HubDataFromHubRpc(eibcConfigPath)
+	if err != nil {
+		rspn.Fail("failed to retrieve hub data")
+		return err
+	}
+	gi, err := LoadGroupInfo(home)
+	if err != nil {
+		rspn.Fail("failed to retrieve the operator group")
+		return err
+	}
+	gi.Hub = *hd
+
+	rspn.UpdateText("pushing changes to chain")
+	m, _, err := UpdateOperatorMetadata(*gi, update)
+	if err != nil {
+		rspn.Fail("failed to update eibc operator metadata")
+		return err
+	}
+	rspn.Success("operator metadata updated, new metadata:")
+
+	ym, err := yaml.Marshal(m)
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(ym))
+	return nil
+}
+
+// PrintDiff prints the unified diff with the removed lines in red and the added ones in green
+func PrintDiff(diff string) {
+	for _, line := range strings.Split(strings.TrimRight(diff, "\n"), "\n") {
+		switch {
+		case strings.HasPrefix(line, "---"), strings.HasPrefix(line, "+++"):
+			pterm.Println(pterm.Bold.Sprint(line))
+		case strings.HasPrefix(line, "-"):
+			pterm.Println(pterm.FgRed.Sprint(line))
+		case strings.HasPrefix(line, "+"):
+			pterm.Println(pterm.FgGreen.Sprint(line))
+		case strings.HasPrefix(line, "@@"):
+			pterm.Println(pterm.FgCyan.Sprint(line))
+		default:
+			pterm.Println(line)
+		}
+	}
+}
@@ -1,19 +1,12 @@
 package eibc
 
 import (
-	"encoding/base64"
 	"encoding/json"
-	"fmt"
-	"os/exec"
-	"path/filepath"
 	"strings"
 
 	"github.com/pterm/pterm"
-	"gopkg.in/yaml.v3"
 
 	"github.com/dymensionxyz/roller/cmd/consts"
-	"github.com/dymensionxyz/roller/utils/bash"
-	"github.com/dymensionxyz/roller/utils/tx"
 )
 
 // TODO: refactor everything here, it'll be a lot easier and cleaner to use io.Reader/Writer + []byte
@@ -27,6 +20,9 @@ type EibcOperatorMetadata struct {
 	PolicyAddress     string                     `json:"policy_address"     yaml:"policy_address"`
 	FeeShare          float64                    `json:"fee_share"          yaml:"fee_share"`
 	SupportedRollapps []string                   `json:"supported_rollapps" yaml:"supported_rollapps"`
+	// FulfillPolicy publishes the fulfill criteria of the operator, it's omitted when the
+	// operator has no policy
+	FulfillPolicy *FulfillPolicy `json:"fulfill_policy,omitempty" yaml:"fulfill_policy,omitempty"`
 }
 
 // EibcOperatorContactDetails struct represents the contact details for the eibc operator
@@ -141,206 +137,63 @@ func NewEibcOperatorMetadata(raIDs []string) *EibcOperatorMetadata {
 	return metadata
 }
 
-func getUpdateEibcOperatorMetadataCmd(
-	eibcHome string,
-	adminAddr, groupID, metadata string,
-	hd consts.HubData,
-) *exec.Cmd {
-	cmd := exec.Command(
-		consts.Executables.Dymension,
-		"tx",
-		"group",
-		"update-group-metadata",
-		adminAddr,
-		groupID,
-		metadata,
-		"--home",
-		eibcHome,
-		"--node",
-		hd.RpcUrl,
-		"--chain-id",
-		hd.ID,
-		"--keyring-backend",
-		"test",
-		"--fees",
-		fmt.Sprintf("%d%s", consts.DefaultTxFee, consts.Denoms.Hub),
-		"-y",
-	)
-
-	return cmd
-}
-
+// UpdateEibcOperatorMetadata publishes the base64 encoded metadata in the operator group of
+// the eibc client
 func UpdateEibcOperatorMetadata(home, metadata string, hd consts.HubData) error {
-	eibcHome := filepath.Join(home, consts.ConfigDirName.Eibc)
-	kc := GetKeyConfig()
-	ki, err := kc.Info(home)
-	if err != nil {
-		return err
-	}
-
-	gid, err := GetGroups(eibcHome, ki.Address, hd)
-	if err != nil {
-		return err
-	}
-
-	c := getUpdateEibcOperatorMetadataCmd(eibcHome, ki.Address, gid.Groups[0].ID, metadata, hd)
-
-	out, err := bash.ExecCommandWithStdout(c)
-	if err != nil {
-		pterm.Error.Println("failed to create group: ", err)
-		return err
-	}
-
-	txHash, err := bash.ExtractTxHash(out.String())
+	m, err := decodeOperatorMetadata(metadata)
 	if err != nil {
-		pterm.Error.Println("failed to extract tx hash: ", err)
 		return err
 	}
 
-	err = tx.MonitorTransaction(hd.RpcUrl, txHash)
+	gi, err := LoadGroupInfo(home)
 	if err != nil {
 		return err
 	}
+	gi.Hub = hd
 
-	return nil
+	_, _, err = UpdateOperatorMetadata(*gi, func(om *EibcOperatorMetadata) { *om = *m })
+	return err
 }
 
+// EibcOperatorMetadataFromChain returns the onchain metadata of the operator group of the
+// eibc client, new metadata is returned when the group has none
 func EibcOperatorMetadataFromChain(
 	home string,
 	hd consts.HubData,
 ) (*EibcOperatorMetadata, error) {
-	eibcHome := filepath.Join(home, consts.ConfigDirName.Eibc)
-	kc := GetKeyConfig()
-
-	ki, err := kc.Info(home)
-	if err != nil {
-		return nil, err
-	}
-
-	pol, err := GetGroups(eibcHome, ki.Address, hd)
+	gi, err := LoadGroupInfo(home)
 	if err != nil {
 		return nil, err
 	}
+	gi.Hub = hd
 
-	if pol.Groups[0].Metadata != "" {
-		metadataB64 := pol.Groups[0].Metadata
-		var m EibcOperatorMetadata
-		metadata, err := base64.StdEncoding.DecodeString(metadataB64)
-		if err != nil {
-			pterm.Warning.Println("not base 64 decodeable")
-			m.Moniker = pol.Groups[0].Metadata
-
-			return &m, nil
-		}
-
-		err = json.Unmarshal(metadata, &m)
-		if err != nil {
-			return nil, err
-		}
-		return &m, nil
-	}
-
-	raIDs, err := LoadSupportedRollapps(filepath.Join(eibcHome, "config.yaml"))
-	if err != nil {
-		return nil, err
-	}
-	m := NewEibcOperatorMetadata(raIDs)
-	return m, nil
+	m, _, err := operatorMetadataOrNew(*gi)
+	return m, err
 }
 
 // UpdateGroupSupportedRollapps function updates the supported rollapps list in the onchain metadata
 // of group and group-policy and returns an error if any
 func UpdateGroupSupportedRollapps(eibcConfigPath string, cfg Config, home string) error {
-	rspn, _ := pterm.DefaultSpinner.Start("updating eibc operator metadata")
-	rspn.UpdateText("retrieving updated supported rollapp list")
 	raIDs, err := LoadSupportedRollapps(eibcConfigPath)
 	if err != nil {
 		pterm.Error.Println("failed to load supported rollapps: ", err)
 		return err
 	}
-	hd, err := cfg.HubDataFromHubRpc(eibcConfigPath)
-	if err != nil {
-		pterm.Error.Println("failed to retrieve hub data: ", err)
-		return err
-	}
 
-	rspn.UpdateText("retrieving existing eibc operator metadata")
-	metadata, err := EibcOperatorMetadataFromChain(home, *hd)
-	if err != nil {
-		pterm.Error.Println("failed to retrieve eibc operator metadata: ", err)
-		return err
-	}
-	rspn.UpdateText("updating supported rollapp list")
-	metadata.SupportedRollapps = raIDs
-
-	mb, err := metadata.ToBytes()
-	if err != nil {
-		pterm.Error.Println("failed to generate eibc operator metadata: ", err)
-		return err
-	}
-	mbs := base64.StdEncoding.EncodeToString(mb)
-
-	rspn.UpdateText("pushing changes to chain")
-	err = UpdateEibcOperatorMetadata(home, mbs, *hd)
-	if err != nil {
-		pterm.Error.Println("failed to update eibc operator metadata: ", err)
-		return err
-	}
-	rspn.Success("operator metadata updated, new metadata:")
-	ym, err := yaml.Marshal(metadata)
-	if err != nil {
-		pterm.Error.Println("failed to marshal eibc operator metadata: ", err)
-		return err
-	}
-	fmt.Println(string(ym))
-	return nil
+	return UpdateGroupOperatorMetadata(eibcConfigPath, cfg, home, func(m *EibcOperatorMetadata) {
+		m.SupportedRollapps = raIDs
+	})
 }
 
-// UpdateGroupSupportedRollapps function updates the supported rollapps list in the onchain metadata
-// of group and group-policy and returns an error if any
+// UpdateGroupOperatorMinFee updates the fee share in the onchain metadata of the operator
+// group and returns an error if any
 func UpdateGroupOperatorMinFee(
 	eibcConfigPath string,
 	feeShare float64,
 	cfg Config,
 	home string,
 ) error {
-	rspn, _ := pterm.DefaultSpinner.Start("updating eibc operator metadata")
-	rspn.UpdateText("retrieving updated supported rollapp list")
-	hd, err := cfg.HubDataFromHubRpc(eibcConfigPath)
-	if err != nil {
-		pterm.Error.Println("failed to retrieve hub data: ", err)
-		return err
-	}
-
-	rspn.UpdateText("retrieving existing eibc operator metadata")
-	metadata, err := EibcOperatorMetadataFromChain(home, *hd)
-	if err != nil {
-		pterm.Error.Println("failed to retrieve eibc operator metadata: ", err)
-		return err
-	}
-
-	rspn.UpdateText("updating supported rollapp list")
-	metadata.FeeShare = feeShare
-
-	mb, err := metadata.ToBytes()
-	if err != nil {
-		pterm.Error.Println("failed to generate eibc operator metadata: ", err)
-		return err
-	}
-	mbs := base64.StdEncoding.EncodeToString(mb)
-
-	rspn.UpdateText("pushing changes to chain")
-	err = UpdateEibcOperatorMetadata(home, mbs, *hd)
-	if err != nil {
-		pterm.Error.Println("failed to update eibc operator metadata: ", err)
-		return err
-	}
-	rspn.Success("operator metadata updated, new metadata:")
-	ym, err := yaml.Marshal(metadata)
-	if err != nil {
-		pterm.Error.Println("failed to marshal eibc operator metadata: ", err)
-		return err
-	}
-	fmt.Println(string(ym))
-	return nil
+	return UpdateGroupOperatorMetadata(eibcConfigPath, cfg, home, func(m *EibcOperatorMetadata) {
+		m.FeeShare = feeShare
+	})
 }
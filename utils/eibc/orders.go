@@ -0,0 +1,433 @@
+package eibc
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	cosmossdkmath "cosmossdk.io/math"
+	cosmossdktypes "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/utils/bash"
+	"github.com/dymensionxyz/roller/utils/tracing"
+	"github.com/dymensionxyz/roller/utils/tx"
+)
+
+// OrderStatuses are the packet statuses of the demand orders on the hub
+var OrderStatuses = struct {
+	Pending   string
+	Finalized string
+	Reverted  string
+}{
+	Pending:   "PENDING",
+	Finalized: "FINALIZED",
+	Reverted:  "REVERTED",
+}
+
+// FulfillmentStatuses are the values of the order fulfilment filter
+var FulfillmentStatuses = struct {
+	Unfulfilled string
+	Fulfilled   string
+	Any         string
+}{
+	Unfulfilled: "unfulfilled",
+	Fulfilled:   "fulfilled",
+	Any:         "any",
+}
+
+// DemandOrder is an eibc demand order, the fulfiller pays Price to the recipient and
+// receives Price+Fee once the rollapp state is finalized
+type DemandOrder struct {
+	ID               string            `json:"id"`
+	RollappID        string            `json:"rollapp_id"`
+	Denom            string            `json:"denom"`
+	Price            cosmossdkmath.Int `json:"price"`
+	Fee              cosmossdkmath.Int `json:"fee"`
+	Status           string            `json:"status"`
+	Recipient        string            `json:"recipient,omitempty"`
+	FulfillerAddress string            `json:"fulfiller_address,omitempty"`
+	CreationHeight   int64             `json:"creation_height"`
+	CreatedAt        time.Time         `json:"created_at"`
+	ProofHeight      int64             `json:"proof_height,omitempty"`
+}
+
+// Fulfilled returns true when the order was fulfilled by someone
+func (o DemandOrder) Fulfilled() bool {
+	return o.FulfillerAddress != ""
+}
+
+// Amount returns the amount received by the fulfiller on finalization
+func (o DemandOrder) Amount() cosmossdkmath.Int {
+	return o.Price.Add(o.Fee)
+}
+
+// FeePercentage returns the fee of the order as a percentage of its amount
+func (o DemandOrder) FeePercentage() float64 {
+	amount := o.Amount()
+	if !amount.IsPositive() {
+		return 0
+	}
+
+	pct, _ := cosmossdkmath.LegacyNewDecFromInt(o.Fee).
+		QuoInt(amount).
+		MulInt64(100).
+		Float64()
+	return pct
+}
+
+// ExpectedProfit returns the part of the order fee earned by the operator at the fee share
+func (o DemandOrder) ExpectedProfit(feeShare float64) cosmossdkmath.Int {
+	share, err := cosmossdkmath.LegacyNewDecFromStr(strconv.FormatFloat(feeShare, 'f', -1, 64))
+	if err != nil {
+		return cosmossdkmath.ZeroInt()
+	}
+	return cosmossdkmath.LegacyNewDecFromInt(o.Fee).Mul(share).TruncateInt()
+}
+
+// OrderFilter selects the demand orders to show, the zero value matches all the orders
+type OrderFilter struct {
+	RollappID        string
+	Denom            string
+	MinFeePercentage float64
+	// MaxAge ignores the orders created before now-MaxAge, zero disables the filter
+	MaxAge      time.Duration
+	Fulfillment string
+}
+
+// Match returns true when the order passes all the filters
+func (f OrderFilter) Match(o DemandOrder, now time.Time) bool {
+	if f.RollappID != "" && o.RollappID != f.RollappID {
+		return false
+	}
+	if f.Denom != "" && o.Denom != f.Denom {
+		return false
+	}
+	if o.FeePercentage() < f.MinFeePercentage {
+		return false
+	}
+	if f.MaxAge > 0 && !o.CreatedAt.IsZero() && now.Sub(o.CreatedAt) > f.MaxAge {
+		return false
+	}
+
+	switch f.Fulfillment {
+	case FulfillmentStatuses.Unfulfilled:
+		return !o.Fulfilled()
+	case FulfillmentStatuses.Fulfilled:
+		return o.Fulfilled()
+	}
+	return true
+}
+
+// FilterOrders returns the orders that match the filter
+func FilterOrders(orders []DemandOrder, f OrderFilter, now time.Time) []DemandOrder {
+	var matched []DemandOrder
+	for _, o := range orders {
+		if f.Match(o, now) {
+			matched = append(matched, o)
+		}
+	}
+	return matched
+}
+
+type hubDemandOrder struct {
+	ID                   string               `json:"id"`
+	Price                cosmossdktypes.Coins `json:"price"`
+	Fee                  cosmossdktypes.Coins `json:"fee"`
+	Recipient            string               `json:"recipient"`
+	TrackingPacketStatus string               `json:"tracking_packet_status"`
+	RollappID            string               `json:"rollapp_id"`
+	FulfillerAddress     string               `json:"fulfiller_address"`
+	CreationHeight       json.Number          `json:"creation_height"`
+	IsFulfilled          bool                 `json:"is_fulfilled"`
+}
+
+type hubDemandOrdersResponse struct {
+	DemandOrders []hubDemandOrder `json:"demand_orders"`
+}
+
+// QueryHubDemandOrders returns the demand orders with the packet status from the hub
+func QueryHubDemandOrders(hd consts.HubData, status string) ([]DemandOrder, error) {
+	cmd := exec.Command(
+		consts.Executables.Dymension,
+		"q", "eibc", "list-demand-orders", status,
+		"--node", hd.RpcUrl,
+		"--chain-id", hd.ID,
+		"--output", "json",
+	)
+
+	ctx, span := tracing.StartHubQuery("eibc.QueryHubDemandOrders", hd.ID, hd.RpcUrl)
+	out, err := bash.ExecCommandWithStdoutCtx(ctx, cmd)
+	tracing.End(span, err)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp hubDemandOrdersResponse
+	err = json.Unmarshal(out.Bytes(), &resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse demand orders: %w", err)
+	}
+
+	orders := make([]DemandOrder, 0, len(resp.DemandOrders))
+	for _, do := range resp.DemandOrders {
+		o := DemandOrder{
+			ID:               do.ID,
+			RollappID:        do.RollappID,
+			Price:            cosmossdkmath.ZeroInt(),
+			Fee:              cosmossdkmath.ZeroInt(),
+			Status:           do.TrackingPacketStatus,
+			Recipient:        do.Recipient,
+			FulfillerAddress: do.FulfillerAddress,
+		}
+		if len(do.Price) > 0 {
+			o.Denom = do.Price[0].Denom
+			o.Price = do.Price[0].Amount
+		}
+		if len(do.Fee) > 0 {
+			o.Fee = do.Fee[0].Amount
+			if o.Denom == "" {
+				o.Denom = do.Fee[0].Denom
+			}
+		}
+		if do.IsFulfilled && o.FulfillerAddress == "" {
+			o.FulfillerAddress = "unknown"
+		}
+		o.CreationHeight, _ = do.CreationHeight.Int64()
+
+		orders = append(orders, o)
+	}
+
+	return orders, nil
+}
+
+// IndexerOrdersQuery is the graphql query of the pending eibc orders of a hub, it's the
+// query the eibc-client order polling sends to the indexer
+const IndexerOrdersQuery = `{ibcTransferDetails(filter: {network: {equalTo: "%s"} status: {equalTo: EibcPending}}) {nodes {eibcOrderId amount denom price eibcFee rollappId blockHeight blockTimestamp proofHeight packetStatus fulfillerAddress recipient}}}`
+
+// IndexerOrder is a demand order in the format served by the indexer
+type IndexerOrder struct {
+	EibcOrderID      string `json:"eibcOrderId"`
+	Amount           string `json:"amount"`
+	Denom            string `json:"denom"`
+	Price            string `json:"price"`
+	EibcFee          string `json:"eibcFee"`
+	RollappID        string `json:"rollappId"`
+	BlockHeight      string `json:"blockHeight"`
+	BlockTimestamp   string `json:"blockTimestamp"`
+	ProofHeight      string `json:"proofHeight"`
+	PacketStatus     string `json:"packetStatus"`
+	FulfillerAddress string `json:"fulfillerAddress"`
+	Recipient        string `json:"recipient"`
+}
+
+// IndexerOrdersResponse is the graphql response of the indexer
+type IndexerOrdersResponse struct {
+	Data struct {
+		IbcTransferDetails struct {
+			Nodes []IndexerOrder `json:"nodes"`
+		} `json:"ibcTransferDetails"`
+	} `json:"data"`
+}
+
+// QueryIndexerDemandOrders returns the pending demand orders of the hub from the indexer
+func QueryIndexerDemandOrders(indexerURL, hubID string) ([]DemandOrder, error) {
+	body, err := json.Marshal(map[string]string{
+		"query": fmt.Sprintf(IndexerOrdersQuery, hubID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	c := http.Client{Timeout: 10 * time.Second}
+	resp, err := c.Post(indexerURL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query indexer: %w", err)
+	}
+	// nolint: errcheck
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("indexer returned status %d: %s", resp.StatusCode, b)
+	}
+
+	var ir IndexerOrdersResponse
+	err = json.NewDecoder(resp.Body).Decode(&ir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode indexer response: %w", err)
+	}
+
+	orders := make([]DemandOrder, 0, len(ir.Data.IbcTransferDetails.Nodes))
+	for _, n := range ir.Data.IbcTransferDetails.Nodes {
+		orders = append(orders, n.DemandOrder())
+	}
+
+	return orders, nil
+}
+
+var coinRegex = regexp.MustCompile(`^([0-9]+)(.*)$`)
+
+// parseAmount parses an amount that may be suffixed with its denom
+func parseAmount(s string) (cosmossdkmath.Int, string) {
+	m := coinRegex.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return cosmossdkmath.ZeroInt(), ""
+	}
+	amount, ok := cosmossdkmath.NewIntFromString(m[1])
+	if !ok {
+		return cosmossdkmath.ZeroInt(), ""
+	}
+	return amount, m[2]
+}
+
+// DemandOrder converts the indexer order, the price is derived from the amount when the
+// indexer doesn't provide it
+func (n IndexerOrder) DemandOrder() DemandOrder {
+	amount, amountDenom := parseAmount(n.Amount)
+	fee, feeDenom := parseAmount(n.EibcFee)
+	price, priceDenom := parseAmount(n.Price)
+	if n.Price == "" && amount.GTE(fee) {
+		price = amount.Sub(fee)
+	}
+
+	denom := n.Denom
+	for _, d := range []string{priceDenom, amountDenom, feeDenom} {
+		if denom == "" {
+			denom = d
+		}
+	}
+
+	o := DemandOrder{
+		ID:               n.EibcOrderID,
+		RollappID:        n.RollappID,
+		Denom:            denom,
+		Price:            price,
+		Fee:              fee,
+		Status:           n.PacketStatus,
+		Recipient:        n.Recipient,
+		FulfillerAddress: n.FulfillerAddress,
+	}
+	if o.Status == "" {
+		o.Status = OrderStatuses.Pending
+	}
+	o.CreationHeight, _ = strconv.ParseInt(n.BlockHeight, 10, 64)
+	o.ProofHeight, _ = strconv.ParseInt(n.ProofHeight, 10, 64)
+	o.CreatedAt = parseTimestamp(n.BlockTimestamp)
+
+	return o
+}
+
+// parseTimestamp parses the RFC3339 or unix milliseconds timestamps of the indexer
+func parseTimestamp(s string) time.Time {
+	if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return t
+	}
+	if ms, err := strconv.ParseInt(s, 10, 64); err == nil && ms > 0 {
+		return time.UnixMilli(ms)
+	}
+	return time.Time{}
+}
+
+type blockResponse struct {
+	Result struct {
+		Block struct {
+			Header struct {
+				Time time.Time `json:"time"`
+			} `json:"header"`
+		} `json:"block"`
+	} `json:"result"`
+}
+
+// blockTimes caches the times of the hub blocks
+type blockTimes struct {
+	rpc   string
+	c     http.Client
+	times map[int64]time.Time
+}
+
+func newBlockTimes(rpc string) *blockTimes {
+	return &blockTimes{
+		rpc:   strings.TrimSuffix(rpc, "/"),
+		c:     http.Client{Timeout: 10 * time.Second},
+		times: map[int64]time.Time{},
+	}
+}
+
+func (bt *blockTimes) get(height int64) (time.Time, error) {
+	if t, ok := bt.times[height]; ok {
+		return t, nil
+	}
+
+	resp, err := bt.c.Get(fmt.Sprintf("%s/block?height=%d", bt.rpc, height))
+	if err != nil {
+		return time.Time{}, err
+	}
+	// nolint: errcheck
+	defer resp.Body.Close()
+
+	var br blockResponse
+	err = json.NewDecoder(resp.Body).Decode(&br)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	t := br.Result.Block.Header.Time
+	bt.times[height] = t
+	return t, nil
+}
+
+// retain drops the cached times of the heights that aren't kept
+func (bt *blockTimes) retain(keep func(height int64) bool) {
+	for h := range bt.times {
+		if !keep(h) {
+			delete(bt.times, h)
+		}
+	}
+}
+
+// FillCreationTimes sets the creation time of the orders from the time of their creation
+// block, the orders whose block can't be queried (e.g. pruned) are left without time
+func FillCreationTimes(rpc string, orders []DemandOrder) {
+	bt := newBlockTimes(rpc)
+
+	for i := range orders {
+		h := orders[i].CreationHeight
+		if h == 0 || !orders[i].CreatedAt.IsZero() {
+			continue
+		}
+
+		t, err := bt.get(h)
+		if err != nil {
+			continue
+		}
+		orders[i].CreatedAt = t
+	}
+}
+
+// FulfillOrder fulfills the order with the eibc account and waits for the transaction
+func FulfillOrder(home, orderID, fee string, hd consts.HubData) error {
+	cmd, err := GetFulfillOrderCmd(orderID, fee, hd)
+	if err != nil {
+		return err
+	}
+
+	txOutput, err := bash.ExecCommandWithInput(home, cmd, "signatures")
+	if err != nil {
+		return err
+	}
+
+	txHash, err := bash.ExtractTxHash(txOutput)
+	if err != nil {
+		return err
+	}
+
+	return tx.MonitorTransaction(hd.RpcUrl, txHash)
+}
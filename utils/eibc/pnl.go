@@ -0,0 +1,415 @@
+package eibc
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	cosmossdkmath "cosmossdk.io/math"
+	cosmossdktypes "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+)
+
+// PnLGroupings are the dimensions the profit and loss report can be grouped by
+var PnLGroupings = struct {
+	Day     string
+	Rollapp string
+	Asset   string
+}{
+	Day:     "day",
+	Rollapp: "rollapp",
+	Asset:   "asset",
+}
+
+const txSearchPageSize = 100
+
+// FulfilledOrder is a demand order fulfilled with the funds of the operator or the policy,
+// reconstructed from the hub transactions
+type FulfilledOrder struct {
+	DemandOrder
+	TxHash string
+	Height int64
+	Time   time.Time
+	// OperatorFee is the part of the order fee earned by the operator, it's estimated from
+	// the fee share when the hub doesn't emit it
+	OperatorFee cosmossdkmath.Int
+}
+
+// TxCost is the gas paid by the operator, the policy or a fulfiller for a hub transaction
+type TxCost struct {
+	TxHash string
+	Height int64
+	Time   time.Time
+	Fee    cosmossdkmath.Int
+	// Orders are the ids of the orders fulfilled by the transaction
+	Orders []string
+}
+
+// PnLHistory are the fulfilments and transaction costs of the operator addresses
+type PnLHistory struct {
+	Orders []FulfilledOrder
+	Txs    []TxCost
+}
+
+// PnLRow is a line of the profit and loss report, the amounts are in Denom except the gas
+// which is in the hub denom
+type PnLRow struct {
+	Key            string
+	Denom          string
+	Orders         int
+	Volume         cosmossdkmath.Int
+	Fees           cosmossdkmath.Int
+	OperatorIncome cosmossdkmath.Int
+	GasSpent       cosmossdkmath.Int
+}
+
+type txSearchResponse struct {
+	Result struct {
+		Txs []struct {
+			Hash     string `json:"hash"`
+			Height   string `json:"height"`
+			TxResult struct {
+				Code   int `json:"code"`
+				Events []struct {
+					Type       string `json:"type"`
+					Attributes []struct {
+						Key   string `json:"key"`
+						Value string `json:"value"`
+					} `json:"attributes"`
+				} `json:"events"`
+			} `json:"tx_result"`
+		} `json:"txs"`
+		TotalCount string `json:"total_count"`
+	} `json:"result"`
+}
+
+// QueryPnLHistory reconstructs the orders fulfilled with the funds of the addresses and
+// the gas they paid since the given time, from the transactions indexed by the hub node
+func QueryPnLHistory(
+	hd consts.HubData,
+	addresses []string,
+	since time.Time,
+	feeShare float64,
+) (*PnLHistory, error) {
+	bt := newBlockTimes(hd.RpcUrl)
+	c := http.Client{Timeout: 30 * time.Second}
+	history := &PnLHistory{}
+	seen := map[string]bool{}
+
+	for _, addr := range addresses {
+		for _, query := range []string{
+			fmt.Sprintf("message.sender='%s'", addr),
+			fmt.Sprintf("coin_spent.spender='%s'", addr),
+		} {
+			err := searchTxs(c, bt, hd.RpcUrl, query, since, func(res txSearchResult) {
+				if seen[res.hash] {
+					return
+				}
+				seen[res.hash] = true
+				history.add(res, addresses, feeShare)
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	slices.SortFunc(history.Orders, func(a, b FulfilledOrder) int {
+		return a.Time.Compare(b.Time)
+	})
+	slices.SortFunc(history.Txs, func(a, b TxCost) int {
+		return a.Time.Compare(b.Time)
+	})
+
+	return history, nil
+}
+
+type txSearchResult struct {
+	hash   string
+	height int64
+	time   time.Time
+	failed bool
+	events []txEvent
+}
+
+type txEvent struct {
+	kind  string
+	attrs map[string]string
+}
+
+// searchTxs calls fn with the transactions matching the query from the newest to the
+// oldest one, it stops at the first transaction older than since
+func searchTxs(
+	c http.Client,
+	bt *blockTimes,
+	rpc, query string,
+	since time.Time,
+	fn func(res txSearchResult),
+) error {
+	for page := 1; ; page++ {
+		endpoint := fmt.Sprintf(
+			"%s/tx_search?query=%s&page=%d&per_page=%d&order_by=%s",
+			strings.TrimSuffix(rpc, "/"),
+			url.QueryEscape(fmt.Sprintf("%q", query)),
+			page,
+			txSearchPageSize,
+			url.QueryEscape(`"desc"`),
+		)
+
+		resp, err := c.Get(endpoint)
+		if err != nil {
+			return fmt.Errorf("failed to search transactions: %w", err)
+		}
+		var sr txSearchResponse
+		err = json.NewDecoder(resp.Body).Decode(&sr)
+		// nolint: errcheck
+		resp.Body.Close()
+		if err != nil {
+			return fmt.Errorf("failed to decode transactions: %w", err)
+		}
+
+		for _, t := range sr.Result.Txs {
+			res := txSearchResult{hash: t.Hash, failed: t.TxResult.Code != 0}
+			res.height, _ = strconv.ParseInt(t.Height, 10, 64)
+			res.time, err = bt.get(res.height)
+			if err != nil {
+				return fmt.Errorf("failed to query block %d: %w", res.height, err)
+			}
+			if res.time.Before(since) {
+				return nil
+			}
+
+			for _, e := range t.TxResult.Events {
+				ev := txEvent{kind: e.Type, attrs: map[string]string{}}
+				for _, a := range e.Attributes {
+					ev.attrs[a.Key] = unquote(a.Value)
+				}
+				res.events = append(res.events, ev)
+			}
+			fn(res)
+		}
+
+		total, _ := strconv.Atoi(sr.Result.TotalCount)
+		if len(sr.Result.Txs) < txSearchPageSize || page*txSearchPageSize >= total {
+			return nil
+		}
+	}
+}
+
+// unquote returns the value of the json encoded attributes of the typed events
+func unquote(v string) string {
+	var s string
+	if json.Unmarshal([]byte(v), &s) == nil {
+		return s
+	}
+	return v
+}
+
+// add records the fulfilments and the gas of the transaction, the addresses are the
+// operator, the policy and the fulfillers that pay the gas of the eibc client
+func (h *PnLHistory) add(res txSearchResult, addresses []string, feeShare float64) {
+	cost := TxCost{
+		TxHash: res.hash,
+		Height: res.height,
+		Time:   res.time,
+		Fee:    cosmossdkmath.ZeroInt(),
+	}
+
+	for _, e := range res.events {
+		switch {
+		case e.kind == "tx" && slices.Contains(addresses, e.attrs["fee_payer"]):
+			fee, _ := cosmossdktypes.ParseCoinsNormalized(e.attrs["fee"])
+			cost.Fee = cost.Fee.Add(fee.AmountOf(consts.Denoms.Hub))
+		case !res.failed && strings.Contains(e.kind, "EventDemandOrderFulfilled"):
+			o, ok := fulfilledOrderFromEvent(e, addresses, feeShare)
+			if !ok {
+				continue
+			}
+			o.TxHash, o.Height, o.Time = res.hash, res.height, res.time
+			h.Orders = append(h.Orders, o)
+			cost.Orders = append(cost.Orders, o.ID)
+		}
+	}
+
+	if cost.Fee.IsPositive() {
+		h.Txs = append(h.Txs, cost)
+	}
+}
+
+// fulfilledOrderFromEvent parses the fulfilment event of the hub, the orders fulfilled by
+// other accounts in the same transaction are ignored
+func fulfilledOrderFromEvent(
+	e txEvent,
+	addresses []string,
+	feeShare float64,
+) (FulfilledOrder, bool) {
+	var fulfillers []string
+	for _, k := range []string{"fulfiller", "fulfiller_address", "lp_address", "operator_address"} {
+		if v := e.attrs[k]; v != "" {
+			fulfillers = append(fulfillers, v)
+		}
+	}
+	if len(fulfillers) > 0 && !slices.ContainsFunc(fulfillers, func(a string) bool {
+		return slices.Contains(addresses, a)
+	}) {
+		return FulfilledOrder{}, false
+	}
+
+	price, priceDenom := parseEventAmount(e.attrs["price"])
+	fee, feeDenom := parseEventAmount(e.attrs["fee"])
+	denom := e.attrs["denom"]
+	if denom == "" {
+		denom = priceDenom
+	}
+	if denom == "" {
+		denom = feeDenom
+	}
+
+	o := FulfilledOrder{
+		DemandOrder: DemandOrder{
+			ID:               e.attrs["order_id"],
+			RollappID:        e.attrs["rollapp_id"],
+			Denom:            denom,
+			Price:            price,
+			Fee:              fee,
+			Status:           e.attrs["packet_status"],
+			FulfillerAddress: strings.Join(fulfillers, ","),
+		},
+	}
+	o.CreationHeight, _ = strconv.ParseInt(e.attrs["creation_height"], 10, 64)
+
+	operatorFee, _ := parseEventAmount(e.attrs["operator_fee"])
+	if operatorFee.IsPositive() {
+		o.OperatorFee = operatorFee
+	} else {
+		o.OperatorFee = o.ExpectedProfit(feeShare)
+	}
+
+	return o, o.ID != ""
+}
+
+// parseEventAmount parses the amounts of the events, either coins or a bare amount
+func parseEventAmount(v string) (cosmossdkmath.Int, string) {
+	var coins cosmossdktypes.Coins
+	if json.Unmarshal([]byte(v), &coins) == nil && len(coins) > 0 {
+		return coins[0].Amount, coins[0].Denom
+	}
+
+	parsed, err := cosmossdktypes.ParseCoinsNormalized(v)
+	if err == nil && len(parsed) > 0 {
+		return parsed[0].Amount, parsed[0].Denom
+	}
+
+	return parseAmount(v)
+}
+
+// Aggregate groups the history by day, rollapp or asset and by denom. The gas of a
+// transaction is split between the orders it fulfilled, the gas of the other
+// transactions is reported on rows without orders
+func (h *PnLHistory) Aggregate(groupBy string) []PnLRow {
+	rows := map[string]*PnLRow{}
+	row := func(key, denom string) *PnLRow {
+		r, ok := rows[key+"/"+denom]
+		if !ok {
+			r = &PnLRow{
+				Key:            key,
+				Denom:          denom,
+				Volume:         cosmossdkmath.ZeroInt(),
+				Fees:           cosmossdkmath.ZeroInt(),
+				OperatorIncome: cosmossdkmath.ZeroInt(),
+				GasSpent:       cosmossdkmath.ZeroInt(),
+			}
+			rows[key+"/"+denom] = r
+		}
+		return r
+	}
+	keyOf := func(t time.Time, o *FulfilledOrder) string {
+		switch groupBy {
+		case PnLGroupings.Rollapp:
+			if o == nil {
+				return "-"
+			}
+			return o.RollappID
+		case PnLGroupings.Asset:
+			if o == nil {
+				return "-"
+			}
+			return o.Denom
+		default:
+			return t.UTC().Format(time.DateOnly)
+		}
+	}
+
+	byID := map[string]*FulfilledOrder{}
+	for i := range h.Orders {
+		o := &h.Orders[i]
+		byID[o.TxHash+"/"+o.ID] = o
+
+		r := row(keyOf(o.Time, o), o.Denom)
+		r.Orders++
+		r.Volume = r.Volume.Add(o.Price)
+		r.Fees = r.Fees.Add(o.Fee)
+		r.OperatorIncome = r.OperatorIncome.Add(o.OperatorFee)
+	}
+
+	for _, t := range h.Txs {
+		var orders []*FulfilledOrder
+		for _, id := range t.Orders {
+			if o, ok := byID[t.TxHash+"/"+id]; ok {
+				orders = append(orders, o)
+			}
+		}
+		if len(orders) == 0 {
+			r := row(keyOf(t.Time, nil), "-")
+			r.GasSpent = r.GasSpent.Add(t.Fee)
+			continue
+		}
+
+		part := t.Fee.QuoRaw(int64(len(orders)))
+		rest := t.Fee.Sub(part.MulRaw(int64(len(orders))))
+		for i, o := range orders {
+			r := row(keyOf(o.Time, o), o.Denom)
+			r.GasSpent = r.GasSpent.Add(part)
+			if i == 0 {
+				r.GasSpent = r.GasSpent.Add(rest)
+			}
+		}
+	}
+
+	result := make([]PnLRow, 0, len(rows))
+	for _, r := range rows {
+		result = append(result, *r)
+	}
+	slices.SortFunc(result, func(a, b PnLRow) int {
+		if c := strings.Compare(a.Key, b.Key); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Denom, b.Denom)
+	})
+
+	return result
+}
+
+// LockedOrders returns the pending orders fulfilled by the addresses, their price is
+// locked until the rollapp state is finalized
+func LockedOrders(hd consts.HubData, addresses []string) ([]DemandOrder, error) {
+	orders, err := QueryHubDemandOrders(hd, OrderStatuses.Pending)
+	if err != nil {
+		return nil, err
+	}
+
+	var locked []DemandOrder
+	for _, o := range orders {
+		if slices.Contains(addresses, o.FulfillerAddress) {
+			locked = append(locked, o)
+		}
+	}
+
+	return locked, nil
+}
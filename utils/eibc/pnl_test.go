@@ -0,0 +1,154 @@
+package eibc
+
+import (
+	"testing"
+	"time"
+
+	cosmossdkmath "cosmossdk.io/math"
+)
+
+func TestPnLHistoryAddFulfillerGas(t *testing.T) {
+	addresses := []string{"operator", "policy", "fulfiller-1"}
+
+	tests := []struct {
+		name       string
+		feePayer   string
+		wantTxs    int
+		wantOrders int
+	}{
+		{name: "paid by the operator", feePayer: "operator", wantTxs: 1, wantOrders: 1},
+		{name: "paid by a fulfiller", feePayer: "fulfiller-1", wantTxs: 1, wantOrders: 1},
+		{name: "paid by another account", feePayer: "other", wantTxs: 0, wantOrders: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var h PnLHistory
+			h.add(txSearchResult{
+				hash:   "tx",
+				height: 10,
+				time:   time.Unix(1700000000, 0),
+				events: []txEvent{
+					{
+						kind:  "tx",
+						attrs: map[string]string{"fee_payer": tt.feePayer, "fee": "1000adym"},
+					},
+					{
+						kind: "dymensionxyz.dymension.eibc.EventDemandOrderFulfilled",
+						attrs: map[string]string{
+							"order_id":   "order",
+							"rollapp_id": "rollapp_1-1",
+							"price":      "990adym",
+							"fee":        "10adym",
+							"fulfiller":  "policy",
+						},
+					},
+				},
+			}, addresses, 0.5)
+
+			if len(h.Txs) != tt.wantTxs {
+				t.Fatalf("expected %d txs, got %d", tt.wantTxs, len(h.Txs))
+			}
+			if len(h.Orders) != tt.wantOrders {
+				t.Fatalf("expected %d orders, got %d", tt.wantOrders, len(h.Orders))
+			}
+			if tt.wantTxs > 0 && !h.Txs[0].Fee.Equal(cosmossdkmath.NewInt(1000)) {
+				t.Fatalf("expected fee 1000, got %s", h.Txs[0].Fee)
+			}
+		})
+	}
+}
+
+func TestPnLHistoryAggregate(t *testing.T) {
+	day1 := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	day2 := day1.AddDate(0, 0, 1)
+	order := func(txHash, id, raID, denom string, at time.Time, price, fee int64) FulfilledOrder {
+		return FulfilledOrder{
+			DemandOrder: DemandOrder{
+				ID:        id,
+				RollappID: raID,
+				Denom:     denom,
+				Price:     cosmossdkmath.NewInt(price),
+				Fee:       cosmossdkmath.NewInt(fee),
+			},
+			TxHash:      txHash,
+			Time:        at,
+			OperatorFee: cosmossdkmath.NewInt(fee / 2),
+		}
+	}
+	h := PnLHistory{
+		Orders: []FulfilledOrder{
+			order("tx1", "a", "rollapp_1-1", "adym", day1, 100, 10),
+			order("tx1", "b", "rollapp_2-1", "adym", day1, 200, 20),
+			order("tx2", "c", "rollapp_1-1", "uatom", day2, 300, 30),
+		},
+		Txs: []TxCost{
+			{TxHash: "tx1", Time: day1, Fee: cosmossdkmath.NewInt(7), Orders: []string{"a", "b"}},
+			{TxHash: "tx2", Time: day2, Fee: cosmossdkmath.NewInt(5), Orders: []string{"c"}},
+			{TxHash: "tx3", Time: day2, Fee: cosmossdkmath.NewInt(3)},
+		},
+	}
+
+	type want struct {
+		key, denom string
+		orders     int
+		volume     int64
+		fees       int64
+		income     int64
+		gas        int64
+	}
+	tests := []struct {
+		groupBy string
+		want    []want
+	}{
+		{
+			groupBy: PnLGroupings.Day,
+			want: []want{
+				{"2024-01-01", "adym", 2, 300, 30, 15, 7},
+				{"2024-01-02", "-", 0, 0, 0, 0, 3},
+				{"2024-01-02", "uatom", 1, 300, 30, 15, 5},
+			},
+		},
+		{
+			groupBy: PnLGroupings.Rollapp,
+			want: []want{
+				{"-", "-", 0, 0, 0, 0, 3},
+				{"rollapp_1-1", "adym", 1, 100, 10, 5, 4},
+				{"rollapp_1-1", "uatom", 1, 300, 30, 15, 5},
+				{"rollapp_2-1", "adym", 1, 200, 20, 10, 3},
+			},
+		},
+		{
+			groupBy: PnLGroupings.Asset,
+			want: []want{
+				{"-", "-", 0, 0, 0, 0, 3},
+				{"adym", "adym", 2, 300, 30, 15, 7},
+				{"uatom", "uatom", 1, 300, 30, 15, 5},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.groupBy, func(t *testing.T) {
+			rows := h.Aggregate(tt.groupBy)
+			if len(rows) != len(tt.want) {
+				t.Fatalf("expected %d rows, got %d: %+v", len(tt.want), len(rows), rows)
+			}
+			for i, w := range tt.want {
+				r := rows[i]
+				got := want{
+					r.Key,
+					r.Denom,
+					r.Orders,
+					r.Volume.Int64(),
+					r.Fees.Int64(),
+					r.OperatorIncome.Int64(),
+					r.GasSpent.Int64(),
+				}
+				if got != w {
+					t.Fatalf("expected %+v, got %+v", w, got)
+				}
+			}
+		})
+	}
+}
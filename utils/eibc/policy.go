@@ -0,0 +1,261 @@
+package eibc
+
+import (
+	"fmt"
+	"slices"
+
+	cosmossdkmath "cosmossdk.io/math"
+	cosmossdktypes "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/utils/config/yamlconfig"
+)
+
+// PolicyTargets are the kinds of entries of the fulfill policy
+var PolicyTargets = struct {
+	Rollapp string
+	Asset   string
+}{
+	Rollapp: "rollapp",
+	Asset:   "asset",
+}
+
+// FulfillPolicy are the criteria used to pick the orders to fulfill, the chain entries are
+// keyed by rollapp id and the asset entries by denom. An order has to satisfy both its
+// rollapp and its asset entries. The eibc client only reads the min fee percentage, the
+// max order size is enforced by roller when it fulfills orders and applied by simulate
+// and scale
+type FulfillPolicy struct {
+	MinFeePercentage minFeePercentage `yaml:"min_fee_percentage" json:"min_fee_percentage"`
+	MaxOrderSize     maxOrderSize     `yaml:"max_order_size"     json:"max_order_size"`
+}
+
+// PolicyEntry is the fulfill policy of a single rollapp or asset
+type PolicyEntry struct {
+	Target string
+	Key    string
+	// MinFeePercentage is nil when the entry has no minimal fee
+	MinFeePercentage *float32
+	// MaxOrderSize is empty when the entry has no maximal order size
+	MaxOrderSize string
+}
+
+// IsEmpty returns true when the policy has no entry
+func (p FulfillPolicy) IsEmpty() bool {
+	return len(p.MinFeePercentage.Chain) == 0 && len(p.MinFeePercentage.Asset) == 0 &&
+		len(p.MaxOrderSize.Chain) == 0 && len(p.MaxOrderSize.Asset) == 0
+}
+
+func (p *FulfillPolicy) maps(target string) (map[string]float32, map[string]string) {
+	if p.MinFeePercentage.Chain == nil {
+		p.MinFeePercentage.Chain = map[string]float32{}
+	}
+	if p.MinFeePercentage.Asset == nil {
+		p.MinFeePercentage.Asset = map[string]float32{}
+	}
+	if p.MaxOrderSize.Chain == nil {
+		p.MaxOrderSize.Chain = map[string]string{}
+	}
+	if p.MaxOrderSize.Asset == nil {
+		p.MaxOrderSize.Asset = map[string]string{}
+	}
+
+	if target == PolicyTargets.Asset {
+		return p.MinFeePercentage.Asset, p.MaxOrderSize.Asset
+	}
+	return p.MinFeePercentage.Chain, p.MaxOrderSize.Chain
+}
+
+// Set updates the entry of the rollapp or asset, the nil values are left unchanged
+func (p *FulfillPolicy) Set(target, key string, minFee *float32, maxSize *cosmossdkmath.Int) {
+	fees, sizes := p.maps(target)
+	if minFee != nil {
+		fees[key] = *minFee
+	}
+	if maxSize != nil {
+		sizes[key] = maxSize.String()
+	}
+}
+
+// Remove deletes the entry of the rollapp or asset and returns false when there was none
+func (p *FulfillPolicy) Remove(target, key string) bool {
+	fees, sizes := p.maps(target)
+	_, hasFee := fees[key]
+	_, hasSize := sizes[key]
+
+	delete(fees, key)
+	delete(sizes, key)
+
+	return hasFee || hasSize
+}
+
+// RemoveRollapp deletes the entry of a rollapp that is no longer supported
+func (p *FulfillPolicy) RemoveRollapp(raID string) {
+	delete(p.MinFeePercentage.Chain, raID)
+	delete(p.MaxOrderSize.Chain, raID)
+}
+
+// Entries returns the rollapp entries followed by the asset entries, sorted by key
+func (p FulfillPolicy) Entries() []PolicyEntry {
+	var entries []PolicyEntry
+	for _, target := range []string{PolicyTargets.Rollapp, PolicyTargets.Asset} {
+		fees, sizes := p.maps(target)
+
+		var keys []string
+		for k := range fees {
+			keys = append(keys, k)
+		}
+		for k := range sizes {
+			if _, ok := fees[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		slices.Sort(keys)
+
+		for _, k := range keys {
+			e := PolicyEntry{Target: target, Key: k, MaxOrderSize: sizes[k]}
+			if f, ok := fees[k]; ok {
+				e.MinFeePercentage = &f
+			}
+			entries = append(entries, e)
+		}
+	}
+
+	return entries
+}
+
+// MinFeePercentageFor returns the minimal fee percentage required for the order, the
+// highest of its rollapp and asset entries
+func (p FulfillPolicy) MinFeePercentageFor(o DemandOrder) float64 {
+	var minFee float64
+	if f, ok := p.MinFeePercentage.Chain[o.RollappID]; ok {
+		minFee = float64(f)
+	}
+	if f, ok := p.MinFeePercentage.Asset[o.Denom]; ok && float64(f) > minFee {
+		minFee = float64(f)
+	}
+	return minFee
+}
+
+// Allows returns an empty string when the order satisfies the policy and the reason it
+// doesn't otherwise
+func (p FulfillPolicy) Allows(o DemandOrder) string {
+	minFee := p.MinFeePercentageFor(o)
+	if o.FeePercentage() < minFee {
+		return fmt.Sprintf("fee %.3f%% is below %v%%", o.FeePercentage(), minFee)
+	}
+
+	limits := []string{p.MaxOrderSize.Chain[o.RollappID], p.MaxOrderSize.Asset[o.Denom]}
+	for _, limit := range limits {
+		if limit == "" {
+			continue
+		}
+		maxSize, ok := cosmossdkmath.NewIntFromString(limit)
+		if ok && o.Price.GT(maxSize) {
+			return fmt.Sprintf("price %s is above the maximal order size %s", o.Price, limit)
+		}
+	}
+
+	return ""
+}
+
+// CheckFulfillPolicy returns an error when the pending order doesn't satisfy the fulfill
+// policy of the eibc config, roller refuses to fulfill such orders
+func CheckFulfillPolicy(eibcConfigPath, orderID string, hd consts.HubData) error {
+	var cfg Config
+	err := cfg.LoadConfig(eibcConfigPath)
+	if err != nil {
+		return err
+	}
+	if cfg.FulfillCriteria.IsEmpty() {
+		return nil
+	}
+
+	orders, err := QueryHubDemandOrders(hd, OrderStatuses.Pending)
+	if err != nil {
+		return err
+	}
+	for _, o := range orders {
+		if o.ID != orderID {
+			continue
+		}
+		if reason := cfg.FulfillCriteria.Allows(o); reason != "" {
+			return fmt.Errorf("order %s doesn't satisfy the fulfill policy: %s", orderID, reason)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("order %s is not a pending demand order", orderID)
+}
+
+// ValidatePolicyEntry checks the values of a fulfill policy entry, the rollapp entries
+// are only accepted for the rollapps supported by the eibc client
+func ValidatePolicyEntry(
+	cfg Config,
+	target, key string,
+	minFee *float32,
+	maxSize *cosmossdkmath.Int,
+) error {
+	switch target {
+	case PolicyTargets.Rollapp:
+		if _, ok := cfg.Rollapps[key]; !ok {
+			return fmt.Errorf(
+				"%s is not a supported rollapp, add it with 'roller eibc fulfill rollapps set'",
+				key,
+			)
+		}
+	case PolicyTargets.Asset:
+		err := cosmossdktypes.ValidateDenom(key)
+		if err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("invalid policy target: %s", target)
+	}
+
+	if minFee != nil && (*minFee < 0 || *minFee > 100) {
+		return fmt.Errorf("min fee percentage must be between 0 and 100, got %v", *minFee)
+	}
+	if maxSize != nil && !maxSize.IsPositive() {
+		return fmt.Errorf("max order size must be positive, got %s", maxSize)
+	}
+
+	return nil
+}
+
+// ParseMaxOrderSize parses a max order size, the denom suffix is ignored
+func ParseMaxOrderSize(s string) (cosmossdkmath.Int, error) {
+	amount, _ := parseAmount(s)
+	if !amount.IsPositive() {
+		return cosmossdkmath.Int{}, fmt.Errorf("invalid max order size: %s", s)
+	}
+	return amount, nil
+}
+
+// WriteFulfillPolicy replaces the fulfill criteria of the eibc client config, the eibc
+// client doesn't read the max order size, it's only enforced by roller
+func WriteFulfillPolicy(eibcConfigPath string, p FulfillPolicy) error {
+	p.maps(PolicyTargets.Rollapp)
+
+	updates := map[string]interface{}{
+		"fulfill_criteria": p,
+	}
+	err := yamlconfig.UpdateNestedYAML(eibcConfigPath, updates)
+	if err != nil {
+		return fmt.Errorf("failed to update config: %v", err)
+	}
+	return nil
+}
+
+// UpdateGroupFulfillPolicy publishes the fulfill policy of the eibc config in the onchain
+// metadata of the operator group
+func UpdateGroupFulfillPolicy(eibcConfigPath string, cfg Config, home string) error {
+	return UpdateGroupOperatorMetadata(eibcConfigPath, cfg, home, func(m *EibcOperatorMetadata) {
+		if cfg.FulfillCriteria.IsEmpty() {
+			m.FulfillPolicy = nil
+			return
+		}
+		p := cfg.FulfillCriteria
+		m.FulfillPolicy = &p
+	})
+}
@@ -0,0 +1,100 @@
+package eibc
+
+import (
+	"testing"
+
+	cosmossdkmath "cosmossdk.io/math"
+)
+
+func TestFulfillPolicyAllows(t *testing.T) {
+	policy := FulfillPolicy{
+		MinFeePercentage: minFeePercentage{
+			Chain: map[string]float32{"rollapp_1-1": 1},
+			Asset: map[string]float32{"adym": 2},
+		},
+		MaxOrderSize: maxOrderSize{
+			Chain: map[string]string{"rollapp_1-1": "1000"},
+			Asset: map[string]string{"adym": "500"},
+		},
+	}
+
+	order := func(raID, denom string, price, fee int64) DemandOrder {
+		return DemandOrder{
+			RollappID: raID,
+			Denom:     denom,
+			Price:     cosmossdkmath.NewInt(price),
+			Fee:       cosmossdkmath.NewInt(fee),
+		}
+	}
+
+	tests := []struct {
+		name    string
+		policy  FulfillPolicy
+		order   DemandOrder
+		allowed bool
+	}{
+		{
+			name:    "empty policy",
+			policy:  FulfillPolicy{},
+			order:   order("rollapp_1-1", "adym", 1000000, 0),
+			allowed: true,
+		},
+		{
+			name:    "satisfies the rollapp entry",
+			policy:  policy,
+			order:   order("rollapp_1-1", "uatom", 990, 10),
+			allowed: true,
+		},
+		{
+			name:    "fee below the rollapp entry",
+			policy:  policy,
+			order:   order("rollapp_1-1", "uatom", 995, 5),
+			allowed: false,
+		},
+		{
+			name:    "price above the rollapp entry",
+			policy:  policy,
+			order:   order("rollapp_1-1", "uatom", 1980, 20),
+			allowed: false,
+		},
+		{
+			name:    "fee below the higher asset entry",
+			policy:  policy,
+			order:   order("rollapp_1-1", "adym", 490, 8),
+			allowed: false,
+		},
+		{
+			name:    "price above the lower asset entry",
+			policy:  policy,
+			order:   order("rollapp_1-1", "adym", 735, 15),
+			allowed: false,
+		},
+		{
+			name:    "satisfies both entries",
+			policy:  policy,
+			order:   order("rollapp_1-1", "adym", 490, 10),
+			allowed: true,
+		},
+		{
+			name:    "price equal to the max order size",
+			policy:  policy,
+			order:   order("rollapp_2-1", "adym", 500, 11),
+			allowed: true,
+		},
+		{
+			name:    "other rollapp and asset",
+			policy:  policy,
+			order:   order("rollapp_2-1", "uatom", 100000, 0),
+			allowed: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason := tt.policy.Allows(tt.order)
+			if (reason == "") != tt.allowed {
+				t.Fatalf("expected allowed %v, got reason %q", tt.allowed, reason)
+			}
+		})
+	}
+}
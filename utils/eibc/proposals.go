@@ -0,0 +1,342 @@
+package eibc
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	cosmossdktypes "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/utils/bash"
+	"github.com/dymensionxyz/roller/utils/keys"
+	"github.com/dymensionxyz/roller/utils/tx"
+)
+
+// GroupProposal is the proposal file format of 'tx group submit-proposal'
+type GroupProposal struct {
+	GroupPolicyAddress string   `json:"group_policy_address"`
+	Messages           []any    `json:"messages"`
+	Metadata           string   `json:"metadata"`
+	Proposers          []string `json:"proposers"`
+	Title              string   `json:"title"`
+	Summary            string   `json:"summary"`
+}
+
+// NewBankSendMsg returns the json of a bank send message, to be executed by a proposal
+func NewBankSendMsg(from, to string, amount cosmossdktypes.Coins) map[string]any {
+	return map[string]any{
+		"@type":        "/cosmos.bank.v1beta1.MsgSend",
+		"from_address": from,
+		"to_address":   to,
+		"amount":       amount,
+	}
+}
+
+// SubmitGroupProposal submits the proposal with the eibc account and waits for the
+// transaction. With execute, the proposal is executed in the same transaction when the
+// decision policy allows it, which is the case of the policy created by 'eibc init'
+func SubmitGroupProposal(
+	eibcHome string,
+	p GroupProposal,
+	execute bool,
+	hd consts.HubData,
+) (string, error) {
+	proposalsDir := filepath.Join(eibcHome, "proposals")
+	err := os.MkdirAll(proposalsDir, 0o755)
+	if err != nil {
+		return "", err
+	}
+
+	b, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	proposalPath := filepath.Join(
+		proposalsDir,
+		fmt.Sprintf("proposal-%d.json", time.Now().UnixNano()),
+	)
+	err = os.WriteFile(proposalPath, b, 0o644)
+	if err != nil {
+		return "", err
+	}
+
+	args := []string{"tx", "group", "submit-proposal", proposalPath}
+	if execute {
+		args = append(args, "--exec", "try")
+	}
+
+	return execEibcTx(eibcHome, hd, args...)
+}
+
+// execEibcTx signs the transaction with the eibc account, it returns the transaction
+// hash once the transaction is included in a block
+func execEibcTx(eibcHome string, hd consts.HubData, args ...string) (string, error) {
+	args = append(
+		args,
+		"--from", consts.KeysIds.Eibc,
+		"--keyring-backend", string(consts.SupportedKeyringBackends.Test),
+		"--home", eibcHome,
+		"--fees", fmt.Sprintf("%d%s", consts.DefaultTxFee, consts.Denoms.Hub),
+		"--node", hd.RpcUrl,
+		"--chain-id", hd.ID,
+		"-y",
+	)
+
+	out, err := bash.ExecCommandWithStdout(exec.Command(consts.Executables.Dymension, args...))
+	if err != nil {
+		return "", err
+	}
+
+	txHash, err := bash.ExtractTxHash(out.String())
+	if err != nil {
+		return "", err
+	}
+
+	return txHash, tx.MonitorTransaction(hd.RpcUrl, txHash)
+}
+
+// ProposalExecutorResults are the x/group results of a proposal execution
+var ProposalExecutorResults = struct {
+	NotRun  string
+	Success string
+	Failure string
+}{
+	NotRun:  "PROPOSAL_EXECUTOR_RESULT_NOT_RUN",
+	Success: "PROPOSAL_EXECUTOR_RESULT_SUCCESS",
+	Failure: "PROPOSAL_EXECUTOR_RESULT_FAILURE",
+}
+
+// ProposalOutcome is the state of a proposal after the transaction that submitted, voted
+// on or executed it
+type ProposalOutcome struct {
+	ProposalID     string
+	Status         string
+	ExecutorResult string
+	// Logs are the execution logs of a failed proposal
+	Logs string
+}
+
+// Executed returns true when the messages of the proposal were executed
+func (o ProposalOutcome) Executed() bool {
+	return o.ExecutorResult == ProposalExecutorResults.Success
+}
+
+// Failed returns true when the execution of the proposal messages failed
+func (o ProposalOutcome) Failed() bool {
+	return o.ExecutorResult == ProposalExecutorResults.Failure
+}
+
+type txEventsResponse struct {
+	Events []struct {
+		Type       string `json:"type"`
+		Attributes []struct {
+			Key   string `json:"key"`
+			Value string `json:"value"`
+		} `json:"attributes"`
+	} `json:"events"`
+}
+
+// QueryProposalOutcome returns the outcome of the proposal of the transaction, from the
+// execution event of the transaction or from the proposal when it wasn't executed
+func QueryProposalOutcome(eibcHome, txHash string, hd consts.HubData) (*ProposalOutcome, error) {
+	cmd := exec.Command(
+		consts.Executables.Dymension,
+		"q", "tx", txHash,
+		"-o", "json",
+		"--node", hd.RpcUrl,
+		"--chain-id", hd.ID,
+		"--home", eibcHome,
+	)
+	out, err := bash.ExecCommandWithStdout(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp txEventsResponse
+	err = json.Unmarshal(out.Bytes(), &resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse transaction %s: %w", txHash, err)
+	}
+
+	outcome := &ProposalOutcome{}
+	for _, e := range resp.Events {
+		attrs := map[string]string{}
+		for _, a := range e.Attributes {
+			attrs[a.Key] = unquote(a.Value)
+		}
+
+		switch e.Type {
+		case "cosmos.group.v1.EventSubmitProposal", "cosmos.group.v1.EventVote":
+			outcome.ProposalID = attrs["proposal_id"]
+		case "cosmos.group.v1.EventExec":
+			outcome.ProposalID = attrs["proposal_id"]
+			outcome.ExecutorResult = attrs["result"]
+			outcome.Logs = attrs["logs"]
+		}
+	}
+	if outcome.ProposalID == "" {
+		return nil, fmt.Errorf("no proposal found in transaction %s", txHash)
+	}
+
+	// the proposals are pruned once executed successfully
+	if outcome.Executed() {
+		outcome.Status = "PROPOSAL_STATUS_ACCEPTED"
+		return outcome, nil
+	}
+
+	cmd = exec.Command(
+		consts.Executables.Dymension,
+		"q", "group", "proposal", outcome.ProposalID,
+		"-o", "json",
+		"--node", hd.RpcUrl,
+		"--chain-id", hd.ID,
+		"--home", eibcHome,
+	)
+	out, err = bash.ExecCommandWithStdout(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	var proposal struct {
+		Proposal ProposalInfo `json:"proposal"`
+	}
+	err = json.Unmarshal(out.Bytes(), &proposal)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse proposal %s: %w", outcome.ProposalID, err)
+	}
+	outcome.Status = proposal.Proposal.Status
+	if outcome.ExecutorResult == "" {
+		outcome.ExecutorResult = proposal.Proposal.ExecutorResult
+	}
+
+	return outcome, nil
+}
+
+// VoteOptions maps the vote options accepted by roller to the x/group ones
+var VoteOptions = map[string]string{
+	"yes":     "VOTE_OPTION_YES",
+	"no":      "VOTE_OPTION_NO",
+	"abstain": "VOTE_OPTION_ABSTAIN",
+	"veto":    "VOTE_OPTION_NO_WITH_VETO",
+}
+
+// ProposalInfo is a proposal of the operator group policy
+type ProposalInfo struct {
+	ID                 string    `json:"id"`
+	GroupPolicyAddress string    `json:"group_policy_address"`
+	Metadata           string    `json:"metadata"`
+	Proposers          []string  `json:"proposers"`
+	SubmitTime         time.Time `json:"submit_time"`
+	Status             string    `json:"status"`
+	FinalTallyResult   struct {
+		YesCount        string `json:"yes_count"`
+		AbstainCount    string `json:"abstain_count"`
+		NoCount         string `json:"no_count"`
+		NoWithVetoCount string `json:"no_with_veto_count"`
+	} `json:"final_tally_result"`
+	VotingPeriodEnd time.Time         `json:"voting_period_end"`
+	ExecutorResult  string            `json:"executor_result"`
+	Messages        []json.RawMessage `json:"messages"`
+	Title           string            `json:"title"`
+	Summary         string            `json:"summary"`
+}
+
+// MessageTypes returns the type urls of the proposal messages
+func (p ProposalInfo) MessageTypes() []string {
+	var types []string
+	for _, m := range p.Messages {
+		var msg struct {
+			Type string `json:"@type"`
+		}
+		if json.Unmarshal(m, &msg) == nil {
+			types = append(types, msg.Type)
+		}
+	}
+	return types
+}
+
+// GetGroupProposals returns the proposals of the group policy that were not pruned, the
+// executed and rejected proposals are pruned at the end of the voting period
+func GetGroupProposals(gi GroupInfo) ([]ProposalInfo, error) {
+	cmd := exec.Command(
+		consts.Executables.Dymension,
+		"q", "group", "proposals-by-group-policy", gi.PolicyAddress,
+		"-o", "json",
+		"--node", gi.Hub.RpcUrl,
+		"--chain-id", gi.Hub.ID,
+		"--home", gi.EibcHome,
+	)
+
+	out, err := bash.ExecCommandWithStdout(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Proposals []ProposalInfo `json:"proposals"`
+	}
+	err = json.Unmarshal(out.Bytes(), &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Proposals, nil
+}
+
+// VoteGroupProposal votes on the proposal with the member key of the group info, the
+// proposal is executed after the vote when execute is set and the vote makes it pass
+func VoteGroupProposal(gi GroupInfo, proposalID, option string, execute bool) (string, error) {
+	voteOption, ok := VoteOptions[option]
+	if !ok {
+		return "", fmt.Errorf("invalid vote option: %s", option)
+	}
+
+	args := []string{"tx", "group", "vote", proposalID, gi.Member.Address, voteOption, ""}
+	if execute {
+		args = append(args, "--exec", "try")
+	}
+
+	return execMemberTx(gi, args...)
+}
+
+// ExecGroupProposal executes the accepted proposal with the member key of the group info
+func ExecGroupProposal(gi GroupInfo, proposalID string) (string, error) {
+	return execMemberTx(gi, "tx", "group", "exec", proposalID)
+}
+
+// execMemberTx signs the transaction with the member key of the group info, it returns
+// the transaction hash once the transaction is included in a block
+func execMemberTx(gi GroupInfo, args ...string) (string, error) {
+	m := gi.Member
+	args = append(
+		args,
+		"--from", m.Name,
+		"--keyring-backend", string(m.KeyringBackend),
+		"--keyring-dir", m.KeyringDir,
+		"--fees", fmt.Sprintf("%d%s", consts.DefaultTxFee, consts.Denoms.Hub),
+		"--node", gi.Hub.RpcUrl,
+		"--chain-id", gi.Hub.ID,
+		"-y",
+	)
+
+	out, err := keys.RunCmdBasedOnKeyringBackend(
+		m.home,
+		consts.Executables.Dymension,
+		args,
+		m.KeyringBackend,
+	)
+	if err != nil {
+		return "", err
+	}
+
+	txHash, err := bash.ExtractTxHash(out.String())
+	if err != nil {
+		return "", err
+	}
+
+	return txHash, tx.MonitorTransaction(gi.Hub.RpcUrl, txHash)
+}
@@ -0,0 +1,302 @@
+package eibc
+
+import (
+	"fmt"
+	"math"
+	"os/exec"
+	"regexp"
+	"slices"
+	"strconv"
+	"time"
+
+	cosmossdkmath "cosmossdk.io/math"
+	cosmossdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/pterm/pterm"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/utils/bash"
+	"github.com/dymensionxyz/roller/utils/keys"
+	"github.com/dymensionxyz/roller/utils/tx"
+)
+
+const (
+	// fulfillerTxsPerMinute is the number of fulfilment transactions a fulfiller sends per
+	// minute, a transaction is sent once the previous one is included in a block
+	fulfillerTxsPerMinute = 6
+	// scaleHeadroom is the capacity kept above the peak order rate
+	scaleHeadroom = 2
+	// LowGasRunway is the number of transactions below which a fulfiller is low on gas
+	LowGasRunway = 20
+)
+
+// FulfillerLoad is the balance and the orders in flight of a fulfiller
+type FulfillerLoad struct {
+	AccountFunds
+	// InFlight are the orders fulfilled by the fulfiller that are pending finalization
+	InFlight []DemandOrder
+	// GasRunway is the number of transactions the fulfiller can pay for, -1 when unknown
+	GasRunway int
+}
+
+// GasFee returns the fee paid by the eibc client for a transaction
+func (c Config) GasFee() (cosmossdktypes.Coin, bool) {
+	fees, err := cosmossdktypes.ParseCoinsNormalized(c.Gas.Fees)
+	if err != nil || len(fees) == 0 {
+		return cosmossdktypes.Coin{}, false
+	}
+	return fees[0], true
+}
+
+// QueryFulfillerLoad returns the fulfillers sorted by their index with their balances and
+// their orders in flight
+func QueryFulfillerLoad(cfg Config, hd consts.HubData) ([]FulfillerLoad, error) {
+	fulfillers, err := ListFulfillers(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list fulfillers: %w", err)
+	}
+	slices.SortFunc(fulfillers, func(a, b keys.KeyInfo) int {
+		return fulfillerIndex(a.Name) - fulfillerIndex(b.Name)
+	})
+
+	pending, err := QueryHubDemandOrders(hd, OrderStatuses.Pending)
+	if err != nil {
+		return nil, err
+	}
+
+	gasFee, hasGasFee := cfg.GasFee()
+
+	loads := make([]FulfillerLoad, 0, len(fulfillers))
+	for _, f := range fulfillers {
+		l := FulfillerLoad{
+			AccountFunds: AccountFunds{
+				Name:    f.Name,
+				Address: f.Address,
+				Role:    AccountRoles.Fulfiller,
+			},
+			GasRunway: -1,
+		}
+
+		l.Balances, err = QueryBalances(hd, f.Address)
+		if err != nil {
+			return nil, err
+		}
+		if hasGasFee && gasFee.IsPositive() {
+			runway := l.Balances.AmountOf(gasFee.Denom).Quo(gasFee.Amount)
+			if runway.IsInt64() && runway.Int64() < math.MaxInt32 {
+				l.GasRunway = int(runway.Int64())
+			} else {
+				l.GasRunway = math.MaxInt32
+			}
+		}
+
+		for _, o := range pending {
+			if o.FulfillerAddress == f.Address {
+				l.InFlight = append(l.InFlight, o)
+			}
+		}
+
+		loads = append(loads, l)
+	}
+
+	return loads, nil
+}
+
+// LowGas returns true when the fulfiller can pay for few transactions only
+func (l FulfillerLoad) LowGas() bool {
+	return l.GasRunway >= 0 && l.GasRunway < LowGasRunway
+}
+
+var fulfillerIndexRegex = regexp.MustCompile(`(\d+)$`)
+
+// fulfillerIndex returns the index suffix of the fulfiller key name, the eibc client
+// removes the fulfillers with the highest index when scaling down
+func fulfillerIndex(name string) int {
+	m := fulfillerIndexRegex.FindStringSubmatch(name)
+	if m == nil {
+		return math.MaxInt32
+	}
+	i, err := strconv.Atoi(m[1])
+	if err != nil {
+		return math.MaxInt32
+	}
+	return i
+}
+
+// OrderRate is the rate of the orders created on the hub over a window
+type OrderRate struct {
+	Window time.Duration
+	Orders int
+	// PerMinute is the average number of orders created per minute
+	PerMinute float64
+	// PeakPerMinute is the highest number of orders created in a minute of the window
+	PeakPerMinute int
+}
+
+// QueryOrderRate returns the rate of the orders of the supported rollapps created within
+// the window. The hub only lists the orders pending finalization, so the window should
+// not exceed the dispute period of the rollapps
+func QueryOrderRate(cfg Config, hd consts.HubData, window time.Duration) (OrderRate, error) {
+	rate := OrderRate{Window: window}
+
+	orders, err := QueryHubDemandOrders(hd, OrderStatuses.Pending)
+	if err != nil {
+		return rate, err
+	}
+	orders = supportedOrders(cfg, orders)
+	FillCreationTimes(hd.RpcUrl, orders)
+
+	now := time.Now()
+	perMinute := map[int64]int{}
+	for _, o := range orders {
+		if o.CreatedAt.IsZero() || now.Sub(o.CreatedAt) > window {
+			continue
+		}
+		rate.Orders++
+		minute := o.CreatedAt.Unix() / 60
+		perMinute[minute]++
+		if perMinute[minute] > rate.PeakPerMinute {
+			rate.PeakPerMinute = perMinute[minute]
+		}
+	}
+	if window >= time.Minute {
+		rate.PerMinute = float64(rate.Orders) / window.Minutes()
+	}
+
+	return rate, nil
+}
+
+// supportedOrders returns the orders of the rollapps supported by the eibc client
+func supportedOrders(cfg Config, orders []DemandOrder) []DemandOrder {
+	var supported []DemandOrder
+	for _, o := range orders {
+		if _, ok := cfg.Rollapps[o.RollappID]; ok {
+			supported = append(supported, o)
+		}
+	}
+	return supported
+}
+
+// RecommendFulfillers returns the number of fulfillers needed to fulfill the peak order
+// rate with headroom, every fulfiller fulfills up to maxOrdersPerTx orders per transaction
+func RecommendFulfillers(rate OrderRate, maxOrdersPerTx int) int {
+	if maxOrdersPerTx <= 0 {
+		maxOrdersPerTx = 1
+	}
+	capacity := float64(maxOrdersPerTx * fulfillerTxsPerMinute)
+	n := int(math.Ceil(float64(rate.PeakPerMinute) * scaleHeadroom / capacity))
+	return max(n, 1)
+}
+
+// QueueDepth returns the number of unfulfilled pending orders of the supported rollapps
+// that satisfy the fulfill policy
+func QueueDepth(cfg Config, hd consts.HubData) (int, error) {
+	orders, err := QueryHubDemandOrders(hd, OrderStatuses.Pending)
+	if err != nil {
+		return 0, err
+	}
+
+	var depth int
+	for _, o := range supportedOrders(cfg, orders) {
+		if !o.Fulfilled() && cfg.FulfillCriteria.Allows(o) == "" {
+			depth++
+		}
+	}
+	return depth, nil
+}
+
+// FulfillersForQueue returns the number of fulfillers needed to drain the queue within a
+// transaction of every fulfiller, bounded by minCount and maxCount
+func FulfillersForQueue(depth, maxOrdersPerTx, minCount, maxCount int) int {
+	if maxOrdersPerTx <= 0 {
+		maxOrdersPerTx = 1
+	}
+	n := (depth + maxOrdersPerTx - 1) / maxOrdersPerTx
+	return min(max(n, minCount), maxCount)
+}
+
+// ScaleFulfillers sets the number of fulfillers of the eibc client
+func ScaleFulfillers(count int) error {
+	_, err := bash.ExecCommandWithStdout(GetScaleCmd(strconv.Itoa(count)))
+	return err
+}
+
+// DrainFulfillers waits until the orders fulfilled by the addresses are no longer pending,
+// it returns the orders still in flight after the timeout
+func DrainFulfillers(
+	hd consts.HubData,
+	addresses []string,
+	timeout time.Duration,
+) ([]DemandOrder, error) {
+	deadline := time.Now().Add(timeout)
+	spinner, _ := pterm.DefaultSpinner.Start("draining fulfillers")
+
+	for {
+		inFlight, err := LockedOrders(hd, addresses)
+		if err != nil {
+			spinner.Fail("failed to query the orders in flight")
+			return nil, err
+		}
+		if len(inFlight) == 0 {
+			spinner.Success("fulfillers drained")
+			return nil, nil
+		}
+		if time.Now().After(deadline) {
+			spinner.Warning(fmt.Sprintf("%d orders still in flight", len(inFlight)))
+			return inFlight, nil
+		}
+
+		spinner.UpdateText(fmt.Sprintf("waiting for %d orders in flight", len(inFlight)))
+		time.Sleep(10 * time.Second)
+	}
+}
+
+// SweepFulfiller sends the balance of the fulfiller left after the transaction fee to the
+// address, it returns an empty hash when the balance doesn't cover the fee. The balance is
+// queried again as the orders drained since the load was queried changed it
+func SweepFulfiller(cfg Config, hd consts.HubData, f FulfillerLoad, to string) (string, error) {
+	fee, ok := cfg.GasFee()
+	if !ok {
+		fee = cosmossdktypes.NewCoin(
+			consts.Denoms.Hub,
+			cosmossdkmath.NewInt(consts.DefaultTxFee),
+		)
+	}
+
+	balances, err := QueryBalances(hd, f.Address)
+	if err != nil {
+		return "", err
+	}
+
+	// the fulfiller can't pay for the transaction
+	if balances.AmountOf(fee.Denom).LTE(fee.Amount) {
+		return "", nil
+	}
+	amount := balances.Sub(fee)
+
+	keyringDir, backend, err := fulfillersKeyring(cfg)
+	if err != nil {
+		return "", err
+	}
+
+	cmd := exec.Command(
+		consts.Executables.Dymension,
+		"tx", "bank", "send", f.Address, to, amount.String(),
+		"--keyring-backend", backend,
+		"--keyring-dir", keyringDir,
+		"--fees", fee.String(),
+		"--node", hd.RpcUrl,
+		"--chain-id", hd.ID,
+		"-y",
+	)
+	out, err := bash.ExecCommandWithStdout(cmd)
+	if err != nil {
+		return "", err
+	}
+
+	txHash, err := bash.ExtractTxHash(out.String())
+	if err != nil {
+		return "", err
+	}
+
+	return txHash, tx.MonitorTransaction(hd.RpcUrl, txHash)
+}
@@ -0,0 +1,410 @@
+package eibc
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	cosmossdkmath "cosmossdk.io/math"
+	cosmossdktypes "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ValidationLevels are the levels at which the eibc client validates the rollapp
+// transfer of an order before fulfilling it, p2p from the rollapp full nodes and
+// settlement from the state updates of the rollapp on the hub
+var ValidationLevels = struct {
+	P2P        string
+	Settlement string
+}{
+	P2P:        "p2p",
+	Settlement: "settlement",
+}
+
+// SimOrder is a demand order of a simulation fixture
+type SimOrder struct {
+	DemandOrder
+	// ValidatedAfter is the delay after the creation of the order at which it's validated
+	// at each level, an order without validation delays is validated when it's created
+	ValidatedAfter map[string]string `json:"validated_after,omitempty"`
+}
+
+// SimConfig is the fulfilment policy of the simulation
+type SimConfig struct {
+	Policy FulfillPolicy
+	// Rollapps are the supported rollapps, all the rollapps are supported when it's empty
+	Rollapps       map[string]bool
+	MaxOrdersPerTx int
+	Fulfillers     int
+	// TxInterval is the time a fulfiller takes to send a transaction and have it included
+	TxInterval    time.Duration
+	FeeShare      float64
+	FallbackLevel string
+	WaitTime      time.Duration
+	// Finalization is the delay after which the capital of a fulfilled order is returned,
+	// the capital stays locked until the end of the simulation when it's zero
+	Finalization time.Duration
+	// Funds are the funds available to fulfill orders, they are unlimited when nil
+	Funds cosmossdktypes.Coins
+}
+
+// SimConfigFromEibcConfig returns the simulation config of the eibc client config
+func SimConfigFromEibcConfig(cfg Config) (SimConfig, error) {
+	sc := SimConfig{
+		Policy:         cfg.FulfillCriteria,
+		Rollapps:       map[string]bool{},
+		MaxOrdersPerTx: cfg.Fulfillers.MaxOrdersPerTx,
+		Fulfillers:     cfg.Fulfillers.Scale,
+		TxInterval:     time.Minute / fulfillerTxsPerMinute,
+		FeeShare:       float64(cfg.OperatorConfig.MinFeeShare),
+		FallbackLevel:  cfg.Validation.FallbackLevel,
+	}
+	for raID := range cfg.Rollapps {
+		sc.Rollapps[raID] = true
+	}
+
+	if cfg.Validation.WaitTime != "" {
+		waitTime, err := time.ParseDuration(cfg.Validation.WaitTime)
+		if err != nil {
+			return sc, fmt.Errorf("invalid validation wait time: %w", err)
+		}
+		sc.WaitTime = waitTime
+	}
+
+	return sc, nil
+}
+
+// LoadSimOrders reads the orders of a fixture, either a list of orders, an object with an
+// "orders" list or the output of 'dymd q eibc list-demand-orders -o json'
+func LoadSimOrders(path string) ([]SimOrder, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var orders []SimOrder
+	if json.Unmarshal(b, &orders) == nil {
+		return normalizeSimOrders(orders), nil
+	}
+
+	var fixture struct {
+		Orders []SimOrder `json:"orders"`
+		hubDemandOrdersResponse
+	}
+	err = json.Unmarshal(b, &fixture)
+	if err != nil {
+		return nil, fmt.Errorf("invalid order fixture: %w", err)
+	}
+	if len(fixture.Orders) > 0 {
+		return normalizeSimOrders(fixture.Orders), nil
+	}
+
+	for _, do := range fixture.DemandOrders {
+		o := SimOrder{DemandOrder: DemandOrder{
+			ID:               do.ID,
+			RollappID:        do.RollappID,
+			Price:            cosmossdkmath.ZeroInt(),
+			Fee:              cosmossdkmath.ZeroInt(),
+			Status:           do.TrackingPacketStatus,
+			Recipient:        do.Recipient,
+			FulfillerAddress: do.FulfillerAddress,
+		}}
+		if len(do.Price) > 0 {
+			o.Denom = do.Price[0].Denom
+			o.Price = do.Price[0].Amount
+		}
+		if len(do.Fee) > 0 {
+			o.Fee = do.Fee[0].Amount
+		}
+		o.CreationHeight, _ = do.CreationHeight.Int64()
+		orders = append(orders, o)
+	}
+
+	return orders, nil
+}
+
+// normalizeSimOrders sets the missing amounts of the orders to zero
+func normalizeSimOrders(orders []SimOrder) []SimOrder {
+	for i := range orders {
+		if orders[i].Price.IsNil() {
+			orders[i].Price = cosmossdkmath.ZeroInt()
+		}
+		if orders[i].Fee.IsNil() {
+			orders[i].Fee = cosmossdkmath.ZeroInt()
+		}
+	}
+	return orders
+}
+
+// SimResult is the outcome of an order of the simulation
+type SimResult struct {
+	Order DemandOrder
+	Taken bool
+	// Reason is the reason the order isn't taken
+	Reason      string
+	FulfilledAt time.Time
+	// Tx is the index of the fulfilment transaction of the order
+	Tx int
+}
+
+// SimDenomSummary sums up the simulation of the orders of a denom
+type SimDenomSummary struct {
+	Denom   string
+	Taken   int
+	Skipped int
+	Volume  cosmossdkmath.Int
+	Fees    cosmossdkmath.Int
+	Profit  cosmossdkmath.Int
+	// Capital is the peak of the funds locked in fulfilled orders
+	Capital cosmossdkmath.Int
+}
+
+// SimReport is the outcome of the simulation
+type SimReport struct {
+	Results []SimResult
+	Txs     int
+	Denoms  []SimDenomSummary
+}
+
+type simLock struct {
+	denom    string
+	amount   cosmossdkmath.Int
+	at       time.Time
+	released time.Time
+}
+
+func (l simLock) activeAt(t time.Time) bool {
+	return !l.at.After(t) && (l.released.IsZero() || l.released.After(t))
+}
+
+// Simulate replays the orders against the fulfilment policy. The orders are fulfilled in
+// the order they are validated, by the first free fulfiller, in transactions of up to
+// MaxOrdersPerTx orders. The orders that find insufficient funds wait for the capital of
+// the fulfilled orders to be returned after Finalization
+func Simulate(orders []SimOrder, sc SimConfig) SimReport {
+	var report SimReport
+	results := make([]SimResult, len(orders))
+
+	var start time.Time
+	for _, o := range orders {
+		if !o.CreatedAt.IsZero() && (start.IsZero() || o.CreatedAt.Before(start)) {
+			start = o.CreatedAt
+		}
+	}
+
+	type candidate struct {
+		idx     int
+		readyAt time.Time
+	}
+	var queue []candidate
+	for i, o := range orders {
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = start
+		}
+		results[i] = SimResult{Order: o.DemandOrder}
+
+		readyAt, reason := sc.admit(o)
+		if reason != "" {
+			results[i].Reason = reason
+			continue
+		}
+		queue = append(queue, candidate{idx: i, readyAt: readyAt})
+	}
+	slices.SortStableFunc(queue, func(a, b candidate) int {
+		return a.readyAt.Compare(b.readyAt)
+	})
+
+	maxOrdersPerTx := max(sc.MaxOrdersPerTx, 1)
+	nextFree := make([]time.Time, max(sc.Fulfillers, 1))
+	var locks []simLock
+
+	for len(queue) > 0 {
+		f := 0
+		for i := range nextFree {
+			if nextFree[i].Before(nextFree[f]) {
+				f = i
+			}
+		}
+		t := nextFree[f]
+		if queue[0].readyAt.After(t) {
+			t = queue[0].readyAt
+		}
+
+		var batch int
+		var rest []candidate
+		for _, c := range queue {
+			if batch >= maxOrdersPerTx || c.readyAt.After(t) {
+				rest = append(rest, c)
+				continue
+			}
+
+			o := orders[c.idx].DemandOrder
+			if sc.Funds != nil {
+				locked := cosmossdkmath.ZeroInt()
+				for _, l := range locks {
+					if l.denom == o.Denom && l.activeAt(t) {
+						locked = locked.Add(l.amount)
+					}
+				}
+				if sc.Funds.AmountOf(o.Denom).Sub(locked).LT(o.Price) {
+					// the order is retried once the capital of a fulfilled order is
+					// returned, it's dropped when no capital is returned anymore
+					results[c.idx].Reason = "insufficient funds"
+					if release, ok := nextRelease(locks, o.Denom, t); ok {
+						rest = append(rest, candidate{idx: c.idx, readyAt: release})
+					}
+					continue
+				}
+			}
+
+			lock := simLock{denom: o.Denom, amount: o.Price, at: t}
+			if sc.Finalization > 0 {
+				lock.released = t.Add(sc.Finalization)
+			}
+			locks = append(locks, lock)
+
+			results[c.idx].Taken = true
+			results[c.idx].Reason = ""
+			results[c.idx].FulfilledAt = t
+			results[c.idx].Tx = report.Txs
+			batch++
+		}
+		queue = rest
+		slices.SortStableFunc(queue, func(a, b candidate) int {
+			return a.readyAt.Compare(b.readyAt)
+		})
+
+		if batch > 0 {
+			report.Txs++
+			nextFree[f] = t.Add(sc.TxInterval)
+		}
+	}
+
+	report.Results = results
+	report.Denoms = summarize(results, locks, sc.FeeShare)
+	return report
+}
+
+// nextRelease returns the first time after t at which locked capital of the denom is
+// returned, the capital is never returned without finalization delay
+func nextRelease(locks []simLock, denom string, t time.Time) (time.Time, bool) {
+	var next time.Time
+	for _, l := range locks {
+		if l.denom != denom || !l.released.After(t) {
+			continue
+		}
+		if next.IsZero() || l.released.Before(next) {
+			next = l.released
+		}
+	}
+	return next, !next.IsZero()
+}
+
+// admit returns the time the order can be fulfilled at, or the reason it's not taken
+func (sc SimConfig) admit(o SimOrder) (time.Time, string) {
+	if o.Fulfilled() {
+		return time.Time{}, "already fulfilled"
+	}
+	if o.Status != "" && o.Status != OrderStatuses.Pending {
+		return time.Time{}, fmt.Sprintf("status %s", o.Status)
+	}
+	if len(sc.Rollapps) > 0 && !sc.Rollapps[o.RollappID] {
+		return time.Time{}, "rollapp not supported"
+	}
+	if reason := sc.Policy.Allows(o.DemandOrder); reason != "" {
+		return time.Time{}, reason
+	}
+
+	delay, reason := sc.validationDelay(o)
+	if reason != "" {
+		return time.Time{}, reason
+	}
+	return o.CreatedAt.Add(delay), ""
+}
+
+// validationDelay returns the delay after which the order is validated at the p2p level or
+// at the fallback level, within the validation wait time
+func (sc SimConfig) validationDelay(o SimOrder) (time.Duration, string) {
+	if len(o.ValidatedAfter) == 0 {
+		return 0, ""
+	}
+
+	levels := []string{ValidationLevels.P2P}
+	if sc.FallbackLevel != "" && sc.FallbackLevel != ValidationLevels.P2P {
+		levels = append(levels, sc.FallbackLevel)
+	}
+
+	validated := false
+	var delay time.Duration
+	for _, level := range levels {
+		v, ok := o.ValidatedAfter[level]
+		if !ok {
+			continue
+		}
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return 0, fmt.Sprintf("invalid %s validation delay: %s", level, v)
+		}
+		if sc.WaitTime > 0 && d > sc.WaitTime {
+			continue
+		}
+		if !validated || d < delay {
+			validated = true
+			delay = d
+		}
+	}
+
+	if !validated {
+		return 0, fmt.Sprintf("not validated at %v within %s", levels, sc.WaitTime)
+	}
+	return delay, ""
+}
+
+func summarize(results []SimResult, locks []simLock, feeShare float64) []SimDenomSummary {
+	byDenom := map[string]*SimDenomSummary{}
+	var denoms []string
+	for _, r := range results {
+		s, ok := byDenom[r.Order.Denom]
+		if !ok {
+			s = &SimDenomSummary{
+				Denom:   r.Order.Denom,
+				Volume:  cosmossdkmath.ZeroInt(),
+				Fees:    cosmossdkmath.ZeroInt(),
+				Profit:  cosmossdkmath.ZeroInt(),
+				Capital: cosmossdkmath.ZeroInt(),
+			}
+			byDenom[r.Order.Denom] = s
+			denoms = append(denoms, r.Order.Denom)
+		}
+
+		if !r.Taken {
+			s.Skipped++
+			continue
+		}
+		s.Taken++
+		s.Volume = s.Volume.Add(r.Order.Price)
+		s.Fees = s.Fees.Add(r.Order.Fee)
+		s.Profit = s.Profit.Add(r.Order.ExpectedProfit(feeShare))
+	}
+
+	// the locked funds only grow when an order is fulfilled
+	for _, l := range locks {
+		locked := cosmossdkmath.ZeroInt()
+		for _, other := range locks {
+			if other.denom == l.denom && other.activeAt(l.at) {
+				locked = locked.Add(other.amount)
+			}
+		}
+		if s := byDenom[l.denom]; locked.GT(s.Capital) {
+			s.Capital = locked
+		}
+	}
+
+	slices.Sort(denoms)
+	summaries := make([]SimDenomSummary, 0, len(denoms))
+	for _, d := range denoms {
+		summaries = append(summaries, *byDenom[d])
+	}
+	return summaries
+}
@@ -0,0 +1,247 @@
+package eibc
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	cosmossdkmath "cosmossdk.io/math"
+	cosmossdktypes "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestSimulate(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	order := func(
+		id string,
+		price int64,
+		created time.Duration,
+		validated map[string]string,
+	) SimOrder {
+		return SimOrder{
+			DemandOrder: DemandOrder{
+				ID:        id,
+				RollappID: "rollapp_1-1",
+				Denom:     "adym",
+				Price:     cosmossdkmath.NewInt(price),
+				Fee:       cosmossdkmath.NewInt(price / 100),
+				CreatedAt: start.Add(created),
+			},
+			ValidatedAfter: validated,
+		}
+	}
+
+	type want struct {
+		taken bool
+		at    time.Duration
+		tx    int
+		// reason is a substring of the reason the order isn't taken
+		reason string
+	}
+	tests := []struct {
+		name    string
+		orders  []SimOrder
+		sc      SimConfig
+		want    []want
+		wantTxs int
+	}{
+		{
+			name: "batches of max orders per tx",
+			orders: []SimOrder{
+				order("a", 100, 0, nil),
+				order("b", 100, 0, nil),
+				order("c", 100, 0, nil),
+				order("d", 100, 0, nil),
+				order("e", 100, 0, nil),
+			},
+			sc: SimConfig{MaxOrdersPerTx: 2, Fulfillers: 1, TxInterval: time.Minute},
+			want: []want{
+				{taken: true, at: 0, tx: 0},
+				{taken: true, at: 0, tx: 0},
+				{taken: true, at: time.Minute, tx: 1},
+				{taken: true, at: time.Minute, tx: 1},
+				{taken: true, at: 2 * time.Minute, tx: 2},
+			},
+			wantTxs: 3,
+		},
+		{
+			name: "batches spread over the fulfillers",
+			orders: []SimOrder{
+				order("a", 100, 0, nil),
+				order("b", 100, 0, nil),
+				order("c", 100, 0, nil),
+				order("d", 100, 0, nil),
+				order("e", 100, 0, nil),
+			},
+			sc: SimConfig{MaxOrdersPerTx: 2, Fulfillers: 2, TxInterval: time.Minute},
+			want: []want{
+				{taken: true, at: 0, tx: 0},
+				{taken: true, at: 0, tx: 0},
+				{taken: true, at: 0, tx: 1},
+				{taken: true, at: 0, tx: 1},
+				{taken: true, at: time.Minute, tx: 2},
+			},
+			wantTxs: 3,
+		},
+		{
+			name: "requeued at the next capital release",
+			orders: []SimOrder{
+				order("a", 100, 0, nil),
+				order("b", 100, time.Minute, nil),
+			},
+			sc: SimConfig{
+				MaxOrdersPerTx: 1,
+				Fulfillers:     1,
+				TxInterval:     time.Minute,
+				Finalization:   10 * time.Minute,
+				Funds:          cosmossdktypes.NewCoins(cosmossdktypes.NewInt64Coin("adym", 150)),
+			},
+			want: []want{
+				{taken: true, at: 0, tx: 0},
+				{taken: true, at: 10 * time.Minute, tx: 1},
+			},
+			wantTxs: 2,
+		},
+		{
+			name: "dropped without capital release",
+			orders: []SimOrder{
+				order("a", 100, 0, nil),
+				order("b", 100, time.Minute, nil),
+			},
+			sc: SimConfig{
+				MaxOrdersPerTx: 1,
+				Fulfillers:     1,
+				TxInterval:     time.Minute,
+				Funds:          cosmossdktypes.NewCoins(cosmossdktypes.NewInt64Coin("adym", 150)),
+			},
+			want: []want{
+				{taken: true, at: 0, tx: 0},
+				{reason: "insufficient funds"},
+			},
+			wantTxs: 1,
+		},
+		{
+			name: "validation wait time fallback",
+			orders: []SimOrder{
+				order("p2p", 100, 0, map[string]string{
+					ValidationLevels.P2P: "2m",
+				}),
+				order("fallback", 100, 0, map[string]string{
+					ValidationLevels.P2P:        "10m",
+					ValidationLevels.Settlement: "3m",
+				}),
+				order("late", 100, 0, map[string]string{
+					ValidationLevels.P2P:        "10m",
+					ValidationLevels.Settlement: "8m",
+				}),
+				order("invalid", 100, 0, map[string]string{
+					ValidationLevels.P2P: "soon",
+				}),
+			},
+			sc: SimConfig{
+				MaxOrdersPerTx: 1,
+				Fulfillers:     1,
+				TxInterval:     time.Minute,
+				FallbackLevel:  ValidationLevels.Settlement,
+				WaitTime:       5 * time.Minute,
+			},
+			want: []want{
+				{taken: true, at: 2 * time.Minute, tx: 0},
+				{taken: true, at: 3 * time.Minute, tx: 1},
+				{reason: "not validated"},
+				{reason: "invalid p2p validation delay"},
+			},
+			wantTxs: 2,
+		},
+		{
+			name: "p2p only without fallback level",
+			orders: []SimOrder{
+				order("a", 100, 0, map[string]string{
+					ValidationLevels.P2P:        "10m",
+					ValidationLevels.Settlement: "3m",
+				}),
+			},
+			sc: SimConfig{
+				MaxOrdersPerTx: 1,
+				Fulfillers:     1,
+				TxInterval:     time.Minute,
+				WaitTime:       5 * time.Minute,
+			},
+			want:    []want{{reason: "not validated"}},
+			wantTxs: 0,
+		},
+		{
+			name: "policy and supported rollapps",
+			orders: []SimOrder{
+				order("a", 100, 0, nil),
+				order("b", 2000, 0, nil),
+				func() SimOrder {
+					o := order("c", 100, 0, nil)
+					o.RollappID = "rollapp_2-1"
+					return o
+				}(),
+			},
+			sc: SimConfig{
+				Policy: FulfillPolicy{
+					MaxOrderSize: maxOrderSize{Asset: map[string]string{"adym": "1000"}},
+				},
+				Rollapps:       map[string]bool{"rollapp_1-1": true},
+				MaxOrdersPerTx: 1,
+				Fulfillers:     1,
+				TxInterval:     time.Minute,
+			},
+			want: []want{
+				{taken: true, at: 0, tx: 0},
+				{reason: "above the maximal order size"},
+				{reason: "rollapp not supported"},
+			},
+			wantTxs: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := Simulate(tt.orders, tt.sc)
+			if report.Txs != tt.wantTxs {
+				t.Fatalf("expected %d txs, got %d", tt.wantTxs, report.Txs)
+			}
+			if len(report.Results) != len(tt.want) {
+				t.Fatalf("expected %d results, got %d", len(tt.want), len(report.Results))
+			}
+
+			for i, w := range tt.want {
+				r := report.Results[i]
+				if r.Taken != w.taken {
+					t.Fatalf(
+						"order %s: expected taken %v, got %v (%s)",
+						r.Order.ID,
+						w.taken,
+						r.Taken,
+						r.Reason,
+					)
+				}
+				if !w.taken {
+					if !strings.Contains(r.Reason, w.reason) {
+						t.Fatalf(
+							"order %s: expected reason %q, got %q",
+							r.Order.ID,
+							w.reason,
+							r.Reason,
+						)
+					}
+					continue
+				}
+				if !r.FulfilledAt.Equal(start.Add(w.at)) {
+					t.Fatalf(
+						"order %s: expected fulfilment at %v, got %v",
+						r.Order.ID,
+						start.Add(w.at),
+						r.FulfilledAt,
+					)
+				}
+				if r.Tx != w.tx {
+					t.Fatalf("order %s: expected tx %d, got %d", r.Order.ID, w.tx, r.Tx)
+				}
+			}
+		})
+	}
+}
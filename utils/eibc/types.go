@@ -22,9 +22,10 @@ type Config struct {
 	LogLevel    string `yaml:"log_level"`
 	NodeAddress string `yaml:"node_address"`
 
-	OperatorConfig operatorConfig           `yaml:"operator"`
-	OrderPolling   orderPollingConfig       `yaml:"order_polling"`
-	Rollapps       map[string]rollappConfig `yaml:"rollapps"`
+	FulfillCriteria FulfillPolicy            `yaml:"fulfill_criteria"`
+	OperatorConfig  operatorConfig           `yaml:"operator"`
+	OrderPolling    orderPollingConfig       `yaml:"order_polling"`
+	Rollapps        map[string]rollappConfig `yaml:"rollapps"`
 
 	SlackConfig slackConfig      `yaml:"slack"`
 	Validation  validationConfig `yaml:"validation"`
@@ -68,8 +69,15 @@ type validationConfig struct {
 }
 
 type minFeePercentage struct {
-	Chain map[string]float32 `yaml:"chain"`
-	Asset map[string]float32 `yaml:"asset"`
+	Chain map[string]float32 `yaml:"chain" json:"chain,omitempty"`
+	Asset map[string]float32 `yaml:"asset" json:"asset,omitempty"`
+}
+
+// maxOrderSize values are the maximal order prices in the base denom, they are not read by
+// the eibc client and are enforced by roller
+type maxOrderSize struct {
+	Chain map[string]string `yaml:"chain" json:"chain,omitempty"`
+	Asset map[string]string `yaml:"asset" json:"asset,omitempty"`
 }
 
 type slackConfig struct {
@@ -81,6 +89,7 @@ type slackConfig struct {
 
 func (e *Config) RemoveChain(chainId string) {
 	delete(e.Rollapps, chainId)
+	e.FulfillCriteria.RemoveRollapp(chainId)
 }
 
 func (e *Config) LoadConfig(eibcConfigPath string) error {
@@ -8,6 +8,36 @@ import (
 	"github.com/pterm/pterm"
 )
 
+// serviceFilePath returns the path of the service file of the service on the current OS
+func serviceFilePath(svc string) (string, error) {
+	switch runtime.GOOS {
+	case "linux":
+		return filepath.Join("/etc/systemd/system/", fmt.Sprintf("%s.service", svc)), nil
+	case "darwin":
+		return filepath.Join(
+			"/Library/LaunchDaemons/",
+			fmt.Sprintf("xyz.dymension.roller.%s.plist", svc),
+		), nil
+	default:
+		return "", fmt.Errorf("OS %s not supported", runtime.GOOS)
+	}
+}
+
+// ServiceFilesExist returns true when the services are loaded with 'roller services load'
+func ServiceFilesExist(services []string) bool {
+	for _, svc := range services {
+		fp, err := serviceFilePath(svc)
+		if err != nil {
+			return false
+		}
+		exists, err := DoesFileExist(fp)
+		if err != nil || !exists {
+			return false
+		}
+	}
+	return true
+}
+
 func RemoveServiceFiles(services []string) error {
 	pterm.Info.Println("removing old systemd services")
 
@@ -0,0 +1,265 @@
+package healthagent
+
+import (
+	"fmt"
+	"log"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/cmd/services/load"
+	"github.com/dymensionxyz/roller/utils/config/tomlconfig"
+	"github.com/dymensionxyz/roller/utils/liveness"
+	"github.com/dymensionxyz/roller/utils/roller"
+	servicemanager "github.com/dymensionxyz/roller/utils/service_manager"
+)
+
+const (
+	checkInterval = 15 * time.Second
+	// livenessInterval is the interval of the hub liveness checks, they are
+	// executed less often as they query the hub
+	livenessInterval = time.Minute
+	// maxHistory is the number of checks and actions kept in memory and
+	// returned by the status API
+	maxHistory = 50
+	// maxFailedDaSubmissions is the number of consecutive failed DA submissions
+	// after which the DA node is considered unhealthy
+	maxFailedDaSubmissions = 10
+
+	localEndpoint       = "localhost"
+	defaultRaMetricPort = "2112"
+	defaultDaRpcPort    = "26658"
+)
+
+type Check struct {
+	Time                   time.Time `json:"time"`
+	DaNode                 string    `json:"da_node"`
+	DaNodeHealthy          bool      `json:"da_node_healthy"`
+	FailedDaSubmissions    int       `json:"failed_da_submissions"`
+	FailedDaSubmissionsErr string    `json:"failed_da_submissions_error,omitempty"`
+	Healthy                bool      `json:"healthy"`
+}
+
+type Action struct {
+	Time    time.Time `json:"time"`
+	Action  string    `json:"action"`
+	Details string    `json:"details"`
+	Error   string    `json:"error,omitempty"`
+}
+
+type Status struct {
+	StartedAt            time.Time `json:"started_at"`
+	RemediationEnabled   bool      `json:"remediation_enabled"`
+	CurrentDaNode        string    `json:"current_da_node"`
+	Healthy              bool      `json:"healthy"`
+	Checks               []Check   `json:"checks"`
+	Actions              []Action  `json:"actions"`
+	LastCheckAt          time.Time `json:"last_check_at"`
+	CheckIntervalSeconds int       `json:"check_interval_seconds"`
+	// Liveness is the latest hub liveness report, it's only available for sequencers
+	Liveness          *liveness.Report `json:"liveness,omitempty"`
+	ProposerRotations int              `json:"proposer_rotations"`
+}
+
+// Agent periodically checks the health of the DA light client and, when
+// remediation is enabled, hotswaps the DA state node when it's unhealthy
+type Agent struct {
+	home   string
+	logger *log.Logger
+
+	mu             sync.RWMutex
+	status         Status
+	lastLivenessAt time.Time
+}
+
+func NewAgent(home string, l *log.Logger) *Agent {
+	return &Agent{
+		home:   home,
+		logger: l,
+		status: Status{
+			StartedAt:            time.Now().UTC(),
+			Healthy:              true,
+			Checks:               []Check{},
+			Actions:              []Action{},
+			CheckIntervalSeconds: int(checkInterval.Seconds()),
+		},
+	}
+}
+
+// Run blocks and executes the health checks every checkInterval
+func (a *Agent) Run() {
+	for {
+		time.Sleep(checkInterval)
+		a.runCheck()
+	}
+}
+
+// Status returns a copy of the current agent status
+func (a *Agent) Status() Status {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	s := a.status
+	s.Checks = slices.Clone(a.status.Checks)
+	s.Actions = slices.Clone(a.status.Actions)
+	return s
+}
+
+func (a *Agent) runCheck() {
+	rollerData, err := roller.LoadConfig(a.home)
+	if err != nil {
+		a.logger.Println("failed to load roller config:", err)
+		return
+	}
+
+	c := Check{
+		Time:   time.Now().UTC(),
+		DaNode: rollerData.DA.CurrentStateNode,
+	}
+
+	localDaRpcEndpoint := fmt.Sprintf("http://%s:%s", localEndpoint, defaultDaRpcPort)
+	c.DaNodeHealthy, _ = IsEndpointHealthy(localDaRpcEndpoint)
+	c.Healthy = c.DaNodeHealthy
+
+	submissions, err := QueryPromMetric(
+		localEndpoint,
+		defaultRaMetricPort,
+		"rollapp_consecutive_failed_da_submissions",
+	)
+	if err != nil {
+		a.logger.Println(err)
+		c.FailedDaSubmissionsErr = err.Error()
+	}
+	c.FailedDaSubmissions = submissions
+
+	if submissions > maxFailedDaSubmissions {
+		c.Healthy = false
+	}
+
+	a.mu.Lock()
+	a.status.Checks = appendCapped(a.status.Checks, c)
+	a.status.CurrentDaNode = c.DaNode
+	a.status.Healthy = c.Healthy
+	a.status.LastCheckAt = c.Time
+	a.status.RemediationEnabled = rollerData.HealthAgent.Enabled
+	a.mu.Unlock()
+
+	if rollerData.HubData.ID != consts.MockHubID && rollerData.NodeType == "sequencer" &&
+		time.Since(a.lastLivenessAt) >= livenessInterval {
+		a.lastLivenessAt = time.Now()
+		a.checkLiveness(rollerData)
+	}
+
+	if c.Healthy || !rollerData.HealthAgent.Enabled {
+		return
+	}
+
+	a.swapDaNode(rollerData)
+}
+
+func (a *Agent) checkLiveness(rollerData roller.RollappConfig) {
+	r, err := liveness.Check(rollerData)
+	if err != nil {
+		a.logger.Println("failed to check hub liveness:", err)
+		return
+	}
+
+	for _, w := range r.Warnings {
+		a.logger.Println("liveness:", w)
+	}
+
+	a.mu.Lock()
+	prev := a.status.Liveness
+	a.status.Liveness = r
+	rotated := prev != nil && prev.IsProposer && !r.IsProposer
+	if rotated {
+		a.status.ProposerRotations++
+	}
+	a.mu.Unlock()
+
+	if rotated {
+		a.recordAction(
+			"proposer_rotation",
+			fmt.Sprintf("proposer changed from %s to %s", prev.Proposer, r.Proposer),
+			nil,
+		)
+	}
+}
+
+// TODO: improve the node swapping, add health checks before swapping etc.
+func (a *Agent) swapDaNode(rollerData roller.RollappConfig) {
+	if len(rollerData.DA.StateNodes) == 0 {
+		a.recordAction("swap_da_node", "no DA state nodes available to swap to", nil)
+		return
+	}
+
+	i := slices.Index(rollerData.DA.StateNodes, rollerData.DA.CurrentStateNode)
+	var nodeIndex int
+	if i >= 0 && i+1 < len(rollerData.DA.StateNodes) {
+		nodeIndex = i + 1
+	} else {
+		nodeIndex = 0
+	}
+	newStateNode := rollerData.DA.StateNodes[nodeIndex]
+
+	a.logger.Printf("detected problems with DA, hotswapping node to %s\n", newStateNode)
+	details := fmt.Sprintf("%s -> %s", rollerData.DA.CurrentStateNode, newStateNode)
+
+	err := tomlconfig.UpdateFieldInFile(
+		roller.GetConfigPath(a.home),
+		"DA.current_state_node",
+		newStateNode,
+	)
+	if err != nil {
+		a.recordAction("swap_da_node", details, fmt.Errorf("failed to update state node: %w", err))
+		return
+	}
+	rollerData.DA.CurrentStateNode = newStateNode
+
+	servicesToRestart := []string{
+		"da-light-client",
+	}
+
+	err = load.LoadServices(servicesToRestart, rollerData)
+	if err != nil {
+		a.recordAction("swap_da_node", details, fmt.Errorf("failed to update services: %w", err))
+		return
+	}
+
+	err = servicemanager.RestartSystemServices(servicesToRestart, a.home)
+	if err != nil {
+		a.recordAction("swap_da_node", details, fmt.Errorf("failed to restart services: %w", err))
+		return
+	}
+
+	a.mu.Lock()
+	a.status.CurrentDaNode = newStateNode
+	a.mu.Unlock()
+
+	a.recordAction("swap_da_node", details, nil)
+}
+
+func (a *Agent) recordAction(action, details string, err error) {
+	ac := Action{
+		Time:    time.Now().UTC(),
+		Action:  action,
+		Details: details,
+	}
+	if err != nil {
+		a.logger.Println(err)
+		ac.Error = err.Error()
+	}
+
+	a.mu.Lock()
+	a.status.Actions = appendCapped(a.status.Actions, ac)
+	a.mu.Unlock()
+}
+
+func appendCapped[T any](s []T, v T) []T {
+	s = append(s, v)
+	if len(s) > maxHistory {
+		s = s[len(s)-maxHistory:]
+	}
+	return s
+}
@@ -5,94 +5,19 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"log"
 	"net/http"
-	"slices"
 	"strconv"
 	"strings"
-	"time"
 
-	"github.com/pterm/pterm"
-
-	"github.com/dymensionxyz/roller/cmd/services/load"
-	"github.com/dymensionxyz/roller/utils/config/tomlconfig"
 	"github.com/dymensionxyz/roller/utils/dymint"
-	"github.com/dymensionxyz/roller/utils/errorhandling"
-	"github.com/dymensionxyz/roller/utils/roller"
-	servicemanager "github.com/dymensionxyz/roller/utils/service_manager"
 )
 
-func Start(home string, l *log.Logger) {
-	for {
-		time.Sleep(15 * time.Second)
-		var healthy bool
-		localEndpoint := "localhost"
-		defaultRaMetricPort := "2112"
-		localDaRpcEndpoint := fmt.Sprintf("http://%s:%s", localEndpoint, "26658")
-
-		isDaNodeHealthy, _ := IsEndpointHealthy(localDaRpcEndpoint)
-		healthy = isDaNodeHealthy
-
-		submissions, err := QueryPromMetric(
-			localEndpoint,
-			defaultRaMetricPort,
-			"rollapp_consecutive_failed_da_submissions",
-		)
-		if err != nil {
-			l.Println(err)
-		}
-
-		if submissions > 10 {
-			healthy = false
-		}
-
-		// TODO: improve the node swapping, add health checks before swapping etc.
-		if !healthy {
-			rollerData, err := roller.LoadConfig(home)
-			errorhandling.PrettifyErrorIfExists(err)
-			rollerConfigPath := roller.GetConfigPath(home)
-
-			i := slices.Index(rollerData.DA.StateNodes, rollerData.DA.CurrentStateNode)
-			var newStateNode string
-			var nodeIndex int
-			if i >= 0 && i+1 < len(rollerData.DA.StateNodes) {
-				nodeIndex = i + 1
-			} else {
-				nodeIndex = 0
-			}
-
-			pterm.Warning.Printf(
-				"detected problems with DA, hotswapping node to %s\n",
-				rollerData.DA.StateNodes[nodeIndex],
-			)
-			nsn := rollerData.DA.StateNodes[nodeIndex]
-			newStateNode = nsn
-			err = tomlconfig.UpdateFieldInFile(
-				rollerConfigPath,
-				"DA.current_state_node",
-				newStateNode,
-			)
-			if err != nil {
-				pterm.Error.Println("failed to update state node: ", err)
-			}
-
-			servicesToRestart := []string{
-				"da-light-client",
-			}
-
-			err = load.LoadServices(servicesToRestart, rollerData)
-			if err != nil {
-				pterm.Error.Println("failed to update services")
-			}
-
-			err = servicemanager.RestartSystemServices(servicesToRestart, home)
-			if err != nil {
-				pterm.Error.Println("failed to restart services")
-			}
-		}
-
-		healthy = true
-	}
+// DymintMetrics are the key metrics exposed by the rollapp node
+var DymintMetrics = []string{
+	"dymint_mempool_size",
+	"rollapp_pending_submissions_skew_batches",
+	"rollapp_hub_height",
+	"rollapp_consecutive_failed_da_submissions",
 }
 
 func IsEndpointHealthy(url string) (bool, any) {
@@ -130,34 +55,72 @@ func IsEndpointHealthy(url string) (bool, any) {
 }
 
 func QueryPromMetric(host, promMetricPort, metric string) (int, error) {
+	values, err := queryPromMetricValues(host, promMetricPort, []string{metric})
+	if err != nil {
+		return 0, err
+	}
+
+	v, ok := values[metric]
+	if !ok {
+		return 0, fmt.Errorf("metric not found: %s", metric)
+	}
+
+	value, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, fmt.Errorf("error converting metric value to int: %v", err)
+	}
+	return value, nil
+}
+
+// QueryPromMetrics returns the values of the requested metrics, metrics that are
+// not exposed by the endpoint are omitted from the result
+func QueryPromMetrics(host, promMetricPort string, metrics []string) (map[string]float64, error) {
+	values, err := queryPromMetricValues(host, promMetricPort, metrics)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]float64, len(values))
+	for m, v := range values {
+		value, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error converting metric %s value to float: %v", m, err)
+		}
+		res[m] = value
+	}
+
+	return res, nil
+}
+
+func queryPromMetricValues(host, promMetricPort string, metrics []string) (map[string]string, error) {
 	endpoint := fmt.Sprintf("http://%s:%s/metrics", host, promMetricPort)
 	// nolint: gosec
 	resp, err := http.Get(endpoint)
 	if err != nil {
-		return 0, fmt.Errorf("error fetching metrics: %v", err)
+		return nil, fmt.Errorf("error fetching metrics: %v", err)
 	}
 	// nolint: errcheck
 	defer resp.Body.Close()
 
+	values := make(map[string]string, len(metrics))
 	scanner := bufio.NewScanner(resp.Body)
 	for scanner.Scan() {
 		line := scanner.Text()
-		if strings.HasPrefix(line, metric) {
+		for _, metric := range metrics {
+			if _, ok := values[metric]; ok || !strings.HasPrefix(line, metric) {
+				continue
+			}
 			parts := strings.Fields(line)
 			if len(parts) != 2 {
-				return 0, fmt.Errorf("unexpected format for metric line: %s", line)
-			}
-			value, err := strconv.Atoi(parts[1])
-			if err != nil {
-				return 0, fmt.Errorf("error converting metric value to int: %v", err)
+				return nil, fmt.Errorf("unexpected format for metric line: %s", line)
 			}
-			return value, nil
+			values[metric] = parts[1]
 		}
 	}
 
 	if err := scanner.Err(); err != nil {
-		return 0, fmt.Errorf("error reading metrics response: %v", err)
+		return nil, fmt.Errorf("error reading metrics response: %v", err)
 	}
 
-	return 0, fmt.Errorf("metric not found: %s", metric)
+	return values, nil
 }
@@ -0,0 +1,130 @@
+package healthagent
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/dymensionxyz/roller/utils/roller"
+)
+
+const DefaultListenAddress = "localhost:2113"
+
+// Serve exposes the agent status over http on addr, it blocks until the server fails
+func (a *Agent) Serve(addr string) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/status", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		// nolint: errcheck
+		json.NewEncoder(w).Encode(a.Status())
+	})
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		writeMetrics(w, a.Status())
+	})
+
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	return srv.ListenAndServe()
+}
+
+// QueryStatus retrieves the status of the health agent listening on addr
+func QueryStatus(addr string) (*Status, error) {
+	c := http.Client{Timeout: 5 * time.Second}
+	resp, err := c.Get(fmt.Sprintf("http://%s/status", addr))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query health agent: %w", err)
+	}
+	// nolint: errcheck
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("health agent returned status %d", resp.StatusCode)
+	}
+
+	var s Status
+	err = json.NewDecoder(resp.Body).Decode(&s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode health agent status: %w", err)
+	}
+
+	return &s, nil
+}
+
+// ListenAddress returns the address of the status API from the roller config
+func ListenAddress(cfg roller.HealthAgentConfig) string {
+	if cfg.ListenAddress == "" {
+		return DefaultListenAddress
+	}
+	return cfg.ListenAddress
+}
+
+// writeMetrics writes the agent status in the prometheus text format so that
+// it can be scraped and used in alert rules
+func writeMetrics(w io.Writer, s Status) {
+	gauge := func(name, help string, v float64) {
+		// nolint: errcheck
+		fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s gauge\n%s %v\n", name, help, name, name, v)
+	}
+	b := func(v bool) float64 {
+		if v {
+			return 1
+		}
+		return 0
+	}
+
+	gauge("roller_health_agent_healthy", "Whether the last health check succeeded", b(s.Healthy))
+
+	l := s.Liveness
+	if l == nil {
+		return
+	}
+	gauge(
+		"roller_liveness_is_proposer",
+		"Whether the local sequencer is the current proposer on the hub",
+		b(l.IsProposer),
+	)
+	gauge(
+		"roller_liveness_proposer_rotations",
+		"Number of proposer rotations away from the local sequencer since the agent started",
+		float64(s.ProposerRotations),
+	)
+	gauge(
+		"roller_liveness_submission_lag_blocks",
+		"Number of rollapp blocks not yet submitted to the hub",
+		float64(l.SubmissionLag),
+	)
+	gauge(
+		"roller_liveness_hub_blocks_since_last_state_update",
+		"Number of hub blocks since the last state update of the rollapp",
+		float64(l.BlocksSinceLastStateUpdate),
+	)
+	if !l.LastStateUpdateTime.IsZero() {
+		gauge(
+			"roller_liveness_seconds_since_last_state_update",
+			"Seconds since the last state update of the rollapp",
+			l.Time.Sub(l.LastStateUpdateTime).Seconds(),
+		)
+	}
+	gauge(
+		"roller_liveness_hub_blocks_until_slash",
+		"Number of hub blocks until the liveness slash, -1 when none is scheduled",
+		float64(l.BlocksUntilSlash),
+	)
+	gauge(
+		"roller_liveness_slash_approaching",
+		"Whether the liveness slash of the proposer is approaching",
+		b(l.SlashApproaching),
+	)
+}
@@ -0,0 +1,196 @@
+package liveness
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dymensionxyz/roller/sequencer"
+	"github.com/dymensionxyz/roller/utils/rollapp"
+	"github.com/dymensionxyz/roller/utils/roller"
+	sequencerutils "github.com/dymensionxyz/roller/utils/sequencer"
+)
+
+const (
+	// MaxSubmissionLag is the number of rollapp blocks the hub can be behind the
+	// local node before the submission is considered lagging
+	MaxSubmissionLag = 500
+	// slashWarningRatio is the portion of the liveness slash window left after
+	// which an approaching liveness slash is reported
+	slashWarningRatio = 0.2
+)
+
+// Report is the hub's view of the rollapp compared to the local node
+type Report struct {
+	Time time.Time `json:"time"`
+
+	HubHeight             int64     `json:"hub_height"`
+	LastStateUpdateHeight int64     `json:"last_state_update_height"`
+	LastStateUpdateTime   time.Time `json:"last_state_update_time"`
+	LivenessEventHeight   int64     `json:"liveness_event_height"`
+	LivenessSlashBlocks   int64     `json:"liveness_slash_blocks"`
+
+	HubRollappHeight   int64 `json:"hub_rollapp_height"`
+	LocalRollappHeight int64 `json:"local_rollapp_height"`
+
+	Proposer   string `json:"proposer"`
+	Sequencer  string `json:"sequencer"`
+	IsProposer bool   `json:"is_proposer"`
+
+	// SubmissionLag is the number of rollapp blocks not yet submitted to the hub
+	SubmissionLag int64 `json:"submission_lag"`
+	// BlocksSinceLastStateUpdate is the number of hub blocks since the last state update
+	BlocksSinceLastStateUpdate int64 `json:"blocks_since_last_state_update"`
+	// BlocksUntilSlash is the number of hub blocks left until the liveness slash,
+	// -1 when no liveness event is scheduled
+	BlocksUntilSlash int64 `json:"blocks_until_slash"`
+
+	SubmissionLagging bool     `json:"submission_lagging"`
+	SlashApproaching  bool     `json:"slash_approaching"`
+	Warnings          []string `json:"warnings"`
+}
+
+type hubBlockResponse struct {
+	Result struct {
+		Block struct {
+			Header struct {
+				Height string    `json:"height"`
+				Time   time.Time `json:"time"`
+			} `json:"header"`
+		} `json:"block"`
+	} `json:"result"`
+}
+
+// Check queries the hub for the state of the rollapp and compares it with the
+// local node
+func Check(rlpCfg roller.RollappConfig) (*Report, error) {
+	hd := rlpCfg.HubData
+	r := &Report{
+		Time:             time.Now().UTC(),
+		BlocksUntilSlash: -1,
+		Warnings:         []string{},
+	}
+
+	hubHeight, _, err := getHubBlock(hd.RpcUrl, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve hub height: %w", err)
+	}
+	r.HubHeight = hubHeight
+
+	raResp, err := rollapp.Show(rlpCfg.RollappID, hd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve rollapp from the hub: %w", err)
+	}
+	r.LastStateUpdateHeight = parseInt(raResp.Rollapp.LastStateUpdateHeight)
+	r.LivenessEventHeight = parseInt(raResp.Rollapp.LivenessEventHeight)
+	r.HubRollappHeight = parseInt(raResp.Summary.LatestHeight)
+
+	if r.LastStateUpdateHeight > 0 {
+		r.BlocksSinceLastStateUpdate = r.HubHeight - r.LastStateUpdateHeight
+		_, t, err := getHubBlock(hd.RpcUrl, r.LastStateUpdateHeight)
+		if err == nil {
+			r.LastStateUpdateTime = t
+		}
+	}
+
+	params, err := rollapp.GetRollappParams(hd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve rollapp params: %w", err)
+	}
+	r.LivenessSlashBlocks = parseInt(params.Params.LivenessSlashBlocks)
+
+	r.Proposer, err = rollapp.GetCurrentProposer(rlpCfg.RollappID, hd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve current proposer: %w", err)
+	}
+
+	r.Sequencer, err = sequencerutils.GetHubSequencerAddress(rlpCfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve sequencer address: %w", err)
+	}
+	r.IsProposer = r.Proposer == r.Sequencer
+
+	seq := sequencer.GetInstance(rlpCfg)
+	localHeight, err := seq.GetRollappHeight()
+	if err == nil {
+		r.LocalRollappHeight = parseInt(localHeight)
+	}
+
+	evaluate(r)
+
+	return r, nil
+}
+
+// evaluate populates the flags and warnings of the report from the collected data
+func evaluate(r *Report) {
+	if r.LocalRollappHeight > 0 && r.HubRollappHeight > 0 {
+		r.SubmissionLag = max(0, r.LocalRollappHeight-r.HubRollappHeight)
+	}
+	if r.SubmissionLag > MaxSubmissionLag {
+		r.SubmissionLagging = true
+		r.Warnings = append(
+			r.Warnings,
+			fmt.Sprintf("hub is %d blocks behind the local rollapp node", r.SubmissionLag),
+		)
+	}
+
+	if !r.IsProposer {
+		r.Warnings = append(
+			r.Warnings,
+			fmt.Sprintf("sequencer %s is not the current proposer (%s)", r.Sequencer, r.Proposer),
+		)
+	}
+
+	if r.LivenessEventHeight > 0 {
+		r.BlocksUntilSlash = r.LivenessEventHeight - r.HubHeight
+		if float64(r.BlocksUntilSlash) <= float64(r.LivenessSlashBlocks)*slashWarningRatio {
+			r.SlashApproaching = true
+			r.Warnings = append(
+				r.Warnings,
+				fmt.Sprintf("liveness slash in %d hub blocks", r.BlocksUntilSlash),
+			)
+		}
+	}
+}
+
+// getHubBlock returns the height and time of the hub block at height, the latest
+// block is returned when height is 0
+func getHubBlock(rpc string, height int64) (int64, time.Time, error) {
+	url := fmt.Sprintf("%s/block", strings.TrimSuffix(rpc, "/"))
+	if height > 0 {
+		url = fmt.Sprintf("%s?height=%d", url, height)
+	}
+
+	c := http.Client{Timeout: 10 * time.Second}
+	resp, err := c.Get(url)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	// nolint: errcheck
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+
+	var br hubBlockResponse
+	err = json.Unmarshal(body, &br)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+
+	return parseInt(br.Result.Block.Header.Height), br.Result.Block.Header.Time, nil
+}
+
+func parseInt(s string) int64 {
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return v
+}
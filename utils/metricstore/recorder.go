@@ -0,0 +1,128 @@
+package metricstore
+
+import (
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	datalayer "github.com/dymensionxyz/roller/data_layer"
+	"github.com/dymensionxyz/roller/sequencer"
+	"github.com/dymensionxyz/roller/utils/healthagent"
+	"github.com/dymensionxyz/roller/utils/roller"
+	sequencerutils "github.com/dymensionxyz/roller/utils/sequencer"
+)
+
+const DefaultInterval = 30 * time.Second
+
+// metrics recorded by roller in addition to the dymint metrics
+var RecorderMetrics = struct {
+	RollappHeight    string
+	HubRollappHeight string
+	SequencerBalance string
+	DaBalance        string
+}{
+	RollappHeight:    "roller_rollapp_height",
+	HubRollappHeight: "roller_hub_rollapp_height",
+	SequencerBalance: "roller_sequencer_balance",
+	DaBalance:        "roller_da_balance",
+}
+
+// Recorder periodically samples the key rollapp metrics, balances and heights
+// into the store
+type Recorder struct {
+	rollerData roller.RollappConfig
+	store      *Store
+	interval   time.Duration
+	logger     *log.Logger
+}
+
+func NewRecorder(
+	rollerData roller.RollappConfig,
+	store *Store,
+	interval time.Duration,
+	l *log.Logger,
+) *Recorder {
+	return &Recorder{
+		rollerData: rollerData,
+		store:      store,
+		interval:   interval,
+		logger:     l,
+	}
+}
+
+// Run blocks and records a sample of every metric each interval
+func (r *Recorder) Run() {
+	for {
+		r.record(time.Now())
+		time.Sleep(r.interval)
+	}
+}
+
+func (r *Recorder) record(t time.Time) {
+	samples := r.sample()
+	for m, v := range samples {
+		err := r.store.Append(m, Sample{Time: t, Value: v})
+		if err != nil {
+			r.logger.Printf("failed to record %s: %v\n", m, err)
+		}
+	}
+}
+
+func (r *Recorder) sample() map[string]float64 {
+	samples, err := healthagent.QueryPromMetrics(
+		"localhost",
+		"2112",
+		healthagent.DymintMetrics,
+	)
+	if err != nil {
+		r.logger.Println("failed to query rollapp metrics:", err)
+		samples = map[string]float64{}
+	}
+
+	seq := sequencer.GetInstance(r.rollerData)
+	h, err := seq.GetRollappHeight()
+	if err == nil {
+		setParsed(samples, RecorderMetrics.RollappHeight, h)
+	}
+
+	if r.rollerData.HubData.ID == consts.MockHubID {
+		return samples
+	}
+
+	h, err = seq.GetHubHeight()
+	if err == nil {
+		setParsed(samples, RecorderMetrics.HubRollappHeight, h)
+	}
+
+	if r.rollerData.NodeType != "sequencer" {
+		return samples
+	}
+
+	seqData, err := sequencerutils.GetSequencerData(r.rollerData)
+	if err == nil && len(seqData) > 0 {
+		setParsed(samples, RecorderMetrics.SequencerBalance, seqData[0].Balance.Amount.String())
+	}
+
+	if r.rollerData.DA.Backend == consts.Celestia {
+		damanager := datalayer.NewDAManager(
+			r.rollerData.DA.Backend,
+			r.rollerData.Home,
+			r.rollerData.KeyringBackend,
+		)
+		daData, err := damanager.GetDAAccData(r.rollerData)
+		if err == nil && len(daData) > 0 {
+			setParsed(samples, RecorderMetrics.DaBalance, daData[0].Balance.Amount.String())
+		}
+	}
+
+	return samples
+}
+
+func setParsed(samples map[string]float64, metric, v string) {
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return
+	}
+	samples[metric] = f
+}
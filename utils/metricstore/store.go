@@ -0,0 +1,243 @@
+package metricstore
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultCapacity is the number of samples kept per metric, 7 days of samples
+	// with the default 30s sampling interval
+	DefaultCapacity = 20160
+
+	fileExt    = ".ring"
+	magic      = "RRB1"
+	headerSize = 16
+	recordSize = 16
+)
+
+var metricNameRegex = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+type Sample struct {
+	Time  time.Time
+	Value float64
+}
+
+// Store is an on-disk ring buffer per metric, once a metric reaches the capacity
+// of the store the oldest samples are overwritten
+type Store struct {
+	dir      string
+	capacity uint32
+}
+
+type header struct {
+	Capacity uint32
+	Head     uint32
+	Count    uint32
+}
+
+// Dir returns the directory the metric history is stored in
+func Dir(home string) string {
+	return filepath.Join(home, "metrics-history")
+}
+
+func New(dir string, capacity uint32) (*Store, error) {
+	if capacity == 0 {
+		return nil, errors.New("capacity must be greater than 0")
+	}
+
+	err := os.MkdirAll(dir, 0o755)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Store{dir: dir, capacity: capacity}, nil
+}
+
+// Append stores the sample of the metric, overwriting the oldest sample when the
+// buffer is full
+func (s *Store) Append(metric string, sample Sample) error {
+	fp, err := s.path(metric)
+	if err != nil {
+		return err
+	}
+
+	// nolint:gosec
+	f, err := os.OpenFile(fp, os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		return err
+	}
+	// nolint: errcheck
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	// only a new file gets a fresh header, a truncated or corrupted header is an error
+	// instead of being overwritten
+	h := header{Capacity: s.capacity}
+	if fi.Size() > 0 {
+		h, err = readHeader(f)
+		if err != nil {
+			return fmt.Errorf("failed to read %s history: %w", metric, err)
+		}
+	}
+	if h.Capacity == 0 {
+		return fmt.Errorf("invalid %s history capacity: 0", metric)
+	}
+
+	rec := make([]byte, recordSize)
+	binary.LittleEndian.PutUint64(rec[0:8], uint64(sample.Time.Unix()))
+	binary.LittleEndian.PutUint64(rec[8:16], math.Float64bits(sample.Value))
+
+	_, err = f.WriteAt(rec, int64(headerSize+h.Head*recordSize))
+	if err != nil {
+		return err
+	}
+
+	h.Head = (h.Head + 1) % h.Capacity
+	if h.Count < h.Capacity {
+		h.Count++
+	}
+
+	return writeHeader(f, h)
+}
+
+// Read returns the samples of the metric recorded after since, ordered by time
+func (s *Store) Read(metric string, since time.Time) ([]Sample, error) {
+	fp, err := s.path(metric)
+	if err != nil {
+		return nil, err
+	}
+
+	// nolint:gosec
+	f, err := os.Open(fp)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("no history recorded for metric %s", metric)
+		}
+		return nil, err
+	}
+	// nolint: errcheck
+	defer f.Close()
+
+	h, err := readHeader(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s history: %w", metric, err)
+	}
+
+	buf := make([]byte, int(h.Count)*recordSize)
+	_, err = f.ReadAt(buf, headerSize)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	// the oldest sample is at the head once the buffer has wrapped around
+	oldest := (h.Head + h.Capacity - h.Count) % h.Capacity
+	samples := make([]Sample, 0, h.Count)
+	for i := uint32(0); i < h.Count; i++ {
+		pos := (oldest + i) % h.Capacity
+		rec := buf[pos*recordSize : (pos+1)*recordSize]
+		// nolint:gosec
+		t := time.Unix(int64(binary.LittleEndian.Uint64(rec[0:8])), 0)
+		if t.Before(since) {
+			continue
+		}
+		samples = append(samples, Sample{
+			Time:  t,
+			Value: math.Float64frombits(binary.LittleEndian.Uint64(rec[8:16])),
+		})
+	}
+
+	sort.SliceStable(samples, func(i, j int) bool {
+		return samples[i].Time.Before(samples[j].Time)
+	})
+
+	return samples, nil
+}
+
+// Metrics returns the names of the metrics with recorded history
+func (s *Store) Metrics() ([]string, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var metrics []string
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), fileExt) {
+			continue
+		}
+		metrics = append(metrics, strings.TrimSuffix(e.Name(), fileExt))
+	}
+
+	return metrics, nil
+}
+
+// ValidateMetricName checks that the metric name only contains lowercase letters, digits
+// and underscores so that its file stays in the store directory
+func ValidateMetricName(metric string) error {
+	if !metricNameRegex.MatchString(metric) {
+		return fmt.Errorf(
+			"invalid metric name %q, only lowercase letters, digits and '_' are allowed",
+			metric,
+		)
+	}
+	return nil
+}
+
+func (s *Store) path(metric string) (string, error) {
+	err := ValidateMetricName(metric)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(s.dir, metric+fileExt), nil
+}
+
+func readHeader(f *os.File) (header, error) {
+	b := make([]byte, headerSize)
+	_, err := f.ReadAt(b, 0)
+	if errors.Is(err, io.EOF) {
+		return header{}, errors.New("truncated history file header")
+	}
+	if err != nil {
+		return header{}, err
+	}
+
+	if string(b[0:4]) != magic {
+		return header{}, errors.New("invalid history file")
+	}
+
+	h := header{
+		Capacity: binary.LittleEndian.Uint32(b[4:8]),
+		Head:     binary.LittleEndian.Uint32(b[8:12]),
+		Count:    binary.LittleEndian.Uint32(b[12:16]),
+	}
+	if h.Capacity == 0 || h.Head >= h.Capacity || h.Count > h.Capacity {
+		return header{}, errors.New("corrupted history file header")
+	}
+
+	return h, nil
+}
+
+func writeHeader(f *os.File, h header) error {
+	b := make([]byte, headerSize)
+	copy(b[0:4], magic)
+	binary.LittleEndian.PutUint32(b[4:8], h.Capacity)
+	binary.LittleEndian.PutUint32(b[8:12], h.Head)
+	binary.LittleEndian.PutUint32(b[12:16], h.Count)
+
+	_, err := f.WriteAt(b, 0)
+	return err
+}
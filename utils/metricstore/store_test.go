@@ -0,0 +1,139 @@
+package metricstore
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestStoreWraparound(t *testing.T) {
+	tests := []struct {
+		name       string
+		capacity   uint32
+		appends    int
+		wantValues []float64
+	}{
+		{
+			name:       "below capacity",
+			capacity:   4,
+			appends:    3,
+			wantValues: []float64{0, 1, 2},
+		},
+		{
+			name:       "exactly full",
+			capacity:   4,
+			appends:    4,
+			wantValues: []float64{0, 1, 2, 3},
+		},
+		{
+			name:       "wrapped around",
+			capacity:   4,
+			appends:    6,
+			wantValues: []float64{2, 3, 4, 5},
+		},
+		{
+			name:       "wrapped around twice",
+			capacity:   3,
+			appends:    8,
+			wantValues: []float64{5, 6, 7},
+		},
+	}
+
+	start := time.Unix(1700000000, 0)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := New(t.TempDir(), tt.capacity)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			for i := 0; i < tt.appends; i++ {
+				sample := Sample{Time: start.Add(time.Duration(i) * time.Second), Value: float64(i)}
+				err := s.Append("height", sample)
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+			}
+
+			samples, err := s.Read("height", time.Time{})
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if len(samples) != len(tt.wantValues) {
+				t.Fatalf("expected %d samples, got %d", len(tt.wantValues), len(samples))
+			}
+			for i, want := range tt.wantValues {
+				if samples[i].Value != want {
+					t.Fatalf("expected %v at %d, got %v", want, i, samples[i].Value)
+				}
+				wantTime := start.Add(time.Duration(want) * time.Second)
+				if !samples[i].Time.Equal(wantTime) {
+					t.Fatalf("expected %v at %d, got %v", wantTime, i, samples[i].Time)
+				}
+			}
+
+			since := start.Add(time.Duration(tt.appends-1) * time.Second)
+			samples, err = s.Read("height", since)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if len(samples) != 1 || samples[0].Value != float64(tt.appends-1) {
+				t.Fatalf("expected the last sample only, got %v", samples)
+			}
+		})
+	}
+}
+
+func TestStoreCorruptedHeader(t *testing.T) {
+	tests := []struct {
+		name   string
+		header []byte
+	}{
+		{
+			name:   "zero capacity",
+			header: []byte("RRB1\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00"),
+		},
+		{
+			name:   "head beyond capacity",
+			header: []byte("RRB1\x02\x00\x00\x00\x05\x00\x00\x00\x00\x00\x00\x00"),
+		},
+		{
+			name:   "invalid magic",
+			header: []byte("XXXX\x02\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00"),
+		},
+		{
+			name:   "truncated",
+			header: []byte("RRB1\x02\x00"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			s, err := New(dir, 4)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			err = os.WriteFile(filepath.Join(dir, "height"+fileExt), tt.header, 0o644)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			err = s.Append("height", Sample{Time: time.Now(), Value: 1})
+			if err == nil {
+				t.Fatalf("expected an error on append")
+			}
+			_, err = s.Read("height", time.Time{})
+			if err == nil {
+				t.Fatalf("expected an error on read")
+			}
+		})
+	}
+}
+
+func TestNewZeroCapacity(t *testing.T) {
+	_, err := New(t.TempDir(), 0)
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+}
@@ -0,0 +1,178 @@
+package relayer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/relayer"
+	"github.com/dymensionxyz/roller/utils/bash"
+	"github.com/dymensionxyz/roller/utils/config/yamlconfig"
+)
+
+// RecoverClientProposal represents a governance proposal that replaces the state of an
+// expired or frozen client (subject) with the state of an active client (substitute)
+type RecoverClientProposal struct {
+	Messages []RecoverClientMsg `json:"messages"`
+	Metadata string             `json:"metadata"`
+	Deposit  string             `json:"deposit"`
+	Title    string             `json:"title"`
+	Summary  string             `json:"summary"`
+}
+
+type RecoverClientMsg struct {
+	Type               string `json:"@type"`
+	SubjectClientID    string `json:"subject_client_id"`
+	SubstituteClientID string `json:"substitute_client_id"`
+	Signer             string `json:"signer"`
+}
+
+type govParamsResponse struct {
+	Params struct {
+		MinDeposit []sdk.Coin `json:"min_deposit"`
+	} `json:"params"`
+}
+
+// CreateSubstituteClient creates a new client on the hub (onHub) or on the rollapp that
+// tracks the counterparty chain of the path. rly stores the new client in the path,
+// the original client ID is restored afterwards so that the channel keeps using the
+// recovered client once the proposal passes
+func CreateSubstituteClient(home, pathName string, onHub bool) (string, error) {
+	relayerHome := relayer.GetHomeDir(home)
+	cfgPath := relayer.GetConfigFilePath(relayerHome)
+
+	var rlyCfg relayer.Config
+	err := rlyCfg.Load(cfgPath)
+	if err != nil {
+		return "", err
+	}
+	p := rlyCfg.GetPathByName(pathName)
+	if p == nil {
+		return "", fmt.Errorf("path %s not found", pathName)
+	}
+
+	src, dst, end := p.Src.ChainID, p.Dst.ChainID, "src"
+	subject := p.Src.ClientID
+	if !onHub {
+		src, dst, end = p.Dst.ChainID, p.Src.ChainID, "dst"
+		subject = p.Dst.ClientID
+	}
+
+	cmd := exec.Command(
+		consts.Executables.Relayer,
+		"tx", "client", src, dst, pathName, "--override",
+		"--home", relayerHome,
+	)
+	_, err = bash.ExecCommandWithStdout(cmd)
+	if err != nil {
+		return "", fmt.Errorf("failed to create substitute client: %w", err)
+	}
+
+	var updated relayer.Config
+	err = updated.Load(cfgPath)
+	if err != nil {
+		return "", err
+	}
+	up := updated.GetPathByName(pathName)
+	if up == nil {
+		return "", fmt.Errorf("path %s not found", pathName)
+	}
+
+	substitute := up.Src.ClientID
+	if !onHub {
+		substitute = up.Dst.ClientID
+	}
+	if substitute == subject {
+		return "", errors.New("the relayer did not create a new client")
+	}
+
+	err = yamlconfig.UpdateNestedYAML(cfgPath, map[string]interface{}{
+		fmt.Sprintf("paths.%s.%s.client-id", pathName, end): subject,
+	})
+	if err != nil {
+		return "", fmt.Errorf(
+			"failed to restore client %s in the relayer config: %w",
+			subject,
+			err,
+		)
+	}
+
+	return substitute, nil
+}
+
+// WriteRecoverClientProposal writes the client recovery proposal to the relayer home
+// and returns the path of the file
+func WriteRecoverClientProposal(
+	home, binary, node, chainID, bech32Prefix, subject, substitute string,
+) (string, error) {
+	signer, err := sdk.Bech32ifyAddressBytes(
+		bech32Prefix,
+		authtypes.NewModuleAddress(govtypes.ModuleName),
+	)
+	if err != nil {
+		return "", err
+	}
+
+	proposal := RecoverClientProposal{
+		Messages: []RecoverClientMsg{
+			{
+				Type:               "/ibc.core.client.v1.MsgRecoverClient",
+				SubjectClientID:    subject,
+				SubstituteClientID: substitute,
+				Signer:             signer,
+			},
+		},
+		Deposit: minGovDeposit(binary, node),
+		Title:   fmt.Sprintf("Recover IBC client %s", subject),
+		Summary: fmt.Sprintf(
+			"Replace the state of the expired client %s with the state of the active client %s",
+			subject,
+			substitute,
+		),
+	}
+
+	j, err := json.MarshalIndent(proposal, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	dir := filepath.Join(relayer.GetHomeDir(home), "proposals")
+	err = os.MkdirAll(dir, 0o755)
+	if err != nil {
+		return "", err
+	}
+
+	fp := filepath.Join(dir, fmt.Sprintf("recover-%s-%s.json", chainID, subject))
+	err = os.WriteFile(fp, j, 0o644)
+	if err != nil {
+		return "", err
+	}
+
+	return fp, nil
+}
+
+// minGovDeposit returns the minimum deposit of a governance proposal, an empty string
+// is returned when the parameters can't be queried so that the user fills it in
+func minGovDeposit(binary, node string) string {
+	cmd := exec.Command(binary, "q", "gov", "params", "--node", node, "-o", "json")
+	out, err := bash.ExecCommandWithStdout(cmd)
+	if err != nil {
+		return ""
+	}
+
+	var resp govParamsResponse
+	err = json.Unmarshal(out.Bytes(), &resp)
+	if err != nil || len(resp.Params.MinDeposit) == 0 {
+		return ""
+	}
+
+	return sdk.NewCoins(resp.Params.MinDeposit...).String()
+}
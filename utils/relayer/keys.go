@@ -0,0 +1,287 @@
+package relayer
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+
+	cosmossdkmath "cosmossdk.io/math"
+	cosmossdktypes "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/relayer"
+	"github.com/dymensionxyz/roller/utils/bash"
+	"github.com/dymensionxyz/roller/utils/keys"
+	"github.com/dymensionxyz/roller/utils/tx"
+)
+
+// rotationGasLimit is the gas limit of the transfer from the old to the new relayer key
+const rotationGasLimit = 200000
+
+// KeyRotation replaces the relayer key of a chain. The new key is created under a
+// temporary name and renamed to the original name once the old key is moved aside,
+// so that the rest of roller keeps referring to the relayer keys by their usual names
+type KeyRotation struct {
+	ChainID   string
+	RPC       string
+	Denom     string
+	GasPrices string
+	KeyConfig keys.KeyConfig
+
+	OldAddress string
+	NewKey     *keys.KeyInfo
+	// Resumed is set when the new key was left by an interrupted rotation and reused
+	Resumed bool
+}
+
+func NewKeyRotation(
+	kc keys.KeyConfig,
+	chainID, rpc, denom, gasPrices string,
+) (*KeyRotation, error) {
+	old, err := keys.GetRelayerAddressInfo(kc, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve the current relayer key: %w", err)
+	}
+
+	return &KeyRotation{
+		ChainID:    chainID,
+		RPC:        rpc,
+		Denom:      denom,
+		GasPrices:  gasPrices,
+		KeyConfig:  kc,
+		OldAddress: old.Address,
+	}, nil
+}
+
+func (kr *KeyRotation) tmpKeyConfig() keys.KeyConfig {
+	kc := kr.KeyConfig
+	kc.ID = fmt.Sprintf("%s-rotated", kr.KeyConfig.ID)
+	return kc
+}
+
+// CreateKey creates the new relayer key under the temporary name. A key left under the
+// temporary name by an interrupted rotation is reused so that its funds aren't stranded
+func (kr *KeyRotation) CreateKey() error {
+	tmp := kr.tmpKeyConfig()
+
+	existing, err := keys.GetRelayerAddressInfo(tmp, kr.ChainID)
+	if err == nil {
+		existing.Name = tmp.ID
+		kr.NewKey = existing
+		kr.Resumed = true
+		return nil
+	}
+
+	ki, err := keys.AddRlyKey(tmp, kr.ChainID)
+	if err != nil {
+		return err
+	}
+	kr.NewKey = ki
+
+	return nil
+}
+
+// Fund transfers the balance of the old key, minus the transaction fee, to the new key.
+// When the old key was already drained by an interrupted rotation, the new key is
+// considered funded as long as it holds a balance
+func (kr *KeyRotation) Fund() (*cosmossdktypes.Coin, error) {
+	if kr.NewKey == nil {
+		return nil, errors.New("the new key has to be created first")
+	}
+
+	qc := keys.ChainQueryConfig{
+		Binary: kr.KeyConfig.ChainBinary,
+		Denom:  kr.Denom,
+		RPC:    kr.RPC,
+	}
+	balance, err := keys.QueryBalance(qc, kr.OldAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	fee, err := rotationFee(kr.GasPrices, kr.Denom)
+	if err != nil {
+		return nil, err
+	}
+
+	if balance.Amount.LTE(fee.Amount) {
+		if kr.Resumed {
+			newBalance, err := keys.QueryBalance(qc, kr.NewKey.Address)
+			if err != nil {
+				return nil, err
+			}
+			if newBalance.IsPositive() {
+				zero := cosmossdktypes.NewCoin(kr.Denom, cosmossdkmath.ZeroInt())
+				return &zero, nil
+			}
+		}
+		return nil, fmt.Errorf(
+			"the balance of the old key (%s) doesn't cover the transfer fee (%s)",
+			balance.String(),
+			fee.String(),
+		)
+	}
+	amount := cosmossdktypes.NewCoin(kr.Denom, balance.Amount.Sub(fee.Amount))
+
+	cmd := exec.Command(
+		kr.KeyConfig.ChainBinary,
+		"tx", "bank", "send",
+		kr.KeyConfig.ID, kr.NewKey.Address, amount.String(),
+		"--keyring-backend", string(kr.KeyConfig.KeyringBackend),
+		"--keyring-dir", filepath.Join(kr.KeyConfig.Dir, "keys", kr.ChainID),
+		"--node", kr.RPC,
+		"--chain-id", kr.ChainID,
+		"--gas", fmt.Sprint(rotationGasLimit),
+		"--fees", fee.String(),
+		"-y",
+	)
+	out, err := bash.ExecCommandWithStdout(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	txHash, err := bash.ExtractTxHash(out.String())
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.MonitorTransaction(kr.RPC, txHash)
+	if err != nil {
+		return nil, err
+	}
+
+	return &amount, nil
+}
+
+// Switch makes the new key the one used by the relayer. The old key is renamed to a
+// backup name before the new key takes its name, it's restored when the switch fails and
+// deleted once the new key is in place. The keys are renamed in the keyring so that the
+// mnemonic of the new key is never passed on a command line
+func (kr *KeyRotation) Switch() error {
+	if kr.NewKey == nil {
+		return errors.New("the new key has to be created first")
+	}
+
+	tmp := kr.tmpKeyConfig()
+	backup := fmt.Sprintf("%s-old", kr.KeyConfig.ID)
+
+	err := kr.renameKey(kr.KeyConfig.ID, backup)
+	if err != nil {
+		return fmt.Errorf("failed to back up the old key: %w", err)
+	}
+
+	err = kr.renameKey(tmp.ID, kr.KeyConfig.ID)
+	if err != nil {
+		restoreErr := kr.renameKey(backup, kr.KeyConfig.ID)
+		if restoreErr != nil {
+			return fmt.Errorf(
+				"failed to switch to the new key: %w, the old key is kept as %s: %v",
+				err,
+				backup,
+				restoreErr,
+			)
+		}
+		return fmt.Errorf("failed to switch to the new key: %w", err)
+	}
+
+	err = kr.verifySwitch()
+	if err != nil {
+		return err
+	}
+
+	return rlyKeysCmd("delete", kr.ChainID, backup, "-y", "--home", kr.KeyConfig.Dir)
+}
+
+// verifySwitch checks that the relayer resolves its key name to the new address
+func (kr *KeyRotation) verifySwitch() error {
+	ki, err := keys.GetRelayerAddressInfo(kr.KeyConfig, kr.ChainID)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve the new relayer key: %w", err)
+	}
+	if ki.Address != kr.NewKey.Address {
+		return fmt.Errorf(
+			"the relayer key resolves to %s instead of the new key %s",
+			ki.Address,
+			kr.NewKey.Address,
+		)
+	}
+	return nil
+}
+
+// renameKey renames the key in the relayer keyring of the chain
+func (kr *KeyRotation) renameKey(from, to string) error {
+	cmd := exec.Command(
+		kr.KeyConfig.ChainBinary,
+		"keys", "rename", from, to,
+		"--keyring-backend", string(kr.KeyConfig.KeyringBackend),
+		"--keyring-dir", filepath.Join(kr.KeyConfig.Dir, "keys", kr.ChainID),
+		"-y",
+	)
+	_, err := bash.ExecCommandWithStdout(cmd)
+	return err
+}
+
+func rlyKeysCmd(args ...string) error {
+	cmd := exec.Command(consts.Executables.Relayer, append([]string{"keys"}, args...)...)
+	_, err := bash.ExecCommandWithStdout(cmd)
+	return err
+}
+
+// rotationFee returns the fee of the transfer from the old to the new key based on the
+// gas price configured for the chain in the relayer config
+func rotationFee(gasPrices, denom string) (*cosmossdktypes.Coin, error) {
+	gp, err := cosmossdktypes.ParseDecCoin(gasPrices)
+	if err != nil {
+		return nil, fmt.Errorf("invalid gas price '%s': %w", gasPrices, err)
+	}
+	if gp.Denom != denom {
+		return nil, fmt.Errorf("gas price denom %s doesn't match %s", gp.Denom, denom)
+	}
+
+	amount := gp.Amount.MulInt64(rotationGasLimit).Ceil().TruncateInt()
+	if amount.IsZero() {
+		amount = cosmossdkmath.OneInt()
+	}
+	fee := cosmossdktypes.NewCoin(denom, amount)
+
+	return &fee, nil
+}
+
+// RelayerKeyRotations returns the rotations of the hub and rollapp relayer keys of a path
+func RelayerKeyRotations(
+	rlyCfg *relayer.Config,
+	pathName string,
+	keyConfigs map[string]keys.KeyConfig,
+	raDenom string,
+) ([]*KeyRotation, error) {
+	hd := rlyCfg.HubDataForPath(pathName)
+	raData := rlyCfg.RaDataForPath(pathName)
+	if hd == nil || raData == nil {
+		return nil, fmt.Errorf("path %s not found", pathName)
+	}
+
+	hub, err := NewKeyRotation(
+		keyConfigs[consts.KeysIds.HubRelayer],
+		hd.ID,
+		hd.RpcUrl,
+		consts.Denoms.Hub,
+		rlyCfg.Chains[hd.ID].Value.GasPrices,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	ra, err := NewKeyRotation(
+		keyConfigs[consts.KeysIds.RollappRelayer],
+		raData.ID,
+		raData.RpcUrl,
+		raDenom,
+		rlyCfg.Chains[raData.ID].Value.GasPrices,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*KeyRotation{hub, ra}, nil
+}
@@ -0,0 +1,201 @@
+package relayer
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pterm/pterm"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/relayer"
+	"github.com/dymensionxyz/roller/utils/keys"
+	"github.com/dymensionxyz/roller/utils/rollapp"
+	sequencerutils "github.com/dymensionxyz/roller/utils/sequencer"
+)
+
+// AddRollappPath adds a new path for the rollapp to an existing relayer configuration,
+// the hub of the new path is the hub used by the existing paths
+func AddRollappPath(home, pathName, raID string) (*relayer.Relayer, error) {
+	relayerHome := relayer.GetHomeDir(home)
+
+	var rlyCfg relayer.Config
+	err := rlyCfg.Load(relayer.GetConfigFilePath(relayerHome))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load relayer config, run 'roller relayer setup' first: %w", err)
+	}
+
+	paths := rlyCfg.PathNames()
+	if len(paths) == 0 {
+		return nil, errors.New("no existing relayer paths found, run 'roller relayer setup' first")
+	}
+	if slices.Contains(paths, pathName) {
+		return nil, fmt.Errorf("path %s already exists", pathName)
+	}
+	for _, p := range paths {
+		if rlyCfg.Paths[p].Dst.ChainID == raID {
+			return nil, fmt.Errorf("rollapp %s is already relayed by path %s", raID, p)
+		}
+	}
+
+	hd := rlyCfg.HubDataForPath(paths[0])
+
+	ok, err := rollapp.IsRegistered(raID, *hd)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("%s rollapp not registered on %s", raID, hd.ID)
+	}
+
+	raResp, err := rollapp.GetMetadataFromChain(raID, *hd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch rollapp information from hub: %w", err)
+	}
+
+	raRpc, err := sequencerutils.GetRpcEndpointFromChain(raID, *hd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve rollapp rpc endpoint: %w", err)
+	}
+	raData := consts.RollappData{
+		ID:     raID,
+		RpcUrl: fmt.Sprintf("%s:%d", strings.TrimSuffix(raRpc, "/"), 443),
+		Denom:  raResp.Rollapp.GenesisInfo.NativeDenom.Base,
+	}
+
+	rly := relayer.NewRelayer(home, raData, *hd)
+	rly.SetPath(pathName)
+
+	if _, ok := rlyCfg.Chains[raID]; !ok {
+		pterm.Info.Printf("adding %s to the relayer chains\n", raID)
+		err = relayer.AddRollappChain(
+			relayer.ChainConfig{
+				ID:            raID,
+				RPC:           raData.RpcUrl,
+				Denom:         raData.Denom,
+				AddressPrefix: raResp.Rollapp.GenesisInfo.Bech32Prefix,
+				GasPrices:     "2000000000",
+			}, relayerHome,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to add %s to the relayer: %w", raID, err)
+		}
+
+		err = rly.UpdateRollappChainWithDefaultValues(raID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	kc := keys.KeyConfig{
+		Dir:            relayerHome,
+		ID:             consts.KeysIds.RollappRelayer,
+		ChainBinary:    consts.Executables.RollappEVM,
+		Type:           consts.VMType(strings.ToLower(raResp.Rollapp.VmType)),
+		KeyringBackend: consts.SupportedKeyringBackends.Test,
+	}
+	isPresent, err := keys.IsRlyAddressWithNameInKeyring(kc, raID)
+	if err != nil {
+		return nil, err
+	}
+	if !isPresent {
+		ki, err := keys.AddRlyKey(kc, raID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create rollapp relayer key: %w", err)
+		}
+		ki.Print(keys.WithMnemonic(), keys.WithName())
+	}
+
+	err = relayer.CreateNamedPath(relayerHome, hd.ID, raID, pathName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create relayer path: %w", err)
+	}
+
+	err = populatePathConnection(rly)
+	if err != nil {
+		// nolint: errcheck
+		relayer.DeleteNamedPath(relayerHome, pathName)
+		return nil, err
+	}
+
+	return rly, nil
+}
+
+// populatePathConnection finds the open channel of the rollapp and writes its
+// connection information to the relayer path
+func populatePathConnection(rly *relayer.Relayer) error {
+	raData := rly.Rollapp
+	err := rly.LoadActiveChannel(raData, rly.Hub)
+	if err != nil {
+		if errors.Is(err, relayer.ErrNoOpenChannel) {
+			return fmt.Errorf(
+				"no open channel found for %s, the channel has to be created from the rollapp's sequencer node",
+				raData.ID,
+			)
+		}
+		return err
+	}
+
+	err = rly.ConnectionInfoFromRaConnID(raData, rly.DstConnectionID)
+	if err != nil {
+		return err
+	}
+
+	return rly.UpdatePath()
+}
+
+// RemoveRollappPath removes the path from the relayer configuration, the rollapp chain is
+// removed as well when no other path relays for it
+func RemoveRollappPath(home, pathName string) error {
+	relayerHome := relayer.GetHomeDir(home)
+
+	var rlyCfg relayer.Config
+	err := rlyCfg.Load(relayer.GetConfigFilePath(relayerHome))
+	if err != nil {
+		return fmt.Errorf("failed to load relayer config: %w", err)
+	}
+
+	p := rlyCfg.GetPathByName(pathName)
+	if p == nil {
+		return fmt.Errorf("path %s does not exist", pathName)
+	}
+
+	err = relayer.DeleteNamedPath(relayerHome, pathName)
+	if err != nil {
+		return fmt.Errorf("failed to delete path: %w", err)
+	}
+
+	for _, name := range rlyCfg.PathNames() {
+		if name != pathName && rlyCfg.Paths[name].Dst.ChainID == p.Dst.ChainID {
+			return nil
+		}
+	}
+
+	return relayer.DeleteChain(relayerHome, p.Dst.ChainID)
+}
+
+// SelectPath returns the path name to operate on. The provided name is validated, when
+// it's empty the only path is used or the user is prompted to select one
+func SelectPath(paths []string, name string) (string, error) {
+	switch {
+	case len(paths) == 0:
+		return "", errors.New("no relayer paths found, ensure you've setup the relayer")
+	case name != "" && !slices.Contains(paths, name):
+		return "", fmt.Errorf("path %s not found", name)
+	case name != "":
+		return name, nil
+	case len(paths) == 1:
+		return paths[0], nil
+	}
+
+	selected, err := pterm.DefaultInteractiveSelect.
+		WithDefaultText("select the relayer path").
+		WithOptions(paths).
+		Show()
+	if err != nil {
+		return "", err
+	}
+
+	return selected, nil
+}
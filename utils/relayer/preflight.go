@@ -0,0 +1,393 @@
+package relayer
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"slices"
+
+	"github.com/dymensionxyz/roller/cmd/consts"
+	"github.com/dymensionxyz/roller/relayer"
+	"github.com/dymensionxyz/roller/sequencer"
+	"github.com/dymensionxyz/roller/utils/filesystem"
+	"github.com/dymensionxyz/roller/utils/keys"
+	"github.com/dymensionxyz/roller/utils/roller"
+	sequencerutils "github.com/dymensionxyz/roller/utils/sequencer"
+)
+
+// CheckStatuses are the possible results of a pre-flight check
+var CheckStatuses = struct {
+	Passed  string
+	Warning string
+	Failed  string
+}{
+	Passed:  "passed",
+	Warning: "warning",
+	Failed:  "failed",
+}
+
+// PlanActions describe what the setup does with a resource
+var PlanActions = struct {
+	Create string
+	Reuse  string
+	Update string
+}{
+	Create: "create",
+	Reuse:  "reuse",
+	Update: "update",
+}
+
+// PreflightCheck is the result of a single relayer setup pre-flight check
+type PreflightCheck struct {
+	Name    string
+	Status  string
+	Message string
+}
+
+// PlannedStep is a resource the relayer setup would create, reuse or update
+type PlannedStep struct {
+	Resource string
+	Action   string
+	Details  string
+}
+
+// PreflightReport is the outcome of the relayer setup pre-flight, nothing is sent to
+// the chains while it is collected
+type PreflightReport struct {
+	Checks []PreflightCheck
+	Plan   []PlannedStep
+}
+
+// Passed returns true when none of the checks failed
+func (pr *PreflightReport) Passed() bool {
+	return !slices.ContainsFunc(pr.Checks, func(c PreflightCheck) bool {
+		return c.Status == CheckStatuses.Failed
+	})
+}
+
+func (pr *PreflightReport) check(name, status, format string, a ...any) {
+	pr.Checks = append(pr.Checks, PreflightCheck{
+		Name:    name,
+		Status:  status,
+		Message: fmt.Sprintf(format, a...),
+	})
+}
+
+func (pr *PreflightReport) plan(resource, action, format string, a ...any) {
+	pr.Plan = append(pr.Plan, PlannedStep{
+		Resource: resource,
+		Action:   action,
+		Details:  fmt.Sprintf(format, a...),
+	})
+}
+
+// Preflight runs the checks of 'relayer setup' and reports the IBC resources that would be
+// created or reused, without sending any transaction or writing the relayer config
+func Preflight(
+	home string,
+	rly *relayer.Relayer,
+	rollerData roller.RollappConfig,
+) *PreflightReport {
+	report := &PreflightReport{}
+
+	checkDependencies(report, rollerData)
+	checkRelayerConfig(report, rly)
+	raKey := checkKeys(report, rly, rollerData)
+
+	sp, err := relayer.GetHubStakingParams(rly.Hub)
+	if err != nil {
+		report.check("hub staking params", CheckStatuses.Failed, "%v", err)
+	} else {
+		report.check(
+			"hub staking params",
+			CheckStatuses.Passed,
+			"unbonding time %s",
+			sp.UnbondingTime,
+		)
+	}
+
+	err = rly.LoadActiveChannel(rly.Rollapp, rly.Hub)
+	switch {
+	case err == nil && rly.ChannelReady():
+		planExistingChannel(report, rly)
+		return report
+	case err != nil && !errors.Is(err, relayer.ErrNoOpenChannel):
+		report.check("rollapp channels", CheckStatuses.Failed, "%v", err)
+		return report
+	}
+
+	report.check(
+		"rollapp channels",
+		CheckStatuses.Passed,
+		"no open channel, a new one would be created",
+	)
+
+	canCreate, err := NewIbcConnenctionCanBeCreatedOnCurrentNode(home, rly.Rollapp.ID)
+	if err != nil || !canCreate {
+		report.check(
+			"sequencer node",
+			CheckStatuses.Failed,
+			"new channels can only be created on the sequencer node of %s",
+			rly.Rollapp.ID,
+		)
+		return report
+	}
+	report.check("sequencer node", CheckStatuses.Passed, "running for %s", rly.Rollapp.ID)
+	report.plan(
+		"rollapp block time",
+		PlanActions.Update,
+		"set to 5s while the channel is created, reverted to 1h afterwards",
+	)
+
+	err = relayer.WaitForValidRollappHeight(sequencer.GetInstance(rollerData))
+	if err != nil {
+		report.check("rollapp height", CheckStatuses.Failed, "%v", err)
+	} else {
+		report.check("rollapp height", CheckStatuses.Passed, "rollapp is producing blocks")
+	}
+
+	checkWhitelisting(report, rly, rollerData, raKey)
+	planNewChannel(report, rly)
+
+	return report
+}
+
+func checkDependencies(report *PreflightReport, rollerData roller.RollappConfig) {
+	var missing []string
+	for _, b := range []string{
+		consts.Executables.Relayer,
+		consts.Executables.Dymension,
+		rollerData.RollappBinary,
+	} {
+		_, err := exec.LookPath(b)
+		if err != nil {
+			missing = append(missing, b)
+		}
+	}
+
+	if len(missing) > 0 {
+		report.check(
+			"dependencies",
+			CheckStatuses.Warning,
+			"%v not installed, they are installed by the setup and the checks using them fail",
+			missing,
+		)
+		return
+	}
+	report.check("dependencies", CheckStatuses.Passed, "all binaries are installed")
+}
+
+func checkRelayerConfig(report *PreflightReport, rly *relayer.Relayer) {
+	ok, err := filesystem.DoesFileExist(rly.ConfigFilePath)
+	if err != nil || !ok {
+		report.plan("relayer config", PlanActions.Create, "%s", rly.ConfigFilePath)
+		report.plan("relayer path", PlanActions.Create, "%s", rly.Path)
+		return
+	}
+	report.plan("relayer config", PlanActions.Update, "%s", rly.ConfigFilePath)
+
+	var rlyCfg relayer.Config
+	err = rlyCfg.Load(rly.ConfigFilePath)
+	if err != nil || rlyCfg.GetPathByName(rly.Path) == nil {
+		report.plan("relayer path", PlanActions.Create, "%s", rly.Path)
+		return
+	}
+	report.plan("relayer path", PlanActions.Reuse, "%s", rly.Path)
+}
+
+// checkKeys checks the relayer keys and the balance of the hub key, it returns the
+// address of the rollapp relayer key when it exists
+func checkKeys(
+	report *PreflightReport,
+	rly *relayer.Relayer,
+	rollerData roller.RollappConfig,
+) string {
+	kc := keys.GetRelayerKeysConfig(rollerData)
+
+	var raAddr string
+	raKey, err := keys.GetRelayerAddressInfo(kc[consts.KeysIds.RollappRelayer], rly.Rollapp.ID)
+	if err != nil {
+		report.plan("rollapp relayer key", PlanActions.Create, "%s", consts.KeysIds.RollappRelayer)
+	} else {
+		raAddr = raKey.Address
+		report.plan("rollapp relayer key", PlanActions.Reuse, "%s", raAddr)
+	}
+
+	hubKey, err := keys.GetRelayerAddressInfo(kc[consts.KeysIds.HubRelayer], rly.Hub.ID)
+	if err != nil {
+		report.plan("hub relayer key", PlanActions.Create, "%s", consts.KeysIds.HubRelayer)
+		report.check(
+			"hub relayer balance",
+			CheckStatuses.Warning,
+			"the hub relayer key doesn't exist yet, it has to be funded during the setup",
+		)
+		return raAddr
+	}
+	report.plan("hub relayer key", PlanActions.Reuse, "%s", hubKey.Address)
+
+	balance, err := keys.QueryBalance(
+		keys.ChainQueryConfig{
+			RPC:    rly.Hub.RpcUrl,
+			Denom:  consts.Denoms.Hub,
+			Binary: consts.Executables.Dymension,
+		}, hubKey.Address,
+	)
+	switch {
+	case err != nil:
+		report.check("hub relayer balance", CheckStatuses.Failed, "%v", err)
+	case !balance.Amount.IsPositive():
+		report.check(
+			"hub relayer balance",
+			CheckStatuses.Failed,
+			"%s is not funded",
+			hubKey.Address,
+		)
+	case balance.Amount.LT(relayer.OneDayRelayPrice):
+		report.check(
+			"hub relayer balance",
+			CheckStatuses.Warning,
+			"%s has %s, at least %s%s is recommended",
+			hubKey.Address,
+			balance.String(),
+			relayer.OneDayRelayPrice.String(),
+			consts.Denoms.Hub,
+		)
+	default:
+		report.check("hub relayer balance", CheckStatuses.Passed, "%s", balance.String())
+	}
+
+	return raAddr
+}
+
+func checkWhitelisting(
+	report *PreflightReport,
+	rly *relayer.Relayer,
+	rollerData roller.RollappConfig,
+	raAddr string,
+) {
+	seqAddr, err := sequencerutils.GetSequencerAccountAddress(rollerData)
+	if err != nil {
+		report.check("whitelisting", CheckStatuses.Failed, "sequencer address: %v", err)
+		return
+	}
+	raOpAddr, err := sequencerutils.GetSequencerOperatorAddress(
+		rly.RollerHome,
+		string(rollerData.KeyringBackend),
+	)
+	if err != nil {
+		report.check("whitelisting", CheckStatuses.Failed, "operator address: %v", err)
+		return
+	}
+
+	ws, err := relayer.GetWhitelistStatus(seqAddr, raOpAddr, rly.Hub)
+	if err != nil {
+		report.check("whitelisting", CheckStatuses.Failed, "%v", err)
+		return
+	}
+
+	switch {
+	case raAddr == "":
+		report.check(
+			"whitelisting",
+			CheckStatuses.Passed,
+			"%d relayers whitelisted, the new key would be added to them",
+			len(ws.Hub),
+		)
+		report.plan(
+			"whitelisted relayers",
+			PlanActions.Update,
+			"add the new rollapp relayer key, keeping %d relayers",
+			len(ws.Hub),
+		)
+	case !slices.Contains(ws.Hub, raAddr):
+		report.check(
+			"whitelisting",
+			CheckStatuses.Passed,
+			"%s is not whitelisted, it would be added to the %d whitelisted relayers",
+			raAddr,
+			len(ws.Hub),
+		)
+		report.plan(
+			"whitelisted relayers",
+			PlanActions.Update,
+			"add %s, keeping %d relayers",
+			raAddr,
+			len(ws.Hub),
+		)
+	case !slices.Contains(ws.Rollapp, raAddr):
+		report.check(
+			"whitelisting",
+			CheckStatuses.Warning,
+			"%s is whitelisted on the hub but didn't propagate to the rollapp yet",
+			raAddr,
+		)
+		report.plan("whitelisted relayers", PlanActions.Reuse, "%s", raAddr)
+	default:
+		report.check("whitelisting", CheckStatuses.Passed, "%s is whitelisted", raAddr)
+		report.plan("whitelisted relayers", PlanActions.Reuse, "%s", raAddr)
+	}
+}
+
+func planExistingChannel(report *PreflightReport, rly *relayer.Relayer) {
+	report.check(
+		"rollapp channels",
+		CheckStatuses.Passed,
+		"open channel %s <-> %s",
+		rly.DstChannel,
+		rly.SrcChannel,
+	)
+
+	err := rly.ConnectionInfoFromRaConnID(rly.Rollapp, rly.DstConnectionID)
+	if err != nil {
+		report.check("connection", CheckStatuses.Failed, "%v", err)
+		return
+	}
+
+	report.plan(
+		"clients",
+		PlanActions.Reuse,
+		"hub %s, rollapp %s",
+		rly.SrcClientID,
+		rly.DstClientID,
+	)
+	report.plan(
+		"connection",
+		PlanActions.Reuse,
+		"hub %s, rollapp %s",
+		rly.SrcConnectionID,
+		rly.DstConnectionID,
+	)
+	report.plan("channel", PlanActions.Reuse, "hub %s, rollapp %s", rly.SrcChannel, rly.DstChannel)
+}
+
+func planNewChannel(report *PreflightReport, rly *relayer.Relayer) {
+	raConn, hubConn, err := rly.GetActiveConnections(rly.Rollapp, rly.Hub)
+	if err != nil {
+		report.check("connection", CheckStatuses.Failed, "%v", err)
+		return
+	}
+
+	if raConn != nil && hubConn != nil {
+		report.check(
+			"connection",
+			CheckStatuses.Passed,
+			"open connection %s <-> %s",
+			raConn.ID,
+			hubConn.ID,
+		)
+		report.plan(
+			"clients",
+			PlanActions.Reuse,
+			"hub %s, rollapp %s",
+			hubConn.ClientID,
+			raConn.ClientID,
+		)
+		report.plan("connection", PlanActions.Reuse, "hub %s, rollapp %s", hubConn.ID, raConn.ID)
+	} else {
+		report.check("connection", CheckStatuses.Passed, "no open connection, a new one would be created")
+		report.plan("clients", PlanActions.Create, "on the hub and on the rollapp")
+		report.plan("connection", PlanActions.Create, "between the new clients")
+	}
+
+	report.plan("channel", PlanActions.Create, "transfer channel on the connection")
+}
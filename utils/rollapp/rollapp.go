@@ -19,6 +19,7 @@ import (
 	"github.com/dymensionxyz/roller/utils/filesystem"
 	"github.com/dymensionxyz/roller/utils/keys"
 	"github.com/dymensionxyz/roller/utils/roller"
+	"github.com/dymensionxyz/roller/utils/tracing"
 	"github.com/dymensionxyz/roller/version"
 )
 
@@ -82,7 +83,9 @@ func IsInitialSequencer(addr, raID string, hd consts.HubData) (bool, error) {
 // TODO: most of rollapp utility functions should be tied to an entity
 func IsRegistered(raID string, hd consts.HubData) (bool, error) {
 	cmd := GetShowRollappCmd(raID, hd)
-	_, err := bashutils.ExecCommandWithStdout(cmd)
+	ctx, span := tracing.StartHubQuery("rollapp.IsRegistered", hd.ID, hd.RpcUrl)
+	_, err := bashutils.ExecCommandWithStdoutCtx(ctx, cmd)
+	tracing.End(span, err)
 	if err != nil {
 		if strings.Contains(err.Error(), "NotFound") {
 			return false, errors.New("rollapp not found ")
@@ -135,7 +138,9 @@ func GetCurrentProposerCmd(raID string, hd consts.HubData) *exec.Cmd {
 func GetCurrentProposer(raID string, hd consts.HubData) (string, error) {
 	cmd := GetCurrentProposerCmd(raID, hd)
 
-	out, err := bashutils.ExecCommandWithStdout(cmd)
+	ctx, span := tracing.StartHubQuery("rollapp.GetCurrentProposer", hd.ID, hd.RpcUrl)
+	out, err := bashutils.ExecCommandWithStdoutCtx(ctx, cmd)
+	tracing.End(span, err)
 	if err != nil {
 		return "", err
 	}
@@ -176,7 +181,9 @@ func GetMetadataFromChain(
 	var raResponse ShowRollappResponse
 	getRollappCmd := GetRollappCmd(raID, hd)
 
-	out, err := bash.ExecCommandWithStdout(getRollappCmd)
+	ctx, span := tracing.StartHubQuery("rollapp.GetMetadataFromChain", hd.ID, hd.RpcUrl)
+	out, err := bash.ExecCommandWithStdoutCtx(ctx, getRollappCmd)
+	tracing.End(span, err)
 	if err != nil {
 		return nil, err
 	}
@@ -201,6 +208,7 @@ func PopulateRollerConfigWithRaMetadataFromChain(
 
 	vmt, _ := consts.ToVMType(strings.ToLower(raResponse.Rollapp.VmType))
 	var kb consts.SupportedKeyringBackend
+	var relayerPolicy roller.RelayerPolicy
 
 	rollerConfigExists, err := filesystem.DoesFileExist(roller.GetConfigPath(home))
 	if err != nil {
@@ -216,6 +224,7 @@ func PopulateRollerConfigWithRaMetadataFromChain(
 			pterm.Error.Printf("failed to load roller config: %v\n", err)
 			return nil, err
 		}
+		relayerPolicy = rollerData.Relayer
 		if rollerData.KeyringBackend == "" {
 			pterm.Info.Println(
 				"keyring backend not set in roller config, retrieving it from environment",
@@ -280,6 +289,7 @@ func PopulateRollerConfigWithRaMetadataFromChain(
 		Bech32Prefix:         raResponse.Rollapp.GenesisInfo.Bech32Prefix,
 		BaseDenom:            baseDenom,
 		MinGasPrices:         "0",
+		Relayer:              relayerPolicy,
 	}
 
 	return &cfg, nil
@@ -289,7 +299,9 @@ func Show(raID string, hd consts.HubData) (*ShowRollappResponse, error) {
 	getRaCmd := GetRollappCmd(raID, hd)
 	var raResponse ShowRollappResponse
 
-	out, err := bashutils.ExecCommandWithStdout(getRaCmd)
+	ctx, span := tracing.StartHubQuery("rollapp.Show", hd.ID, hd.RpcUrl)
+	out, err := bashutils.ExecCommandWithStdoutCtx(ctx, getRaCmd)
+	tracing.End(span, err)
 	if err != nil {
 		return nil, err
 	}
@@ -308,6 +320,10 @@ type RaParams struct {
 
 type MinSequencerBond struct {
 	MinSequencerBondGlobal cosmossdktypes.Coin `json:"min_sequencer_bond_global"`
+	// LivenessSlashBlocks is the number of hub blocks without a state update
+	// after which the proposer is slashed
+	LivenessSlashBlocks   string `json:"liveness_slash_blocks"`
+	LivenessSlashInterval string `json:"liveness_slash_interval"`
 }
 
 func GetRollappParams(hd consts.HubData) (*RaParams, error) {
@@ -325,7 +341,9 @@ func GetRollappParams(hd consts.HubData) (*RaParams, error) {
 		"json",
 	)
 
-	out, err := bash.ExecCommandWithStdout(cmd)
+	ctx, span := tracing.StartHubQuery("rollapp.GetRollappParams", hd.ID, hd.RpcUrl)
+	out, err := bash.ExecCommandWithStdoutCtx(ctx, cmd)
+	tracing.End(span, err)
 	if err != nil {
 		return nil, err
 	}
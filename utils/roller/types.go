@@ -30,8 +30,32 @@ type RollappConfig struct {
 	HubData     consts.HubData    `toml:"HubData"`
 	DA          consts.DaData     `toml:"DA"`
 	HealthAgent HealthAgentConfig `toml:"HealthAgent"`
+	Relayer     RelayerPolicy     `toml:"Relayer"`
 }
 
 type HealthAgentConfig struct {
+	// Enabled allows the health agent to take actions (e.g. swap the DA node),
+	// the checks are always executed
 	Enabled bool `toml:"enabled"`
+	// ListenAddress is the address the health agent status API listens on
+	ListenAddress string `toml:"listen_address"`
+}
+
+// RelayerPolicy controls the fees and batching of the relayer, it's applied to the rly
+// config by 'roller relayer update'. Unset values fall back to the relayer defaults
+type RelayerPolicy struct {
+	// GasAdjustment multiplies the estimated gas of the relayer transactions, keyed by chain ID
+	GasAdjustment map[string]float64 `toml:"gas_adjustment"`
+	// GasPrices is the gas price paid by the relayer (e.g. 20000000000adym), keyed by chain ID
+	GasPrices map[string]string `toml:"gas_prices"`
+	// MaxMsgs is the maximum number of messages in a single relayer transaction
+	MaxMsgs int `toml:"max_msgs"`
+	// FlushInterval is the interval between the flushes of the pending packets (e.g. 15m)
+	FlushInterval string `toml:"flush_interval"`
+	// MinPacketValue is the smallest transfer amount that is relayed, keyed by denom.
+	// It's only enforced by the native relaying engine
+	MinPacketValue map[string]string `toml:"min_packet_value"`
+	// Engine is the relaying engine (rly or native) used by 'roller relayer start' and the
+	// relayer service, rly when unset
+	Engine string `toml:"engine"`
 }
@@ -23,6 +23,7 @@ import (
 	"github.com/dymensionxyz/roller/utils/keys"
 	"github.com/dymensionxyz/roller/utils/rollapp"
 	"github.com/dymensionxyz/roller/utils/roller"
+	"github.com/dymensionxyz/roller/utils/tracing"
 	"github.com/dymensionxyz/roller/utils/tx"
 )
 
@@ -348,7 +349,13 @@ func RegisteredRollappSequencersOnHub(
 	var seq Sequencers
 	cmd := getShowSequencerByRollappCmd(raID, hd)
 
-	out, err := bash.ExecCommandWithStdout(cmd)
+	ctx, span := tracing.StartHubQuery(
+		"sequencer.RegisteredRollappSequencersOnHub",
+		hd.ID,
+		hd.RpcUrl,
+	)
+	out, err := bash.ExecCommandWithStdoutCtx(ctx, cmd)
+	tracing.End(span, err)
 	if err != nil {
 		return nil, err
 	}
@@ -392,7 +399,9 @@ func GetMetadata(
 		"--node", hd.RpcUrl, "-o", "json", "--chain-id", hd.ID,
 	)
 
-	out, err := bash.ExecCommandWithStdout(cmd)
+	ctx, span := tracing.StartHubQuery("sequencer.GetMetadata", hd.ID, hd.RpcUrl)
+	out, err := bash.ExecCommandWithStdoutCtx(ctx, cmd)
+	tracing.End(span, err)
 	if err != nil {
 		return nil, err
 	}
@@ -520,7 +529,9 @@ func getShowSequencerCmd(addr string, hd consts.HubData) *exec.Cmd {
 func showSequencer(addr string, hd consts.HubData) (*ShowSequencerResponse, error) {
 	c := getShowSequencerCmd(addr, hd)
 	var GetSequencerResponse ShowSequencerResponse
-	out, err := bash.ExecCommandWithStdout(c)
+	ctx, span := tracing.StartHubQuery("sequencer.ShowSequencer", hd.ID, hd.RpcUrl)
+	out, err := bash.ExecCommandWithStdoutCtx(ctx, c)
+	tracing.End(span, err)
 	if err != nil {
 		return nil, err
 	}
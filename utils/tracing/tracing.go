@@ -0,0 +1,263 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/dymensionxyz/roller/version"
+)
+
+const tracerName = "github.com/dymensionxyz/roller"
+
+var Exporters = struct {
+	None string
+	OTLP string
+	File string
+}{
+	None: "",
+	OTLP: "otlp",
+	File: "file",
+}
+
+// Config represents the tracing configuration, when Exporter is empty tracing
+// is disabled and all spans are no-ops
+type Config struct {
+	Exporter string
+	// Endpoint is the OTLP/HTTP collector endpoint (host:port), when empty the standard
+	// OTEL_EXPORTER_OTLP_* environment variables are respected
+	Endpoint string
+	// FilePath is the file the spans are written to as JSON when using the file exporter
+	FilePath string
+}
+
+var (
+	mu       sync.RWMutex
+	rootCtx  = context.Background()
+	rootSpan trace.Span
+	shutdown = func(context.Context) error { return nil }
+)
+
+// DefaultFilePath returns the default location of the JSON trace file
+func DefaultFilePath(home string) string {
+	return filepath.Join(home, "roller-traces.json")
+}
+
+// Init configures the global tracer provider and starts the root span for the
+// roller command that is being executed
+func Init(cfg Config, command string) error {
+	var exporter sdktrace.SpanExporter
+	var processor sdktrace.SpanProcessor
+
+	switch cfg.Exporter {
+	case Exporters.None:
+		return nil
+	case Exporters.OTLP:
+		opts := []otlptracehttp.Option{}
+		if cfg.Endpoint != "" {
+			opts = append(opts, otlptracehttp.WithEndpoint(cfg.Endpoint), otlptracehttp.WithInsecure())
+		}
+		e, err := otlptracehttp.New(context.Background(), opts...)
+		if err != nil {
+			return fmt.Errorf("failed to create otlp exporter: %w", err)
+		}
+		exporter = e
+		processor = sdktrace.NewBatchSpanProcessor(exporter)
+	case Exporters.File:
+		err := os.MkdirAll(filepath.Dir(cfg.FilePath), 0o755)
+		if err != nil {
+			return err
+		}
+		// nolint:gosec
+		f, err := os.OpenFile(cfg.FilePath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+		if err != nil {
+			return fmt.Errorf("failed to open trace file: %w", err)
+		}
+		e, err := stdouttrace.New(stdouttrace.WithWriter(f))
+		if err != nil {
+			return fmt.Errorf("failed to create file exporter: %w", err)
+		}
+		exporter = e
+		// spans are written synchronously so that they are not lost when roller
+		// exits without calling Shutdown (e.g. os.Exit or a killed service)
+		processor = sdktrace.NewSimpleSpanProcessor(exporter)
+	default:
+		return fmt.Errorf(
+			"unsupported trace exporter '%s', supported exporters: %s, %s",
+			cfg.Exporter,
+			Exporters.OTLP,
+			Exporters.File,
+		)
+	}
+
+	res := resource.NewSchemaless(
+		semconv.ServiceName("roller"),
+		semconv.ServiceVersion(version.BuildVersion),
+	)
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(processor),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(tp)
+
+	ctx, span := tp.Tracer(tracerName).Start(context.Background(), command)
+
+	mu.Lock()
+	rootCtx = ctx
+	rootSpan = span
+	shutdown = tp.Shutdown
+	mu.Unlock()
+
+	return nil
+}
+
+// Shutdown ends the root span and flushes the pending spans to the exporter
+func Shutdown() error {
+	mu.RLock()
+	span := rootSpan
+	sd := shutdown
+	mu.RUnlock()
+
+	if span != nil {
+		span.End()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return sd(ctx)
+}
+
+// Context returns the context of the root span, it is used as the parent for spans
+// started by helpers that don't receive a context
+func Context() context.Context {
+	mu.RLock()
+	defer mu.RUnlock()
+	return rootCtx
+}
+
+// Start starts a new span as a child of the span in ctx
+func Start(
+	ctx context.Context,
+	name string,
+	attrs ...attribute.KeyValue,
+) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// StartHubQuery starts a span for a query or transaction against the Dymension hub
+func StartHubQuery(name, hubID, rpc string) (context.Context, trace.Span) {
+	return Start(
+		Context(),
+		name,
+		attribute.String("hub.id", hubID),
+		attribute.String("hub.rpc", rpc),
+	)
+}
+
+// StartChainQuery starts a span for a query or transaction against a chain other than
+// the hub, e.g. a rollapp
+func StartChainQuery(name, chainID, rpc string) (context.Context, trace.Span) {
+	return Start(
+		Context(),
+		name,
+		attribute.String("chain.id", chainID),
+		attribute.String("chain.rpc", rpc),
+	)
+}
+
+// StartCommand starts a span for an external command executed by roller, the argument
+// values are redacted and only the subcommand and the flag names are recorded, so
+// secrets passed as arguments (e.g. a mnemonic) never reach the exporter
+func StartCommand(ctx context.Context, cmd *exec.Cmd) (trace.Span, time.Time) {
+	_, span := Start(
+		ctx,
+		commandSpanName(cmd),
+		attribute.String("process.command", cmd.Path),
+		attribute.String("process.command_line", redactedCommandLine(cmd)),
+	)
+
+	return span, time.Now()
+}
+
+// EndCommand records the duration and exit code of the command and ends the span
+func EndCommand(span trace.Span, start time.Time, cmd *exec.Cmd, err error) {
+	span.SetAttributes(attribute.Int64("process.duration_ms", time.Since(start).Milliseconds()))
+
+	exitCode := 0
+	if cmd.ProcessState != nil {
+		exitCode = cmd.ProcessState.ExitCode()
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		exitCode = exitErr.ExitCode()
+	}
+	span.SetAttributes(attribute.Int("process.exit_code", exitCode))
+
+	End(span, err)
+}
+
+// End records the error, if any, and ends the span
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+var subcommandRegex = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+// subcommandArgs returns the number of leading arguments that are subcommands
+func subcommandArgs(cmd *exec.Cmd) int {
+	n := 0
+	for _, a := range cmd.Args[1:] {
+		if n == 3 || !subcommandRegex.MatchString(a) {
+			break
+		}
+		n++
+	}
+	return n
+}
+
+// commandSpanName returns the binary name followed by the leading subcommands,
+// e.g. 'dymd q rollapp show'
+func commandSpanName(cmd *exec.Cmd) string {
+	parts := append([]string{filepath.Base(cmd.Path)}, cmd.Args[1:1+subcommandArgs(cmd)]...)
+	return strings.Join(parts, " ")
+}
+
+// redactedCommandLine returns the command line with the values of the arguments and the
+// flags replaced by a placeholder, e.g. 'dymd q rollapp show *** --node ***'
+func redactedCommandLine(cmd *exec.Cmd) string {
+	parts := []string{commandSpanName(cmd)}
+	for _, a := range cmd.Args[1+subcommandArgs(cmd):] {
+		if !strings.HasPrefix(a, "-") {
+			parts = append(parts, "***")
+			continue
+		}
+		flag, _, hasValue := strings.Cut(a, "=")
+		if hasValue {
+			flag += "=***"
+		}
+		parts = append(parts, flag)
+	}
+
+	return strings.Join(parts, " ")
+}
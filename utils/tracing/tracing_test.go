@@ -0,0 +1,62 @@
+package tracing
+
+import (
+	"os/exec"
+	"testing"
+)
+
+func TestRedactedCommandLine(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		wantName string
+		wantLine string
+	}{
+		{
+			name:     "binary only",
+			args:     []string{"dymd"},
+			wantName: "dymd",
+			wantLine: "dymd",
+		},
+		{
+			name:     "subcommands and values",
+			args:     []string{"dymd", "q", "rollapp", "show", "rollapp_1-1", "--node", "http://x"},
+			wantName: "dymd q rollapp show",
+			wantLine: "dymd q rollapp show *** --node ***",
+		},
+		{
+			name:     "at most three subcommands",
+			args:     []string{"dymd", "tx", "eibc", "fulfill-order", "order", "10"},
+			wantName: "dymd tx eibc fulfill-order",
+			wantLine: "dymd tx eibc fulfill-order *** ***",
+		},
+		{
+			name:     "flag with inline value",
+			args:     []string{"rly", "tx", "flush", "--home=/root/.relayer", "--json"},
+			wantName: "rly tx flush",
+			wantLine: "rly tx flush --home=*** --json",
+		},
+		{
+			name: "mnemonic argument",
+			args: []string{
+				"dymd", "keys", "add", "key", "--recover", "abandon abandon about",
+			},
+			wantName: "dymd keys add key",
+			wantLine: "dymd keys add key --recover ***",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := exec.Command(tt.args[0], tt.args[1:]...)
+			cmd.Path = tt.args[0]
+
+			if got := commandSpanName(cmd); got != tt.wantName {
+				t.Fatalf("expected %q, got %q", tt.wantName, got)
+			}
+			if got := redactedCommandLine(cmd); got != tt.wantLine {
+				t.Fatalf("expected %q, got %q", tt.wantLine, got)
+			}
+		})
+	}
+}